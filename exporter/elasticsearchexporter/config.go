@@ -44,7 +44,9 @@ type Config struct {
 	// NumWorkers configures the number of workers publishing bulk requests.
 	NumWorkers int `mapstructure:"num_workers"`
 
-	// Index configures the index, index alias, or data stream name events should be indexed in.
+	// Index configures the index, index alias, or data stream name log events should be indexed in.
+	// The name can contain date formats like %{+yyyy.MM.dd}, which are replaced with the
+	// timestamp of the event.
 	//
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/indices.html
 	// https://www.elastic.co/guide/en/elasticsearch/reference/current/data-streams.html
@@ -52,6 +54,19 @@ type Config struct {
 	// This setting is required.
 	Index string `mapstructure:"index"`
 
+	// TracesIndex configures the index, index alias, or data stream name spans should be indexed in.
+	// The name can contain date formats like Index.
+	//
+	// This setting is required.
+	TracesIndex string `mapstructure:"traces_index"`
+
+	// DynamicIndex configures the parts of the index name taken from the event attributes.
+	DynamicIndex DynamicIndexSettings `mapstructure:"dynamic_index"`
+
+	// DataStream configures indexing into data streams following the Elastic data stream
+	// naming scheme. If enabled, Index, TracesIndex and DynamicIndex are not used.
+	DataStream DataStreamSettings `mapstructure:"data_stream"`
+
 	// Pipeline configures the ingest node pipeline name that should be used to process the
 	// events.
 	//
@@ -144,6 +159,38 @@ type RetrySettings struct {
 	MaxInterval time.Duration `mapstructure:"max_interval"`
 }
 
+// DynamicIndexSettings defines the index name prefix and suffix taken from
+// the event attributes. Record attributes take precedence over resource
+// attributes. If the event has none of the attributes, the index name isn't
+// changed.
+type DynamicIndexSettings struct {
+	// PrefixAttribute is the name of the attribute whose value is prepended to the index name.
+	PrefixAttribute string `mapstructure:"prefix_attribute"`
+
+	// SuffixAttribute is the name of the attribute whose value is appended to the index name.
+	SuffixAttribute string `mapstructure:"suffix_attribute"`
+
+	// Separator is put between the index name and its prefix or suffix.
+	Separator string `mapstructure:"separator"`
+}
+
+// DataStreamSettings defines settings for indexing into data streams named
+// <type>-<dataset>-<namespace>. The type is "logs" for logs and "traces" for
+// spans. The data_stream.dataset and data_stream.namespace attributes of an
+// event take precedence over the configured dataset and namespace.
+//
+// https://www.elastic.co/guide/en/fleet/current/data-streams.html#data-streams-naming-scheme
+type DataStreamSettings struct {
+	// Enabled routes the events into data streams.
+	Enabled bool `mapstructure:"enabled"`
+
+	// Dataset describes the ingested data and its structure.
+	Dataset string `mapstructure:"dataset"`
+
+	// Namespace is a user-configurable arbitrary grouping, such as an environment.
+	Namespace string `mapstructure:"namespace"`
+}
+
 type MappingsSettings struct {
 	// Mode configures the field mappings.
 	Mode string `mapstructure:"mode"`
@@ -172,6 +219,10 @@ var (
 	errConfigNoEndpoint    = errors.New("endpoints or cloudid must be specified")
 	errConfigEmptyEndpoint = errors.New("endpoints must not include empty entries")
 	errConfigNoIndex       = errors.New("index must be specified")
+	errConfigNoTracesIndex = errors.New("traces_index must be specified")
+
+	errConfigDataStreamNoName       = errors.New("data_stream dataset and namespace must be specified")
+	errConfigDataStreamDynamicIndex = errors.New("dynamic_index can't be used together with data_stream")
 )
 
 func (m MappingMode) String() string {
@@ -221,6 +272,26 @@ func (cfg *Config) Validate() error {
 		return errConfigNoIndex
 	}
 
+	if cfg.TracesIndex == "" {
+		return errConfigNoTracesIndex
+	}
+
+	if err := validateIndexTemplate("index", cfg.Index); err != nil {
+		return err
+	}
+	if err := validateIndexTemplate("traces_index", cfg.TracesIndex); err != nil {
+		return err
+	}
+
+	if cfg.DataStream.Enabled {
+		if cfg.DataStream.Dataset == "" || cfg.DataStream.Namespace == "" {
+			return errConfigDataStreamNoName
+		}
+		if cfg.DynamicIndex.PrefixAttribute != "" || cfg.DynamicIndex.SuffixAttribute != "" {
+			return errConfigDataStreamDynamicIndex
+		}
+	}
+
 	if _, ok := mappingModes[cfg.Mapping.Mode]; !ok {
 		return fmt.Errorf("unknown mapping mode %v", cfg.Mapping.Mode)
 	}
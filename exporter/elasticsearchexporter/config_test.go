@@ -49,7 +49,16 @@ func TestLoadConfig(t *testing.T) {
 		Endpoints:        []string{"https://elastic.example.com:9200"},
 		CloudID:          "TRNMxjXlNJEt",
 		Index:            "myindex",
+		TracesIndex:      "mytracesindex-%{+yyyy.MM.dd}",
 		Pipeline:         "mypipeline",
+		DynamicIndex: DynamicIndexSettings{
+			PrefixAttribute: "service.name",
+			Separator:       "_",
+		},
+		DataStream: DataStreamSettings{
+			Dataset:   "generic",
+			Namespace: "default",
+		},
 		HTTPClientSettings: HTTPClientSettings{
 			Authentication: AuthenticationSettings{
 				User:     "elastic",
@@ -42,7 +42,7 @@ type esBulkIndexerResponseItem = esutil7.BulkIndexerResponseItem
 type elasticsearchExporter struct {
 	logger *zap.Logger
 
-	index       string
+	index       *indexResolver
 	maxAttempts int
 
 	client      *esClientCurrent
@@ -54,11 +54,16 @@ var retryOnStatus = []int{500, 502, 503, 504, 429}
 
 const createAction = "create"
 
-func newExporter(logger *zap.Logger, cfg *Config) (*elasticsearchExporter, error) {
+func newExporter(logger *zap.Logger, cfg *Config, index string, dataStreamType string) (*elasticsearchExporter, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
 
+	indexResolver, err := newIndexResolver(cfg, index, dataStreamType)
+	if err != nil {
+		return nil, err
+	}
+
 	client, err := newElasticsearchClient(logger, cfg)
 	if err != nil {
 		return nil, err
@@ -75,14 +80,14 @@ func newExporter(logger *zap.Logger, cfg *Config) (*elasticsearchExporter, error
 	}
 
 	// TODO: Apply encoding and field mapping settings.
-	model := &encodeModel{dedup: true, dedot: false}
+	model := &encodeModel{dedup: true, dedot: false, mode: mappingModes[cfg.Mapping.Mode]}
 
 	return &elasticsearchExporter{
 		logger:      logger,
 		client:      client,
 		bulkIndexer: bulkIndexer,
 
-		index:       cfg.Index,
+		index:       indexResolver,
 		maxAttempts: maxAttempts,
 		model:       model,
 	}, nil
@@ -122,13 +127,48 @@ func (e *elasticsearchExporter) pushLogRecord(ctx context.Context, resource pdat
 	if err != nil {
 		return fmt.Errorf("Failed to encode log event: %w", err)
 	}
-	return e.pushEvent(ctx, document)
+	index := e.index.resolve(resource.Attributes(), record.Attributes(), record.Timestamp())
+	return e.pushEvent(ctx, index, document)
+}
+
+func (e *elasticsearchExporter) pushTracesData(ctx context.Context, td pdata.Traces) error {
+	var errs []error
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource := rs.Resource()
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if err := e.pushTraceRecord(ctx, resource, spans.At(k)); err != nil {
+					if cerr := ctx.Err(); cerr != nil {
+						return cerr
+					}
+
+					errs = append(errs, err)
+				}
+			}
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+func (e *elasticsearchExporter) pushTraceRecord(ctx context.Context, resource pdata.Resource, span pdata.Span) error {
+	document, err := e.model.encodeSpan(resource, span)
+	if err != nil {
+		return fmt.Errorf("Failed to encode trace record: %w", err)
+	}
+	index := e.index.resolve(resource.Attributes(), span.Attributes(), span.StartTimestamp())
+	return e.pushEvent(ctx, index, document)
 }
 
-func (e *elasticsearchExporter) pushEvent(ctx context.Context, document []byte) error {
+func (e *elasticsearchExporter) pushEvent(ctx context.Context, index string, document []byte) error {
 	attempts := 1
 	body := bytes.NewReader(document)
-	item := esBulkIndexerItem{Action: createAction, Index: e.index, Body: body}
+	item := esBulkIndexerItem{Action: createAction, Index: index, Body: body}
 
 	// Setup error handler. The handler handles the per item response status based on the
 	// selective ACKing in the bulk response.
@@ -27,6 +27,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
 )
@@ -89,6 +90,29 @@ func TestExporter_New(t *testing.T) {
 			}),
 			want: failWithMessage("cannot parse CloudID"),
 		},
+		"fail with invalid index date format": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.Index = "logs-%{+yyyy.WW}"
+			}),
+			want: failWithMessage("unsupported date format"),
+		},
+		"fail with dynamic index and data stream": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.DataStream.Enabled = true
+				cfg.DynamicIndex.PrefixAttribute = "service.name"
+			}),
+			want: failWith(errConfigDataStreamDynamicIndex),
+		},
+		"fail with data stream without namespace": {
+			config: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"test:9200"}
+				cfg.DataStream.Enabled = true
+				cfg.DataStream.Namespace = ""
+			}),
+			want: failWith(errConfigDataStreamNoName),
+		},
 		"fail if endpoint and cloudid are set": {
 			config: withDefaultConfig(func(cfg *Config) {
 				cfg.Endpoints = []string{"test:9200"}
@@ -119,7 +143,7 @@ func TestExporter_New(t *testing.T) {
 				os.Setenv(k, v)
 			}
 
-			exporter, err := newExporter(zap.NewNop(), test.config)
+			exporter, err := newExporter(zap.NewNop(), test.config, test.config.Index, dataStreamTypeLogs)
 			if exporter != nil {
 				defer func() {
 					require.NoError(t, exporter.Shutdown(context.TODO()))
@@ -308,8 +332,193 @@ func TestExporter_PushEvent(t *testing.T) {
 	})
 }
 
+func TestExporter_PushTracesData(t *testing.T) {
+	newTraces := func() pdata.Traces {
+		traces := pdata.NewTraces()
+		rs := traces.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", "checkout")
+		span := rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+		span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+		span.SetName("GET /cart")
+		span.SetKind(pdata.SpanKindServer)
+		span.SetStartTimestamp(pdata.NewTimestampFromTime(time.Unix(100, 0)))
+		span.SetEndTimestamp(pdata.NewTimestampFromTime(time.Unix(100, int64(5*time.Millisecond))))
+		span.Status().SetCode(pdata.StatusCodeError)
+		span.Status().SetMessage("boom")
+		span.Attributes().InsertString("http.method", "GET")
+		event := span.Events().AppendEmpty()
+		event.SetName("exception")
+		event.SetTimestamp(pdata.NewTimestampFromTime(time.Unix(100, int64(time.Millisecond))))
+		event.Attributes().InsertString("exception.type", "io.EOF")
+		return traces
+	}
+
+	tests := map[string]struct {
+		mode string
+		want map[string]interface{}
+	}{
+		"none": {
+			mode: "none",
+			want: map[string]interface{}{
+				"@timestamp":             "1970-01-01T00:01:40.000000000Z",
+				"EndTimestamp":           "1970-01-01T00:01:40.005000000Z",
+				"Duration":               float64(5 * time.Millisecond),
+				"TraceId":                "0102030405060708090a0b0c0d0e0f10",
+				"SpanId":                 "0102030405060708",
+				"Name":                   "GET /cart",
+				"Kind":                   "SPAN_KIND_SERVER",
+				"TraceStatus":            "STATUS_CODE_ERROR",
+				"TraceStatusDescription": "boom",
+				"Attributes.http.method": "GET",
+				"Events": []interface{}{map[string]interface{}{
+					"Name":      "exception",
+					"Timestamp": "1970-01-01T00:01:40.001000000Z",
+					"Attributes": map[string]interface{}{
+						"exception": map[string]interface{}{"type": "io.EOF"},
+					},
+				}},
+				"Resource.service.name": "checkout",
+			},
+		},
+		"ecs": {
+			mode: "ecs",
+			want: map[string]interface{}{
+				"@timestamp":             "1970-01-01T00:01:40.000000000Z",
+				"event.end":              "1970-01-01T00:01:40.005000000Z",
+				"event.duration":         float64(5 * time.Millisecond),
+				"event.outcome":          "failure",
+				"trace.id":               "0102030405060708090a0b0c0d0e0f10",
+				"span.id":                "0102030405060708",
+				"span.name":              "GET /cart",
+				"span.kind":              "SPAN_KIND_SERVER",
+				"error.message":          "boom",
+				"Attributes.http.method": "GET",
+				"Events": []interface{}{map[string]interface{}{
+					"Name":      "exception",
+					"Timestamp": "1970-01-01T00:01:40.001000000Z",
+					"Attributes": map[string]interface{}{
+						"exception": map[string]interface{}{"type": "io.EOF"},
+					},
+				}},
+				"Resource.service.name": "checkout",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := newBulkRecorder()
+			server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+				rec.Record(docs)
+				return itemsAllOK(docs)
+			})
+
+			cfg := withTestExporterConfig(func(cfg *Config) {
+				cfg.Mapping.Mode = test.mode
+			})(server.URL)
+			exporter, err := newExporter(zaptest.NewLogger(t), cfg, cfg.TracesIndex, dataStreamTypeTraces)
+			require.NoError(t, err)
+			t.Cleanup(func() { exporter.Shutdown(context.TODO()) })
+
+			require.NoError(t, exporter.pushTracesData(context.TODO(), newTraces()))
+			rec.WaitItems(1)
+
+			item := rec.Items()[0]
+			var action map[string]struct {
+				Index string `json:"_index"`
+			}
+			require.NoError(t, json.Unmarshal(item.Action, &action))
+			assert.Equal(t, "traces-generic-default", action[createAction].Index)
+
+			var doc map[string]interface{}
+			require.NoError(t, json.Unmarshal(item.Document, &doc))
+			assert.Equal(t, test.want, doc)
+		})
+	}
+}
+
+func TestExporter_PushLogsData_Index(t *testing.T) {
+	newLogs := func() pdata.Logs {
+		logs := pdata.NewLogs()
+		rl := logs.ResourceLogs().AppendEmpty()
+		rl.Resource().Attributes().InsertString("service.name", "Checkout")
+		records := rl.InstrumentationLibraryLogs().AppendEmpty().Logs()
+		record := records.AppendEmpty()
+		record.SetTimestamp(pdata.NewTimestampFromTime(time.Date(2021, 11, 22, 17, 8, 58, 0, time.UTC)))
+		record = records.AppendEmpty()
+		record.SetTimestamp(pdata.NewTimestampFromTime(time.Date(2021, 11, 23, 9, 0, 0, 0, time.UTC)))
+		record.Attributes().InsertString("service.name", "payment")
+		record.Attributes().InsertString("data_stream.dataset", "payment-api")
+		return logs
+	}
+
+	tests := map[string]struct {
+		config func(*Config)
+		want   []string
+	}{
+		"static": {
+			config: func(cfg *Config) {},
+			want:   []string{"logs-generic-default", "logs-generic-default"},
+		},
+		"date": {
+			config: func(cfg *Config) {
+				cfg.Index = "logs-%{+yyyy.MM.dd}"
+			},
+			want: []string{"logs-2021.11.22", "logs-2021.11.23"},
+		},
+		"attribute prefix and date": {
+			config: func(cfg *Config) {
+				cfg.Index = "logs-%{+yyyy.MM.dd}"
+				cfg.DynamicIndex.PrefixAttribute = "service.name"
+			},
+			want: []string{"checkout-logs-2021.11.22", "payment-logs-2021.11.23"},
+		},
+		"attribute suffix": {
+			config: func(cfg *Config) {
+				cfg.Index = "logs"
+				cfg.DynamicIndex.SuffixAttribute = "service.name"
+				cfg.DynamicIndex.Separator = "."
+			},
+			want: []string{"logs.checkout", "logs.payment"},
+		},
+		"data stream": {
+			config: func(cfg *Config) {
+				cfg.DataStream.Enabled = true
+				cfg.DataStream.Namespace = "prod"
+			},
+			want: []string{"logs-generic-prod", "logs-payment_api-prod"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := newBulkRecorder()
+			server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+				rec.Record(docs)
+				return itemsAllOK(docs)
+			})
+
+			exporter := newTestExporter(t, server.URL, test.config)
+			require.NoError(t, exporter.pushLogsData(context.TODO(), newLogs()))
+			rec.WaitItems(2)
+
+			var indices []string
+			for _, item := range rec.Items() {
+				var action map[string]struct {
+					Index string `json:"_index"`
+				}
+				require.NoError(t, json.Unmarshal(item.Action, &action))
+				indices = append(indices, action[createAction].Index)
+			}
+			assert.ElementsMatch(t, test.want, indices)
+		})
+	}
+}
+
 func newTestExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchExporter {
-	exporter, err := newExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(url))
+	cfg := withTestExporterConfig(fns...)(url)
+	exporter, err := newExporter(zaptest.NewLogger(t), cfg, cfg.Index, dataStreamTypeLogs)
 	require.NoError(t, err)
 
 	t.Cleanup(func() { exporter.Shutdown(context.TODO()) })
@@ -330,6 +539,6 @@ func withTestExporterConfig(fns ...func(*Config)) func(string) *Config {
 }
 
 func mustSend(t *testing.T, exporter *elasticsearchExporter, contents string) {
-	err := exporter.pushEvent(context.TODO(), []byte(contents))
+	err := exporter.pushEvent(context.TODO(), "logs-generic-default", []byte(contents))
 	require.NoError(t, err)
 }
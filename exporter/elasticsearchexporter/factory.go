@@ -35,6 +35,7 @@ func NewFactory() component.ExporterFactory {
 		typeStr,
 		createDefaultConfig,
 		exporterhelper.WithLogs(createLogsExporter),
+		exporterhelper.WithTraces(createTracesExporter),
 	)
 }
 
@@ -44,7 +45,15 @@ func createDefaultConfig() config.Exporter {
 		HTTPClientSettings: HTTPClientSettings{
 			Timeout: 90 * time.Second,
 		},
-		Index: "logs-generic-default",
+		Index:       "logs-generic-default",
+		TracesIndex: "traces-generic-default",
+		DynamicIndex: DynamicIndexSettings{
+			Separator: "-",
+		},
+		DataStream: DataStreamSettings{
+			Dataset:   "generic",
+			Namespace: "default",
+		},
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -67,7 +76,8 @@ func createLogsExporter(
 	set component.ExporterCreateSettings,
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
-	exporter, err := newExporter(set.Logger, cfg.(*Config))
+	cf := cfg.(*Config)
+	exporter, err := newExporter(set.Logger, cf, cf.Index, dataStreamTypeLogs)
 	if err != nil {
 		return nil, fmt.Errorf("cannot configure Elasticsearch logs exporter: %w", err)
 	}
@@ -79,3 +89,25 @@ func createLogsExporter(
 		exporterhelper.WithShutdown(exporter.Shutdown),
 	)
 }
+
+// createTracesExporter creates a new exporter for traces.
+//
+// Spans are directly indexed into Elasticsearch, one document per span.
+func createTracesExporter(
+	ctx context.Context,
+	set component.ExporterCreateSettings,
+	cfg config.Exporter,
+) (component.TracesExporter, error) {
+	cf := cfg.(*Config)
+	exporter, err := newExporter(set.Logger, cf, cf.TracesIndex, dataStreamTypeTraces)
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch traces exporter: %w", err)
+	}
+
+	return exporterhelper.NewTracesExporter(
+		cfg,
+		set,
+		exporter.pushTracesData,
+		exporterhelper.WithShutdown(exporter.Shutdown),
+	)
+}
@@ -0,0 +1,242 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	dataStreamTypeLogs   = "logs"
+	dataStreamTypeTraces = "traces"
+
+	// dataStreamDatasetAttribute and dataStreamNamespaceAttribute override the
+	// configured data stream dataset and namespace of a single event.
+	dataStreamDatasetAttribute   = "data_stream.dataset"
+	dataStreamNamespaceAttribute = "data_stream.namespace"
+
+	// invalidIndexChars holds the characters Elasticsearch doesn't accept in
+	// index names.
+	invalidIndexChars = "\\/*?\"<>| ,#:"
+)
+
+// indexTemplate is a parsed index name. Literal parts are copied as is, while
+// the date parts in the form of %{+yyyy.MM.dd} are replaced with the
+// formatted timestamp of the event.
+type indexTemplate []indexTemplatePart
+
+type indexTemplatePart struct {
+	literal string
+	// date holds the date tokens and separators of a date part.
+	date []string
+}
+
+// parseIndexTemplate parses an index name with optional date parts.
+func parseIndexTemplate(index string) (indexTemplate, error) {
+	var tmpl indexTemplate
+	for index != "" {
+		start := strings.Index(index, "%{")
+		if start < 0 {
+			tmpl = append(tmpl, indexTemplatePart{literal: index})
+			break
+		}
+		if start > 0 {
+			tmpl = append(tmpl, indexTemplatePart{literal: index[:start]})
+		}
+
+		end := strings.IndexByte(index[start:], '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unterminated placeholder in index %q", index)
+		}
+		placeholder := index[start+2 : start+end]
+		if !strings.HasPrefix(placeholder, "+") {
+			return nil, fmt.Errorf("unsupported placeholder %q in index, only date formats like %%{+yyyy.MM.dd} are supported", placeholder)
+		}
+		date, err := parseDateFormat(placeholder[1:])
+		if err != nil {
+			return nil, err
+		}
+		tmpl = append(tmpl, indexTemplatePart{date: date})
+		index = index[start+end+1:]
+	}
+	return tmpl, nil
+}
+
+// dateTokens are the supported date format tokens, longest first.
+var dateTokens = []string{"yyyy", "yy", "MM", "dd", "HH", "mm", "ss"}
+
+// parseDateFormat splits a date format into the supported tokens and the
+// '.', '-' and '_' separators.
+func parseDateFormat(format string) ([]string, error) {
+	if format == "" {
+		return nil, fmt.Errorf("empty date format in index")
+	}
+
+	var parts []string
+	for format != "" {
+		switch format[0] {
+		case '.', '-', '_':
+			parts = append(parts, format[:1])
+			format = format[1:]
+			continue
+		}
+
+		matched := false
+		for _, token := range dateTokens {
+			if strings.HasPrefix(format, token) {
+				parts = append(parts, token)
+				format = format[len(token):]
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("unsupported date format %q in index, supported tokens are %s", format, strings.Join(dateTokens, ", "))
+		}
+	}
+	return parts, nil
+}
+
+// format returns the index name for an event with the given timestamp.
+func (t indexTemplate) format(ts time.Time) string {
+	var sb strings.Builder
+	for _, part := range t {
+		if part.date == nil {
+			sb.WriteString(part.literal)
+			continue
+		}
+		for _, token := range part.date {
+			sb.WriteString(formatDateToken(token, ts))
+		}
+	}
+	return sb.String()
+}
+
+func formatDateToken(token string, ts time.Time) string {
+	switch token {
+	case "yyyy":
+		return fmt.Sprintf("%04d", ts.Year())
+	case "yy":
+		return fmt.Sprintf("%02d", ts.Year()%100)
+	case "MM":
+		return fmt.Sprintf("%02d", int(ts.Month()))
+	case "dd":
+		return fmt.Sprintf("%02d", ts.Day())
+	case "HH":
+		return fmt.Sprintf("%02d", ts.Hour())
+	case "mm":
+		return fmt.Sprintf("%02d", ts.Minute())
+	case "ss":
+		return fmt.Sprintf("%02d", ts.Second())
+	default:
+		return token
+	}
+}
+
+// indexResolver resolves the index, index alias or data stream an event is
+// indexed in.
+type indexResolver struct {
+	template       indexTemplate
+	dynamic        DynamicIndexSettings
+	dataStream     DataStreamSettings
+	dataStreamType string
+}
+
+func newIndexResolver(cfg *Config, index string, dataStreamType string) (*indexResolver, error) {
+	template, err := parseIndexTemplate(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return &indexResolver{
+		template:       template,
+		dynamic:        cfg.DynamicIndex,
+		dataStream:     cfg.DataStream,
+		dataStreamType: dataStreamType,
+	}, nil
+}
+
+// resolve returns the index name for an event. Record attributes take
+// precedence over resource attributes.
+func (r *indexResolver) resolve(resource, record pdata.AttributeMap, ts pdata.Timestamp) string {
+	if r.dataStream.Enabled {
+		dataset := lookupAttribute(dataStreamDatasetAttribute, record, resource)
+		if dataset == "" {
+			dataset = r.dataStream.Dataset
+		}
+		namespace := lookupAttribute(dataStreamNamespaceAttribute, record, resource)
+		if namespace == "" {
+			namespace = r.dataStream.Namespace
+		}
+
+		// The parts of a data stream name must not contain '-', as it
+		// separates them.
+		return strings.Join([]string{
+			r.dataStreamType,
+			strings.ReplaceAll(sanitizeIndexName(dataset), "-", "_"),
+			strings.ReplaceAll(sanitizeIndexName(namespace), "-", "_"),
+		}, "-")
+	}
+
+	if ts == 0 {
+		ts = pdata.NewTimestampFromTime(time.Now())
+	}
+	index := r.template.format(ts.AsTime().UTC())
+
+	if prefix := lookupAttribute(r.dynamic.PrefixAttribute, record, resource); prefix != "" {
+		index = sanitizeIndexName(prefix) + r.dynamic.Separator + index
+	}
+	if suffix := lookupAttribute(r.dynamic.SuffixAttribute, record, resource); suffix != "" {
+		index = index + r.dynamic.Separator + sanitizeIndexName(suffix)
+	}
+	return index
+}
+
+// lookupAttribute returns the value of the first attribute map containing the
+// key, converted to a string.
+func lookupAttribute(key string, attrs ...pdata.AttributeMap) string {
+	if key == "" {
+		return ""
+	}
+	for _, am := range attrs {
+		if v, ok := am.Get(key); ok {
+			return v.AsString()
+		}
+	}
+	return ""
+}
+
+// sanitizeIndexName lowercases the name and replaces the characters not
+// allowed in Elasticsearch index names.
+func sanitizeIndexName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(invalidIndexChars, r) {
+			return '_'
+		}
+		return r
+	}, strings.ToLower(name))
+}
+
+// validateIndexTemplate checks if an index name can be parsed.
+func validateIndexTemplate(setting, index string) error {
+	if _, err := parseIndexTemplate(index); err != nil {
+		return fmt.Errorf("invalid %s: %w", setting, err)
+	}
+	return nil
+}
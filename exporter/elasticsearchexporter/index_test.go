@@ -0,0 +1,124 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestIndexTemplate(t *testing.T) {
+	ts := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+
+	tests := map[string]struct {
+		index string
+		want  string
+		err   string
+	}{
+		"static":            {index: "logs-generic-default", want: "logs-generic-default"},
+		"date":              {index: "logs-%{+yyyy.MM.dd}", want: "logs-2021.03.04"},
+		"short year":        {index: "logs-%{+yy_MM}", want: "logs-21_03"},
+		"time":              {index: "logs-%{+yyyy.MM.dd-HH.mm.ss}", want: "logs-2021.03.04-05.06.07"},
+		"multiple dates":    {index: "%{+yyyy}-logs-%{+MM}", want: "2021-logs-03"},
+		"unterminated":      {index: "logs-%{+yyyy", err: "unterminated placeholder"},
+		"attribute":         {index: "logs-%{service.name}", err: "unsupported placeholder"},
+		"empty date format": {index: "logs-%{+}", err: "empty date format"},
+		"unsupported token": {index: "logs-%{+yyyy.ww}", err: "unsupported date format"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := parseIndexTemplate(test.index)
+			if test.err != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.want, tmpl.format(ts))
+		})
+	}
+}
+
+func TestIndexResolver(t *testing.T) {
+	ts := pdata.NewTimestampFromTime(time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC))
+	resource := pdata.NewAttributeMapFromMap(map[string]pdata.AttributeValue{
+		"service.name":          pdata.NewAttributeValueString("Cart Service"),
+		"team":                  pdata.NewAttributeValueString("checkout"),
+		"data_stream.namespace": pdata.NewAttributeValueString("staging"),
+	})
+	record := pdata.NewAttributeMapFromMap(map[string]pdata.AttributeValue{
+		"team":                pdata.NewAttributeValueString("payments"),
+		"data_stream.dataset": pdata.NewAttributeValueString("nginx.access"),
+	})
+
+	tests := map[string]struct {
+		config func(*Config)
+		record pdata.AttributeMap
+		want   string
+	}{
+		"prefix from resource": {
+			config: func(cfg *Config) {
+				cfg.Index = "logs-%{+yyyy.MM.dd}"
+				cfg.DynamicIndex.PrefixAttribute = "service.name"
+			},
+			record: pdata.NewAttributeMap(),
+			want:   "cart_service-logs-2021.03.04",
+		},
+		"record attribute takes precedence": {
+			config: func(cfg *Config) {
+				cfg.Index = "logs-%{+yyyy.MM.dd}"
+				cfg.DynamicIndex.SuffixAttribute = "team"
+			},
+			record: record,
+			want:   "logs-2021.03.04-payments",
+		},
+		"missing attribute": {
+			config: func(cfg *Config) {
+				cfg.DynamicIndex.PrefixAttribute = "k8s.namespace.name"
+			},
+			record: pdata.NewAttributeMap(),
+			want:   "logs-generic-default",
+		},
+		"data stream namespace from resource": {
+			config: func(cfg *Config) {
+				cfg.DataStream.Enabled = true
+			},
+			record: pdata.NewAttributeMap(),
+			want:   "logs-generic-staging",
+		},
+		"data stream from attributes": {
+			config: func(cfg *Config) {
+				cfg.DataStream.Enabled = true
+			},
+			record: record,
+			want:   "logs-nginx.access-staging",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := withDefaultConfig(test.config)
+			resolver, err := newIndexResolver(cfg, cfg.Index, dataStreamTypeLogs)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.want, resolver.resolve(resource, test.record, ts))
+		})
+	}
+}
@@ -158,6 +158,28 @@ func (doc *Document) AddAttributes(key string, attributes pdata.AttributeMap) {
 	doc.fields = appendAttributeFields(doc.fields, key, attributes)
 }
 
+// AddEvents converts and adds span events to the document. The events are added
+// as an array of objects holding the name, timestamp and attributes of each event,
+// so that events sharing the same name are all kept.
+func (doc *Document) AddEvents(key string, events pdata.SpanEventSlice) {
+	if events.Len() == 0 {
+		return
+	}
+
+	values := make([]Value, events.Len())
+	for i := 0; i < events.Len(); i++ {
+		e := events.At(i)
+		var event Document
+		event.AddString("Name", e.Name())
+		event.AddTimestamp("Timestamp", e.Timestamp())
+		event.AddAttributes("Attributes", e.Attributes())
+		// Nested objects are always serialized dedotted, which requires sorted keys.
+		event.Sort()
+		values[i] = Value{kind: KindObject, doc: event}
+	}
+	doc.Add(key, ArrValue(values...))
+}
+
 // AddAttribute converts and adds a AttributeValue to the document. If the attribute represents a map,
 // the fields will be flattened.
 func (doc *Document) AddAttribute(key string, attribute pdata.AttributeValue) {
@@ -86,6 +86,52 @@ func TestObjectModel_CreateMap(t *testing.T) {
 			},
 			want: Document{[]field{{"prefix.i", IntValue(42)}, {"prefix.str", StringValue("test")}}},
 		},
+		"add events": {
+			build: func() (doc Document) {
+				events := pdata.NewSpanEventSlice()
+				event := events.AppendEmpty()
+				event.SetName("exception")
+				event.SetTimestamp(pdata.NewTimestampFromTime(dijkstra))
+				event.Attributes().InsertString("exception.type", "io.EOF")
+				doc.AddEvents("Events", events)
+				return doc
+			},
+			want: Document{[]field{
+				{"Events", ArrValue(Value{kind: KindObject, doc: Document{[]field{
+					{"Attributes.exception.type", StringValue("io.EOF")},
+					{"Name", StringValue("exception")},
+					{"Timestamp", TimestampValue(dijkstra)},
+				}}})},
+			}},
+		},
+		"add events with the same name": {
+			build: func() (doc Document) {
+				events := pdata.NewSpanEventSlice()
+				for _, typ := range []string{"io.EOF", "io.ErrUnexpectedEOF"} {
+					event := events.AppendEmpty()
+					event.SetName("exception")
+					event.SetTimestamp(pdata.NewTimestampFromTime(dijkstra))
+					event.Attributes().InsertString("exception.type", typ)
+				}
+				doc.AddEvents("Events", events)
+				doc.Dedup()
+				return doc
+			},
+			want: Document{[]field{
+				{"Events", ArrValue(
+					Value{kind: KindObject, doc: Document{[]field{
+						{"Attributes.exception.type", StringValue("io.EOF")},
+						{"Name", StringValue("exception")},
+						{"Timestamp", TimestampValue(dijkstra)},
+					}}},
+					Value{kind: KindObject, doc: Document{[]field{
+						{"Attributes.exception.type", StringValue("io.ErrUnexpectedEOF")},
+						{"Name", StringValue("exception")},
+						{"Timestamp", TimestampValue(dijkstra)},
+					}}},
+				)},
+			}},
+		},
 	}
 
 	for name, test := range tests {
@@ -24,6 +24,7 @@ import (
 
 type mappingModel interface {
 	encodeLog(pdata.Resource, pdata.LogRecord) ([]byte, error)
+	encodeSpan(pdata.Resource, pdata.Span) ([]byte, error)
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -35,6 +36,7 @@ type mappingModel interface {
 type encodeModel struct {
 	dedup bool
 	dedot bool
+	mode  MappingMode
 }
 
 func (m *encodeModel) encodeLog(resource pdata.Resource, record pdata.LogRecord) ([]byte, error) {
@@ -50,6 +52,50 @@ func (m *encodeModel) encodeLog(resource pdata.Resource, record pdata.LogRecord)
 	document.AddAttributes("Attributes", record.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
 
+	return m.serialize(&document)
+}
+
+// encodeSpan encodes a span into a single document. The span fields are named
+// after the OpenTelemetry span fields, unless the ECS mapping mode is used. In
+// that case the fields defined by ECS use the ECS field names instead.
+func (m *encodeModel) encodeSpan(resource pdata.Resource, span pdata.Span) ([]byte, error) {
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", span.StartTimestamp()) // We use @timestamp in order to ensure that we can index if the default data stream traces template is used.
+	duration := int64(span.EndTimestamp() - span.StartTimestamp())
+
+	switch m.mode {
+	case MappingECS:
+		document.AddTimestamp("event.end", span.EndTimestamp())
+		document.AddInt("event.duration", duration)
+		document.AddString("event.outcome", ecsEventOutcome(span.Status().Code()))
+		document.AddID("trace.id", span.TraceID())
+		document.AddID("span.id", span.SpanID())
+		document.AddID("parent.id", span.ParentSpanID())
+		document.AddString("span.name", span.Name())
+		document.AddString("span.kind", span.Kind().String())
+		if span.Status().Code() == pdata.StatusCodeError {
+			document.AddString("error.message", span.Status().Message())
+		}
+	default:
+		document.AddTimestamp("EndTimestamp", span.EndTimestamp())
+		document.AddInt("Duration", duration)
+		document.AddID("TraceId", span.TraceID())
+		document.AddID("SpanId", span.SpanID())
+		document.AddID("ParentSpanId", span.ParentSpanID())
+		document.AddString("Name", span.Name())
+		document.AddString("Kind", span.Kind().String())
+		document.AddString("TraceStatus", span.Status().Code().String())
+		document.AddString("TraceStatusDescription", span.Status().Message())
+	}
+
+	document.AddAttributes("Attributes", span.Attributes())
+	document.AddEvents("Events", span.Events())
+	document.AddAttributes("Resource", resource.Attributes())
+
+	return m.serialize(&document)
+}
+
+func (m *encodeModel) serialize(document *objmodel.Document) ([]byte, error) {
 	if m.dedup {
 		document.Dedup()
 	} else if m.dedot {
@@ -60,3 +106,17 @@ func (m *encodeModel) encodeLog(resource pdata.Resource, record pdata.LogRecord)
 	err := document.Serialize(&buf, m.dedot)
 	return buf.Bytes(), err
 }
+
+// ecsEventOutcome maps a span status code to the ECS event.outcome values.
+//
+// See: https://www.elastic.co/guide/en/ecs/current/ecs-allowed-values-event-outcome.html
+func ecsEventOutcome(code pdata.StatusCode) string {
+	switch code {
+	case pdata.StatusCodeOk:
+		return "success"
+	case pdata.StatusCodeError:
+		return "failure"
+	default:
+		return "unknown"
+	}
+}
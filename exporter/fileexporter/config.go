@@ -16,16 +16,53 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"errors"
+	"fmt"
+	"time"
 
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	formatTypeJSON  = "json"
+	formatTypeProto = "proto"
+
+	compressionGzip = "gzip"
+	compressionZstd = "zstd"
+)
+
 // Config defines configuration for file exporter.
 type Config struct {
 	config.ExporterSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
 
 	// Path of the file to write to. Path is relative to current directory.
 	Path string `mapstructure:"path"`
+
+	// FormatType is the format of the data written to the file: "json" (default) writes a
+	// Protobuf-JSON message per line, "proto" writes length-delimited Protobuf messages.
+	FormatType string `mapstructure:"format"`
+
+	// Rotation defines when the file is rotated and how many rotated files are kept.
+	// When not set, the file is never rotated and is truncated upon start.
+	Rotation *Rotation `mapstructure:"rotation"`
+
+	// FlushInterval is the maximum time the data is buffered before being written to the file.
+	// When zero, the data is written to the file as soon as it's received.
+	FlushInterval time.Duration `mapstructure:"flush_interval"`
+}
+
+// Rotation defines the rotation of the file being written to.
+type Rotation struct {
+	// MaxMegabytes is the size in megabytes the file may reach before being rotated. Zero disables size-based rotation.
+	MaxMegabytes int `mapstructure:"max_megabytes"`
+
+	// Interval is the time after which the file is rotated. Zero disables time-based rotation.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// MaxBackups is the number of rotated files to retain, the oldest being removed first. Zero retains all of them.
+	MaxBackups int `mapstructure:"max_backups"`
+
+	// Compression is the algorithm used to compress the rotated files, either "gzip" or "zstd". Empty disables compression.
+	Compression string `mapstructure:"compression"`
 }
 
 var _ config.Exporter = (*Config)(nil)
@@ -36,5 +73,30 @@ func (cfg *Config) Validate() error {
 		return errors.New("path must be non-empty")
 	}
 
+	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
+		return fmt.Errorf("format %q is not supported, use either %q or %q", cfg.FormatType, formatTypeJSON, formatTypeProto)
+	}
+
+	if cfg.FlushInterval < 0 {
+		return errors.New("flush_interval must not be negative")
+	}
+
+	if cfg.Rotation != nil {
+		if cfg.Rotation.MaxMegabytes < 0 {
+			return errors.New("rotation max_megabytes must not be negative")
+		}
+		if cfg.Rotation.Interval < 0 {
+			return errors.New("rotation interval must not be negative")
+		}
+		if cfg.Rotation.MaxBackups < 0 {
+			return errors.New("rotation max_backups must not be negative")
+		}
+		switch cfg.Rotation.Compression {
+		case "", compressionGzip, compressionZstd:
+		default:
+			return fmt.Errorf("rotation compression %q is not supported, use either %q or %q", cfg.Rotation.Compression, compressionGzip, compressionZstd)
+		}
+	}
+
 	return nil
 }
@@ -17,6 +17,7 @@ package fileexporter
 import (
 	"path"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -43,5 +44,68 @@ func TestLoadConfig(t *testing.T) {
 		&Config{
 			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "2")),
 			Path:             "./filename.json",
+			FormatType:       "json",
 		})
+
+	e2 := cfg.Exporters[config.NewComponentIDWithName(typeStr, "3")]
+	assert.Equal(t, e2,
+		&Config{
+			ExporterSettings: config.NewExporterSettings(config.NewComponentIDWithName(typeStr, "3")),
+			Path:             "./archive.json",
+			FormatType:       "proto",
+			Rotation: &Rotation{
+				MaxMegabytes: 10,
+				Interval:     24 * time.Hour,
+				MaxBackups:   7,
+				Compression:  "zstd",
+			},
+			FlushInterval: 5 * time.Second,
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		cfg  *Config
+		err  string
+	}{
+		{
+			desc: "valid rotation",
+			cfg:  &Config{Path: "file.json", FormatType: "json", Rotation: &Rotation{MaxMegabytes: 1, Compression: "gzip"}},
+		},
+		{
+			desc: "unsupported format",
+			cfg:  &Config{Path: "file.json", FormatType: "xml"},
+			err:  `format "xml" is not supported, use either "json" or "proto"`,
+		},
+		{
+			desc: "negative flush interval",
+			cfg:  &Config{Path: "file.json", FormatType: "json", FlushInterval: -time.Second},
+			err:  "flush_interval must not be negative",
+		},
+		{
+			desc: "negative max megabytes",
+			cfg:  &Config{Path: "file.json", FormatType: "json", Rotation: &Rotation{MaxMegabytes: -1}},
+			err:  "rotation max_megabytes must not be negative",
+		},
+		{
+			desc: "negative max backups",
+			cfg:  &Config{Path: "file.json", FormatType: "json", Rotation: &Rotation{MaxBackups: -1}},
+			err:  "rotation max_backups must not be negative",
+		},
+		{
+			desc: "unsupported compression",
+			cfg:  &Config{Path: "file.json", FormatType: "json", Rotation: &Rotation{Compression: "lz4"}},
+			err:  `rotation compression "lz4" is not supported, use either "gzip" or "zstd"`,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
 }
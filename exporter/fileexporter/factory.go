@@ -42,6 +42,7 @@ func NewFactory() component.ExporterFactory {
 func createDefaultConfig() config.Exporter {
 	return &Config{
 		ExporterSettings: config.NewExporterSettings(config.NewComponentID(typeStr)),
+		FormatType:       formatTypeJSON,
 	}
 }
 
@@ -51,7 +52,7 @@ func createTracesExporter(
 	cfg config.Exporter,
 ) (component.TracesExporter, error) {
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
+		return newFileExporter(set.Logger, cfg.(*Config))
 	})
 	return exporterhelper.NewTracesExporter(
 		cfg,
@@ -68,7 +69,7 @@ func createMetricsExporter(
 	cfg config.Exporter,
 ) (component.MetricsExporter, error) {
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
+		return newFileExporter(set.Logger, cfg.(*Config))
 	})
 	return exporterhelper.NewMetricsExporter(
 		cfg,
@@ -85,7 +86,7 @@ func createLogsExporter(
 	cfg config.Exporter,
 ) (component.LogsExporter, error) {
 	fe := exporters.GetOrAdd(cfg, func() component.Component {
-		return &fileExporter{path: cfg.(*Config).Path}
+		return newFileExporter(set.Logger, cfg.(*Config))
 	})
 	return exporterhelper.NewLogsExporter(
 		cfg,
@@ -16,27 +16,66 @@ package fileexporter // import "github.com/open-telemetry/opentelemetry-collecto
 
 import (
 	"context"
+	"encoding/binary"
 	"io"
-	"os"
 	"sync"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/model/otlp"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
 )
 
-// Marshaler configuration used for marhsaling Protobuf to JSON.
-var tracesMarshaler = otlp.NewJSONTracesMarshaler()
-var metricsMarshaler = otlp.NewJSONMetricsMarshaler()
-var logsMarshaler = otlp.NewJSONLogsMarshaler()
+// Marshalers for each of the supported formats.
+var tracesMarshalers = map[string]pdata.TracesMarshaler{
+	formatTypeJSON:  otlp.NewJSONTracesMarshaler(),
+	formatTypeProto: otlp.NewProtobufTracesMarshaler(),
+}
+var metricsMarshalers = map[string]pdata.MetricsMarshaler{
+	formatTypeJSON:  otlp.NewJSONMetricsMarshaler(),
+	formatTypeProto: otlp.NewProtobufMetricsMarshaler(),
+}
+var logsMarshalers = map[string]pdata.LogsMarshaler{
+	formatTypeJSON:  otlp.NewJSONLogsMarshaler(),
+	formatTypeProto: otlp.NewProtobufLogsMarshaler(),
+}
 
 // fileExporter is the implementation of file exporter that writes telemetry data to a file
-// in Protobuf-JSON format.
+// in Protobuf-JSON format, one message per line, or in Protobuf binary format, each message
+// preceded by its length.
 type fileExporter struct {
-	path  string
-	file  io.WriteCloser
-	mutex sync.Mutex
+	logger        *zap.Logger
+	path          string
+	rotation      *Rotation
+	flushInterval time.Duration
+	file          io.WriteCloser
+	mutex         sync.Mutex
+
+	tracesMarshaler  pdata.TracesMarshaler
+	metricsMarshaler pdata.MetricsMarshaler
+	logsMarshaler    pdata.LogsMarshaler
+	exporter         func(e *fileExporter, buf []byte) error
+}
+
+func newFileExporter(logger *zap.Logger, cfg *Config) *fileExporter {
+	format := cfg.FormatType
+	exporter := exportMessageAsLine
+	if format == formatTypeProto {
+		exporter = exportMessageAsBuffer
+	}
+
+	return &fileExporter{
+		logger:           logger,
+		path:             cfg.Path,
+		rotation:         cfg.Rotation,
+		flushInterval:    cfg.FlushInterval,
+		tracesMarshaler:  tracesMarshalers[format],
+		metricsMarshaler: metricsMarshalers[format],
+		logsMarshaler:    logsMarshalers[format],
+		exporter:         exporter,
+	}
 }
 
 func (e *fileExporter) Capabilities() consumer.Capabilities {
@@ -44,46 +83,60 @@ func (e *fileExporter) Capabilities() consumer.Capabilities {
 }
 
 func (e *fileExporter) ConsumeTraces(_ context.Context, td pdata.Traces) error {
-	buf, err := tracesMarshaler.MarshalTraces(td)
+	buf, err := e.tracesMarshaler.MarshalTraces(td)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.exporter(e, buf)
 }
 
 func (e *fileExporter) ConsumeMetrics(_ context.Context, md pdata.Metrics) error {
-	buf, err := metricsMarshaler.MarshalMetrics(md)
+	buf, err := e.metricsMarshaler.MarshalMetrics(md)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.exporter(e, buf)
 }
 
 func (e *fileExporter) ConsumeLogs(_ context.Context, ld pdata.Logs) error {
-	buf, err := logsMarshaler.MarshalLogs(ld)
+	buf, err := e.logsMarshaler.MarshalLogs(ld)
 	if err != nil {
 		return err
 	}
-	return exportMessageAsLine(e, buf)
+	return e.exporter(e, buf)
 }
 
 func exportMessageAsLine(e *fileExporter, buf []byte) error {
 	// Ensure only one write operation happens at a time.
 	e.mutex.Lock()
 	defer e.mutex.Unlock()
-	if _, err := e.file.Write(buf); err != nil {
+	// the message and its line break are written at once, so that they always end up in the same file upon rotation
+	if _, err := e.file.Write(append(buf, '\n')); err != nil {
 		return err
 	}
-	if _, err := io.WriteString(e.file, "\n"); err != nil {
+	return nil
+}
+
+func exportMessageAsBuffer(e *fileExporter, buf []byte) error {
+	// Ensure only one write operation happens at a time.
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	// each message is preceded by its size, as a 4-byte big-endian unsigned integer
+	data := make([]byte, 4, 4+len(buf))
+	binary.BigEndian.PutUint32(data, uint32(len(buf)))
+	if _, err := e.file.Write(append(data, buf...)); err != nil {
 		return err
 	}
 	return nil
 }
 
 func (e *fileExporter) Start(context.Context, component.Host) error {
-	var err error
-	e.file, err = os.OpenFile(e.path, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
-	return err
+	w, err := newFileWriter(e.logger, e.path, e.rotation, e.flushInterval)
+	if err != nil {
+		return err
+	}
+	e.file = w
+	return nil
 }
 
 // Shutdown stops the exporter and is invoked during shutdown.
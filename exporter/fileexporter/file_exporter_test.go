@@ -15,6 +15,7 @@ package fileexporter
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"io/ioutil"
 	"os"
@@ -24,12 +25,13 @@ import (
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/model/otlp"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
 )
 
 func TestFileTracesExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
+	fe := newFileExporter(zap.NewNop(), &Config{Path: tempFileName(t), FormatType: formatTypeJSON})
 	require.NotNil(t, fe)
 
 	td := testdata.GenerateTracesTwoSpansSameResource()
@@ -47,7 +49,8 @@ func TestFileTracesExporter(t *testing.T) {
 
 func TestFileTracesExporterError(t *testing.T) {
 	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
+	fe := newFileExporter(zap.NewNop(), &Config{FormatType: formatTypeJSON})
+	fe.file = mf
 	require.NotNil(t, fe)
 
 	td := testdata.GenerateTracesTwoSpansSameResource()
@@ -57,7 +60,7 @@ func TestFileTracesExporterError(t *testing.T) {
 }
 
 func TestFileMetricsExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
+	fe := newFileExporter(zap.NewNop(), &Config{Path: tempFileName(t), FormatType: formatTypeJSON})
 	require.NotNil(t, fe)
 
 	md := testdata.GenerateMetricsTwoMetrics()
@@ -75,7 +78,8 @@ func TestFileMetricsExporter(t *testing.T) {
 
 func TestFileMetricsExporterError(t *testing.T) {
 	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
+	fe := newFileExporter(zap.NewNop(), &Config{FormatType: formatTypeJSON})
+	fe.file = mf
 	require.NotNil(t, fe)
 
 	md := testdata.GenerateMetricsTwoMetrics()
@@ -85,7 +89,7 @@ func TestFileMetricsExporterError(t *testing.T) {
 }
 
 func TestFileLogsExporter(t *testing.T) {
-	fe := &fileExporter{path: tempFileName(t)}
+	fe := newFileExporter(zap.NewNop(), &Config{Path: tempFileName(t), FormatType: formatTypeJSON})
 	require.NotNil(t, fe)
 
 	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
@@ -103,7 +107,8 @@ func TestFileLogsExporter(t *testing.T) {
 
 func TestFileLogsExporterErrors(t *testing.T) {
 	mf := &errorWriter{}
-	fe := &fileExporter{file: mf}
+	fe := newFileExporter(zap.NewNop(), &Config{FormatType: formatTypeJSON})
+	fe.file = mf
 	require.NotNil(t, fe)
 
 	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
@@ -112,6 +117,45 @@ func TestFileLogsExporterErrors(t *testing.T) {
 	assert.NoError(t, fe.Shutdown(context.Background()))
 }
 
+func TestFileExporterProtoFormat(t *testing.T) {
+	fe := newFileExporter(zap.NewNop(), &Config{Path: tempFileName(t), FormatType: formatTypeProto})
+	require.NotNil(t, fe)
+
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	md := testdata.GenerateMetricsTwoMetrics()
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+	assert.NoError(t, fe.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, fe.ConsumeTraces(context.Background(), td))
+	assert.NoError(t, fe.ConsumeMetrics(context.Background(), md))
+	assert.NoError(t, fe.ConsumeLogs(context.Background(), ld))
+	assert.NoError(t, fe.Shutdown(context.Background()))
+
+	buf, err := ioutil.ReadFile(fe.path)
+	require.NoError(t, err)
+
+	var messages [][]byte
+	for len(buf) > 0 {
+		require.GreaterOrEqual(t, len(buf), 4)
+		size := int(binary.BigEndian.Uint32(buf[:4]))
+		require.GreaterOrEqual(t, len(buf), 4+size)
+		messages = append(messages, buf[4:4+size])
+		buf = buf[4+size:]
+	}
+	require.Len(t, messages, 3)
+
+	gotTraces, err := otlp.NewProtobufTracesUnmarshaler().UnmarshalTraces(messages[0])
+	assert.NoError(t, err)
+	assert.EqualValues(t, td, gotTraces)
+
+	gotMetrics, err := otlp.NewProtobufMetricsUnmarshaler().UnmarshalMetrics(messages[1])
+	assert.NoError(t, err)
+	assert.EqualValues(t, md, gotMetrics)
+
+	gotLogs, err := otlp.NewProtobufLogsUnmarshaler().UnmarshalLogs(messages[2])
+	assert.NoError(t, err)
+	assert.EqualValues(t, ld, gotLogs)
+}
+
 // tempFileName provides a temporary file name for testing.
 func tempFileName(t *testing.T) string {
 	tmpfile, err := ioutil.TempFile("", "*.json")
@@ -0,0 +1,414 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+
+import (
+	"bufio"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+)
+
+const (
+	megabyte = 1024 * 1024
+
+	// backupTimeFormat is the format of the timestamp added to the name of the rotated files.
+	// It sorts lexically in the same order as chronologically.
+	backupTimeFormat = "2006-01-02T15-04-05.000"
+)
+
+var compressionExtensions = map[string]string{
+	compressionGzip: ".gz",
+	compressionZstd: ".zst",
+}
+
+// fileWriter writes to a file, optionally buffering the data and rotating the file
+// once it becomes too large or too old
+type fileWriter struct {
+	logger        *zap.Logger
+	path          string
+	rotation      *Rotation
+	flushInterval time.Duration
+	now           func() time.Time
+	rename        func(oldpath, newpath string) error
+
+	// file is nil when it couldn't be reopened after a failed rotation
+	file     *os.File
+	buf      *bufio.Writer
+	size     int64
+	openedAt time.Time
+	mutex    sync.Mutex
+
+	// rotated files are compressed and pruned in the background, one at a time
+	backupsLock sync.Mutex
+	backupsWg   sync.WaitGroup
+
+	stopCh  chan struct{}
+	flushWg sync.WaitGroup
+}
+
+var _ io.WriteCloser = (*fileWriter)(nil)
+
+func newFileWriter(logger *zap.Logger, path string, rotation *Rotation, flushInterval time.Duration) (*fileWriter, error) {
+	w := &fileWriter{
+		logger:        logger,
+		path:          path,
+		rotation:      rotation,
+		flushInterval: flushInterval,
+		now:           time.Now,
+		rename:        os.Rename,
+		stopCh:        make(chan struct{}),
+	}
+
+	// without rotation, the file holds only the data of the current run. With rotation,
+	// the file is part of an archive and the data from previous runs is kept.
+	flags := os.O_RDWR | os.O_CREATE | os.O_TRUNC
+	if rotation != nil {
+		flags = os.O_RDWR | os.O_CREATE | os.O_APPEND
+	}
+	if err := w.open(flags); err != nil {
+		return nil, err
+	}
+
+	if flushInterval > 0 {
+		w.buf = bufio.NewWriter(w.file)
+		w.flushWg.Add(1)
+		go w.periodicallyFlush()
+	}
+
+	return w, nil
+}
+
+// Write writes the given data to the file, rotating the file beforehand if needed.
+// The data from a single call is never split across files.
+func (w *fileWriter) Write(p []byte) (int, error) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if w.file == nil {
+		if err := w.open(os.O_RDWR | os.O_CREATE | os.O_APPEND); err != nil {
+			return 0, err
+		}
+	}
+
+	if w.shouldRotate(len(p)) {
+		if err := w.rotate(); err != nil {
+			if w.file == nil {
+				return 0, err
+			}
+			w.logger.Warn("failed to rotate the file, writing to the current one", zap.String("path", w.path), zap.Error(err))
+		}
+	}
+
+	var n int
+	var err error
+	if w.buf != nil {
+		n, err = w.buf.Write(p)
+	} else {
+		n, err = w.file.Write(p)
+	}
+	w.size += int64(n)
+	return n, err
+}
+
+// Close flushes the buffered data and closes the file, waiting for the rotated files to be processed
+func (w *fileWriter) Close() error {
+	close(w.stopCh)
+	w.flushWg.Wait()
+
+	w.mutex.Lock()
+	err := w.close()
+	w.mutex.Unlock()
+
+	w.backupsWg.Wait()
+	return err
+}
+
+func (w *fileWriter) periodicallyFlush() {
+	defer w.flushWg.Done()
+
+	ticker := time.NewTicker(w.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			w.mutex.Lock()
+			if err := w.buf.Flush(); err != nil {
+				w.logger.Warn("failed to flush the data to the file", zap.String("path", w.path), zap.Error(err))
+			}
+			w.mutex.Unlock()
+		case <-w.stopCh:
+			return
+		}
+	}
+}
+
+func (w *fileWriter) open(flags int) error {
+	file, err := os.OpenFile(w.path, flags, 0600)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return err
+	}
+
+	w.file = file
+	w.size = info.Size()
+	w.openedAt = w.now()
+	if w.buf != nil {
+		w.buf.Reset(file)
+	}
+	return nil
+}
+
+func (w *fileWriter) close() error {
+	if w.file == nil {
+		return nil
+	}
+	if w.buf != nil {
+		if err := w.buf.Flush(); err != nil {
+			w.file.Close()
+			return err
+		}
+	}
+	return w.file.Close()
+}
+
+// shouldRotate tells whether the file has to be rotated before writing n more bytes. The caller is expected to hold the lock.
+func (w *fileWriter) shouldRotate(n int) bool {
+	// an empty file is never rotated, so that a single large write doesn't produce empty files
+	if w.rotation == nil || w.size == 0 {
+		return false
+	}
+
+	if w.rotation.MaxMegabytes > 0 && w.size+int64(n) > int64(w.rotation.MaxMegabytes)*megabyte {
+		return true
+	}
+
+	return w.rotation.Interval > 0 && w.now().Sub(w.openedAt) >= w.rotation.Interval
+}
+
+// rotate moves the current file to a backup and starts a new one. The caller is expected to hold the lock.
+// When the rotation fails, the file found at the path is reopened, so that the writer keeps a usable file.
+func (w *fileWriter) rotate() error {
+	backup, err := w.moveToBackup()
+	if err != nil {
+		if reopenErr := w.open(os.O_RDWR | os.O_CREATE | os.O_APPEND); reopenErr != nil {
+			w.logger.Warn("failed to reopen the file after a failed rotation", zap.String("path", w.path), zap.Error(reopenErr))
+			w.file = nil
+		}
+		return err
+	}
+
+	w.backupsWg.Add(1)
+	go w.processBackup(backup)
+
+	if err := w.open(os.O_RDWR | os.O_CREATE | os.O_TRUNC); err != nil {
+		w.file = nil
+		return err
+	}
+	return nil
+}
+
+// moveToBackup closes the current file and moves it to a new backup, returning the name of the backup
+func (w *fileWriter) moveToBackup() (string, error) {
+	if err := w.close(); err != nil {
+		return "", err
+	}
+
+	backup, err := availableBackupName(w.path, w.now())
+	if err != nil {
+		return "", err
+	}
+	return backup, w.rename(w.path, backup)
+}
+
+// processBackup compresses the given rotated file, if requested, and removes the backups exceeding the retention count
+func (w *fileWriter) processBackup(backup string) {
+	defer w.backupsWg.Done()
+
+	w.backupsLock.Lock()
+	defer w.backupsLock.Unlock()
+
+	if w.rotation.Compression != "" {
+		if err := compressFile(backup, w.rotation.Compression); err != nil {
+			w.logger.Warn("failed to compress the rotated file", zap.String("path", backup), zap.Error(err))
+		}
+	}
+
+	if err := removeOldBackups(w.path, w.rotation.MaxBackups); err != nil {
+		w.logger.Warn("failed to remove old rotated files", zap.String("path", w.path), zap.Error(err))
+	}
+}
+
+// backupName returns the name of the rotated file, like "/var/log/traces-2021-11-22T10-15-30.000.json" for "/var/log/traces.json"
+func backupName(path string, t time.Time) string {
+	dir, filename := filepath.Split(path)
+	ext := filepath.Ext(filename)
+	prefix := strings.TrimSuffix(filename, ext)
+	return filepath.Join(dir, prefix+"-"+t.UTC().Format(backupTimeFormat)+ext)
+}
+
+// availableBackupName returns the name of a rotated file that doesn't exist yet, compressed or not. As the names
+// have a millisecond resolution, the timestamp is moved forward when the file was already rotated within the same
+// millisecond, keeping the backups in chronological order.
+func availableBackupName(path string, t time.Time) (string, error) {
+	for {
+		backup := backupName(path, t)
+		exists, err := backupExists(backup)
+		if err != nil || !exists {
+			return backup, err
+		}
+		t = t.Add(time.Millisecond)
+	}
+}
+
+// backupExists checks whether the rotated file exists, compressed or not
+func backupExists(backup string) (bool, error) {
+	candidates := []string{backup}
+	for _, compressedExt := range compressionExtensions {
+		candidates = append(candidates, backup+compressedExt)
+	}
+
+	for _, candidate := range candidates {
+		_, err := os.Lstat(candidate)
+		if err == nil {
+			return true, nil
+		}
+		if !os.IsNotExist(err) {
+			return false, err
+		}
+	}
+	return false, nil
+}
+
+// listBackups returns the rotated files of the given path, from the oldest to the newest
+func listBackups(path string) ([]string, error) {
+	dir, filename := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+	ext := filepath.Ext(filename)
+	prefix := strings.TrimSuffix(filename, ext) + "-"
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) {
+			continue
+		}
+
+		uncompressed := name
+		for _, compressedExt := range compressionExtensions {
+			uncompressed = strings.TrimSuffix(uncompressed, compressedExt)
+		}
+		if !strings.HasSuffix(uncompressed, ext) {
+			continue
+		}
+
+		timestamp := strings.TrimSuffix(strings.TrimPrefix(uncompressed, prefix), ext)
+		if _, err := time.Parse(backupTimeFormat, timestamp); err != nil {
+			continue
+		}
+
+		backups = append(backups, filepath.Join(dir, name))
+	}
+
+	sort.Strings(backups)
+	return backups, nil
+}
+
+func removeOldBackups(path string, maxBackups int) error {
+	if maxBackups <= 0 {
+		return nil
+	}
+
+	backups, err := listBackups(path)
+	if err != nil {
+		return err
+	}
+
+	for len(backups) > maxBackups {
+		if err := os.Remove(backups[0]); err != nil {
+			return err
+		}
+		backups = backups[1:]
+	}
+
+	return nil
+}
+
+// compressFile replaces the given file with its compressed version
+func compressFile(path string, compression string) (err error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	target := path + compressionExtensions[compression]
+	dst, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(target)
+		}
+	}()
+
+	var encoder io.WriteCloser
+	switch compression {
+	case compressionZstd:
+		if encoder, err = zstd.NewWriter(dst); err != nil {
+			dst.Close()
+			return err
+		}
+	default:
+		encoder = gzip.NewWriter(dst)
+	}
+
+	if _, err = io.Copy(encoder, src); err != nil {
+		encoder.Close()
+		dst.Close()
+		return err
+	}
+	if err = encoder.Close(); err != nil {
+		dst.Close()
+		return err
+	}
+	if err = dst.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(path)
+}
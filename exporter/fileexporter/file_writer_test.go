@@ -0,0 +1,331 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fileexporter
+
+import (
+	"compress/gzip"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestFileWriterWithoutRotationTruncates(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("previous run\n"), 0600))
+
+	// test
+	w, err := newFileWriter(zap.NewNop(), path, nil, 0)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("current run\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// verify
+	buf, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "current run\n", string(buf))
+}
+
+func TestFileWriterRotatesBySize(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	path := filepath.Join(dir, "telemetry.json")
+	w, err := newFileWriter(zap.NewNop(), path, &Rotation{MaxMegabytes: 1}, 0)
+	require.NoError(t, err)
+	w.now = newFakeClock()
+
+	line := make([]byte, megabyte/2)
+	for i := range line {
+		line[i] = 'a'
+	}
+
+	// test
+	for i := 0; i < 5; i++ {
+		_, err = w.Write(line)
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	// verify
+	backups, err := listBackups(path)
+	require.NoError(t, err)
+	assert.Len(t, backups, 2)
+	for _, backup := range backups {
+		info, err := os.Stat(backup)
+		require.NoError(t, err)
+		assert.Equal(t, int64(megabyte), info.Size())
+	}
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(len(line)), info.Size())
+}
+
+func TestFileWriterRotatesByInterval(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	w, err := newFileWriter(zap.NewNop(), path, &Rotation{Interval: time.Hour}, 0)
+	require.NoError(t, err)
+	now := time.Date(2021, 11, 22, 10, 0, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+	w.openedAt = now
+
+	// test
+	_, err = w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	now = now.Add(30 * time.Minute)
+	_, err = w.Write([]byte("second\n"))
+	require.NoError(t, err)
+	now = now.Add(30 * time.Minute)
+	_, err = w.Write([]byte("third\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// verify
+	backup := filepath.Join(filepath.Dir(path), "telemetry-2021-11-22T11-00-00.000.json")
+	buf, err := ioutil.ReadFile(backup)
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(buf))
+
+	buf, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(buf))
+}
+
+func TestFileWriterKeepsDataUponRestartWithRotation(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	require.NoError(t, ioutil.WriteFile(path, []byte("previous run\n"), 0600))
+
+	// test
+	w, err := newFileWriter(zap.NewNop(), path, &Rotation{MaxMegabytes: 1}, 0)
+	require.NoError(t, err)
+	_, err = w.Write([]byte("current run\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// verify
+	buf, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "previous run\ncurrent run\n", string(buf))
+}
+
+func TestFileWriterRetention(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	path := filepath.Join(dir, "telemetry.json")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "unrelated.json"), []byte{}, 0600))
+
+	w, err := newFileWriter(zap.NewNop(), path, &Rotation{MaxMegabytes: 1, MaxBackups: 2}, 0)
+	require.NoError(t, err)
+	w.now = newFakeClock()
+	w.size = megabyte // forces the rotation upon each write
+
+	// test
+	var expected []string
+	for i := 0; i < 5; i++ {
+		expected = append(expected, backupName(path, w.now().Add(time.Second)))
+		_, err = w.Write([]byte("data\n"))
+		require.NoError(t, err)
+		w.size = megabyte
+	}
+	require.NoError(t, w.Close())
+
+	// verify
+	backups, err := listBackups(path)
+	require.NoError(t, err)
+	assert.Equal(t, expected[3:], backups)
+	assert.FileExists(t, filepath.Join(dir, "unrelated.json"))
+}
+
+func TestFileWriterRotationWithinMillisecond(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	w, err := newFileWriter(zap.NewNop(), path, &Rotation{MaxMegabytes: 1}, 0)
+	require.NoError(t, err)
+	now := time.Date(2021, 11, 22, 10, 15, 30, 0, time.UTC)
+	w.now = func() time.Time { return now }
+
+	// test
+	_, err = w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	for _, data := range []string{"second\n", "third\n"} {
+		w.size = megabyte // forces the rotation before the write
+		_, err = w.Write([]byte(data))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	// verify
+	backups, err := listBackups(path)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		backupName(path, now),
+		backupName(path, now.Add(time.Millisecond)),
+	}, backups)
+	for i, expected := range []string{"first\n", "second\n"} {
+		buf, err := ioutil.ReadFile(backups[i])
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(buf))
+	}
+}
+
+func TestFileWriterRotationFailure(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	w, err := newFileWriter(zap.NewNop(), path, &Rotation{MaxMegabytes: 1}, time.Hour)
+	require.NoError(t, err)
+	w.now = newFakeClock()
+	w.rename = func(string, string) error {
+		return errors.New("read-only file system")
+	}
+
+	// test
+	_, err = w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	w.size = megabyte // forces the rotation before the write
+	_, err = w.Write([]byte("second\n"))
+	require.NoError(t, err, "the data should be written to the current file")
+
+	// the next rotation succeeds once the file can be renamed again
+	w.rename = os.Rename
+	w.size = megabyte
+	_, err = w.Write([]byte("third\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	// verify
+	backups, err := listBackups(path)
+	require.NoError(t, err)
+	require.Len(t, backups, 1)
+	buf, err := ioutil.ReadFile(backups[0])
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(buf))
+	buf, err = ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "third\n", string(buf))
+}
+
+func TestFileWriterCompression(t *testing.T) {
+	for _, tt := range []struct {
+		compression string
+		decompress  func(t *testing.T, path string) []byte
+	}{
+		{
+			compressionGzip,
+			func(t *testing.T, path string) []byte {
+				f, err := os.Open(path)
+				require.NoError(t, err)
+				defer f.Close()
+				r, err := gzip.NewReader(f)
+				require.NoError(t, err)
+				buf, err := ioutil.ReadAll(r)
+				require.NoError(t, err)
+				return buf
+			},
+		},
+		{
+			compressionZstd,
+			func(t *testing.T, path string) []byte {
+				f, err := os.Open(path)
+				require.NoError(t, err)
+				defer f.Close()
+				r, err := zstd.NewReader(f)
+				require.NoError(t, err)
+				defer r.Close()
+				buf, err := ioutil.ReadAll(r)
+				require.NoError(t, err)
+				return buf
+			},
+		},
+	} {
+		t.Run(tt.compression, func(t *testing.T) {
+			// prepare
+			path := filepath.Join(t.TempDir(), "telemetry.json")
+			w, err := newFileWriter(zap.NewNop(), path, &Rotation{Interval: time.Minute, Compression: tt.compression}, 0)
+			require.NoError(t, err)
+			w.now = newFakeClock()
+
+			// test
+			_, err = w.Write([]byte("first\n"))
+			require.NoError(t, err)
+			w.openedAt = time.Time{}
+			_, err = w.Write([]byte("second\n"))
+			require.NoError(t, err)
+			require.NoError(t, w.Close())
+
+			// verify
+			backups, err := listBackups(path)
+			require.NoError(t, err)
+			require.Len(t, backups, 1)
+			assert.Equal(t, compressionExtensions[tt.compression], filepath.Ext(backups[0]))
+			assert.Equal(t, "first\n", string(tt.decompress(t, backups[0])))
+		})
+	}
+}
+
+func TestFileWriterFlushInterval(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	w, err := newFileWriter(zap.NewNop(), path, nil, 10*time.Millisecond)
+	require.NoError(t, err)
+	defer w.Close()
+
+	// test
+	_, err = w.Write([]byte("data\n"))
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		buf, err := ioutil.ReadFile(path)
+		return err == nil && string(buf) == "data\n"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestFileWriterFlushesUponClose(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	w, err := newFileWriter(zap.NewNop(), path, nil, time.Hour)
+	require.NoError(t, err)
+
+	_, err = w.Write([]byte("data\n"))
+	require.NoError(t, err)
+
+	// test
+	require.NoError(t, w.Close())
+
+	// verify
+	buf, err := ioutil.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "data\n", string(buf))
+}
+
+// newFakeClock returns a clock advancing one second each time it's read, so that rotated files get distinct names
+func newFakeClock() func() time.Time {
+	now := time.Date(2021, 11, 22, 10, 0, 0, 0, time.UTC)
+	return func() time.Time {
+		now = now.Add(time.Second)
+		return now
+	}
+}
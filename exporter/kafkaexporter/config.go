@@ -15,6 +15,7 @@
 package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -35,9 +36,21 @@ type Config struct {
 	// The name of the kafka topic to export to (default otlp_spans for traces, otlp_metrics for metrics)
 	Topic string `mapstructure:"topic"`
 
+	// TopicFromAttribute is the resource attribute holding the topic to export the data of the resource to.
+	// Data of resources without the attribute is exported to Topic.
+	TopicFromAttribute string `mapstructure:"topic_from_attribute"`
+
 	// Encoding of messages (default "otlp_proto")
 	Encoding string `mapstructure:"encoding"`
 
+	// PartitionTracesByID keys the messages by the trace ID of their spans, splitting batches
+	// per trace, so all the spans of a trace end up in the same partition. Only used for traces.
+	PartitionTracesByID bool `mapstructure:"partition_traces_by_id"`
+
+	// PartitionByResourceAttribute keys the messages by the value of this resource attribute,
+	// splitting batches per value, so all the data with the same value ends up in the same partition.
+	PartitionByResourceAttribute string `mapstructure:"partition_by_resource_attribute"`
+
 	// Metadata is the namespace for metadata management properties used by the
 	// Client, and shared by the Producer/Consumer.
 	Metadata Metadata `mapstructure:"metadata"`
@@ -83,5 +96,8 @@ var _ config.Exporter = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.PartitionTracesByID && cfg.PartitionByResourceAttribute != "" {
+		return errors.New("partition_traces_by_id and partition_by_resource_attribute cannot be used together")
+	}
 	return nil
 }
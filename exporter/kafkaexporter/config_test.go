@@ -54,9 +54,11 @@ func TestLoadConfig(t *testing.T) {
 			NumConsumers: 2,
 			QueueSize:    10,
 		},
-		Topic:    "spans",
-		Encoding: "otlp_proto",
-		Brokers:  []string{"foo:123", "bar:456"},
+		Topic:               "spans",
+		TopicFromAttribute:  "kafka.topic",
+		Encoding:            "otlp_proto",
+		PartitionTracesByID: true,
+		Brokers:             []string{"foo:123", "bar:456"},
 		Authentication: Authentication{
 			PlainText: &PlainTextConfig{
 				Username: "jdoe",
@@ -75,3 +77,12 @@ func TestLoadConfig(t *testing.T) {
 		},
 	}, c)
 }
+
+func TestValidate_partitioning(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.PartitionTracesByID = true
+	assert.NoError(t, cfg.Validate())
+
+	cfg.PartitionByResourceAttribute = "tenant"
+	assert.EqualError(t, cfg.Validate(), "partition_traces_by_id and partition_by_resource_attribute cannot be used together")
+}
@@ -29,10 +29,11 @@ var errUnrecognizedEncoding = fmt.Errorf("unrecognized encoding")
 
 // kafkaTracesProducer uses sarama to produce trace messages to Kafka.
 type kafkaTracesProducer struct {
-	producer  sarama.SyncProducer
-	topic     string
-	marshaler TracesMarshaler
-	logger    *zap.Logger
+	producer           sarama.SyncProducer
+	router             messageRouter
+	partitionByTraceID bool
+	marshaler          TracesMarshaler
+	logger             *zap.Logger
 }
 
 type kafkaErrors struct {
@@ -45,7 +46,7 @@ func (ke kafkaErrors) Error() string {
 }
 
 func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td pdata.Traces) error {
-	messages, err := e.marshaler.Marshal(td, e.topic)
+	messages, err := e.marshal(td)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -61,6 +62,23 @@ func (e *kafkaTracesProducer) tracesPusher(_ context.Context, td pdata.Traces) e
 	return nil
 }
 
+func (e *kafkaTracesProducer) marshal(td pdata.Traces) ([]*sarama.ProducerMessage, error) {
+	if e.router.isStatic() && !e.partitionByTraceID {
+		return e.marshaler.Marshal(td, e.router.topic)
+	}
+	destinations, parts := e.router.routeTraces(td, e.partitionByTraceID)
+	var messages []*sarama.ProducerMessage
+	for i, d := range destinations {
+		partMessages, err := e.marshaler.Marshal(parts[i], d.topic)
+		if err != nil {
+			return nil, err
+		}
+		d.setKey(partMessages)
+		messages = append(messages, partMessages...)
+	}
+	return messages, nil
+}
+
 func (e *kafkaTracesProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
@@ -68,13 +86,13 @@ func (e *kafkaTracesProducer) Close(context.Context) error {
 // kafkaMetricsProducer uses sarama to produce metrics messages to kafka
 type kafkaMetricsProducer struct {
 	producer  sarama.SyncProducer
-	topic     string
+	router    messageRouter
 	marshaler MetricsMarshaler
 	logger    *zap.Logger
 }
 
 func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pdata.Metrics) error {
-	messages, err := e.marshaler.Marshal(md, e.topic)
+	messages, err := e.marshal(md)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -90,6 +108,23 @@ func (e *kafkaMetricsProducer) metricsDataPusher(_ context.Context, md pdata.Met
 	return nil
 }
 
+func (e *kafkaMetricsProducer) marshal(md pdata.Metrics) ([]*sarama.ProducerMessage, error) {
+	if e.router.isStatic() {
+		return e.marshaler.Marshal(md, e.router.topic)
+	}
+	destinations, parts := e.router.routeMetrics(md)
+	var messages []*sarama.ProducerMessage
+	for i, d := range destinations {
+		partMessages, err := e.marshaler.Marshal(parts[i], d.topic)
+		if err != nil {
+			return nil, err
+		}
+		d.setKey(partMessages)
+		messages = append(messages, partMessages...)
+	}
+	return messages, nil
+}
+
 func (e *kafkaMetricsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
@@ -97,13 +132,13 @@ func (e *kafkaMetricsProducer) Close(context.Context) error {
 // kafkaLogsProducer uses sarama to produce logs messages to kafka
 type kafkaLogsProducer struct {
 	producer  sarama.SyncProducer
-	topic     string
+	router    messageRouter
 	marshaler LogsMarshaler
 	logger    *zap.Logger
 }
 
 func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld pdata.Logs) error {
-	messages, err := e.marshaler.Marshal(ld, e.topic)
+	messages, err := e.marshal(ld)
 	if err != nil {
 		return consumererror.NewPermanent(err)
 	}
@@ -119,6 +154,23 @@ func (e *kafkaLogsProducer) logsDataPusher(_ context.Context, ld pdata.Logs) err
 	return nil
 }
 
+func (e *kafkaLogsProducer) marshal(ld pdata.Logs) ([]*sarama.ProducerMessage, error) {
+	if e.router.isStatic() {
+		return e.marshaler.Marshal(ld, e.router.topic)
+	}
+	destinations, parts := e.router.routeLogs(ld)
+	var messages []*sarama.ProducerMessage
+	for i, d := range destinations {
+		partMessages, err := e.marshaler.Marshal(parts[i], d.topic)
+		if err != nil {
+			return nil, err
+		}
+		d.setKey(partMessages)
+		messages = append(messages, partMessages...)
+	}
+	return messages, nil
+}
+
 func (e *kafkaLogsProducer) Close(context.Context) error {
 	return e.producer.Close()
 }
@@ -165,7 +217,7 @@ func newMetricsExporter(config Config, set component.ExporterCreateSettings, mar
 
 	return &kafkaMetricsProducer{
 		producer:  producer,
-		topic:     config.Topic,
+		router:    newMessageRouter(config),
 		marshaler: marshaler,
 		logger:    set.Logger,
 	}, nil
@@ -183,10 +235,11 @@ func newTracesExporter(config Config, set component.ExporterCreateSettings, mars
 		return nil, err
 	}
 	return &kafkaTracesProducer{
-		producer:  producer,
-		topic:     config.Topic,
-		marshaler: marshaler,
-		logger:    set.Logger,
+		producer:           producer,
+		router:             newMessageRouter(config),
+		partitionByTraceID: config.PartitionTracesByID,
+		marshaler:          marshaler,
+		logger:             set.Logger,
 	}, nil
 }
 
@@ -202,7 +255,7 @@ func newLogsExporter(config Config, set component.ExporterCreateSettings, marsha
 
 	return &kafkaLogsProducer{
 		producer:  producer,
-		topic:     config.Topic,
+		router:    newMessageRouter(config),
 		marshaler: marshaler,
 		logger:    set.Logger,
 	}, nil
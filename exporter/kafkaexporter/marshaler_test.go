@@ -24,6 +24,7 @@ import (
 func TestDefaultTracesMarshalers(t *testing.T) {
 	expectedEncodings := []string{
 		"otlp_proto",
+		"otlp_json",
 		"jaeger_proto",
 		"jaeger_json",
 	}
@@ -41,6 +42,7 @@ func TestDefaultTracesMarshalers(t *testing.T) {
 func TestDefaultMetricsMarshalers(t *testing.T) {
 	expectedEncodings := []string{
 		"otlp_proto",
+		"otlp_json",
 	}
 	marshalers := metricsMarshalers()
 	assert.Equal(t, len(expectedEncodings), len(marshalers))
@@ -56,6 +58,8 @@ func TestDefaultMetricsMarshalers(t *testing.T) {
 func TestDefaultLogsMarshalers(t *testing.T) {
 	expectedEncodings := []string{
 		"otlp_proto",
+		"otlp_json",
+		"raw",
 	}
 	marshalers := logsMarshalers()
 	assert.Equal(t, len(expectedEncodings), len(marshalers))
@@ -0,0 +1,177 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// messageRouter picks the topic and the key of the messages produced for each resource.
+type messageRouter struct {
+	// topic is used when topicFromAttribute is not set or not present on the resource.
+	topic              string
+	topicFromAttribute string
+	keyAttribute       string
+}
+
+func newMessageRouter(config Config) messageRouter {
+	return messageRouter{
+		topic:              config.Topic,
+		topicFromAttribute: config.TopicFromAttribute,
+		keyAttribute:       config.PartitionByResourceAttribute,
+	}
+}
+
+// isStatic returns true if all the messages are sent to the same topic without a key.
+func (r messageRouter) isStatic() bool {
+	return r.topicFromAttribute == "" && r.keyAttribute == ""
+}
+
+func (r messageRouter) route(resource pdata.Resource) (topic string, key string) {
+	topic = r.topic
+	if r.topicFromAttribute != "" {
+		if v, ok := resource.Attributes().Get(r.topicFromAttribute); ok && v.AsString() != "" {
+			topic = v.AsString()
+		}
+	}
+	if r.keyAttribute != "" {
+		if v, ok := resource.Attributes().Get(r.keyAttribute); ok {
+			key = v.AsString()
+		}
+	}
+	return topic, key
+}
+
+// messageDestination is the topic and key shared by the messages of a part of the data.
+type messageDestination struct {
+	topic string
+	key   string
+}
+
+// setKey sets the key of the messages the marshaler did not set one for.
+func (d messageDestination) setKey(messages []*sarama.ProducerMessage) {
+	if d.key == "" {
+		return
+	}
+	for _, m := range messages {
+		if m.Key == nil {
+			m.Key = sarama.ByteEncoder(d.key)
+		}
+	}
+}
+
+// routeTraces splits the traces per destination, keeping the order in which the destinations
+// are first found. If byTraceID is set, the spans of each trace are keyed by their trace ID.
+func (r messageRouter) routeTraces(td pdata.Traces, byTraceID bool) ([]messageDestination, []pdata.Traces) {
+	var destinations []messageDestination
+	var parts []pdata.Traces
+	index := map[messageDestination]int{}
+	partFor := func(d messageDestination) pdata.Traces {
+		i, ok := index[d]
+		if !ok {
+			i = len(parts)
+			index[d] = i
+			destinations = append(destinations, d)
+			parts = append(parts, pdata.NewTraces())
+		}
+		return parts[i]
+	}
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		topic, key := r.route(rs.Resource())
+		if !byTraceID {
+			rs.CopyTo(partFor(messageDestination{topic: topic, key: key}).ResourceSpans().AppendEmpty())
+			continue
+		}
+
+		// The spans of each trace are copied into a resource of their own per destination.
+		perTrace := map[messageDestination]pdata.ResourceSpans{}
+		ilss := rs.InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			ils := ilss.At(j)
+			perTraceILS := map[messageDestination]pdata.InstrumentationLibrarySpans{}
+			spans := ils.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				d := messageDestination{topic: topic, key: span.TraceID().HexString()}
+				destILS, ok := perTraceILS[d]
+				if !ok {
+					destRS, ok := perTrace[d]
+					if !ok {
+						destRS = partFor(d).ResourceSpans().AppendEmpty()
+						rs.Resource().CopyTo(destRS.Resource())
+						destRS.SetSchemaUrl(rs.SchemaUrl())
+						perTrace[d] = destRS
+					}
+					destILS = destRS.InstrumentationLibrarySpans().AppendEmpty()
+					ils.InstrumentationLibrary().CopyTo(destILS.InstrumentationLibrary())
+					destILS.SetSchemaUrl(ils.SchemaUrl())
+					perTraceILS[d] = destILS
+				}
+				span.CopyTo(destILS.Spans().AppendEmpty())
+			}
+		}
+	}
+	return destinations, parts
+}
+
+// routeMetrics splits the metrics per destination, keeping the order in which the destinations
+// are first found.
+func (r messageRouter) routeMetrics(md pdata.Metrics) ([]messageDestination, []pdata.Metrics) {
+	var destinations []messageDestination
+	var parts []pdata.Metrics
+	index := map[messageDestination]int{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		topic, key := r.route(rm.Resource())
+		d := messageDestination{topic: topic, key: key}
+		j, ok := index[d]
+		if !ok {
+			j = len(parts)
+			index[d] = j
+			destinations = append(destinations, d)
+			parts = append(parts, pdata.NewMetrics())
+		}
+		rm.CopyTo(parts[j].ResourceMetrics().AppendEmpty())
+	}
+	return destinations, parts
+}
+
+// routeLogs splits the logs per destination, keeping the order in which the destinations
+// are first found.
+func (r messageRouter) routeLogs(ld pdata.Logs) ([]messageDestination, []pdata.Logs) {
+	var destinations []messageDestination
+	var parts []pdata.Logs
+	index := map[messageDestination]int{}
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		topic, key := r.route(rl.Resource())
+		d := messageDestination{topic: topic, key: key}
+		j, ok := index[d]
+		if !ok {
+			j = len(parts)
+			index[d] = j
+			destinations = append(destinations, d)
+			parts = append(parts, pdata.NewLogs())
+		}
+		rl.CopyTo(parts[j].ResourceLogs().AppendEmpty())
+	}
+	return destinations, parts
+}
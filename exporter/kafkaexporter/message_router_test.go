@@ -0,0 +1,175 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+var (
+	traceID1 = pdata.NewTraceID([16]byte{1})
+	traceID2 = pdata.NewTraceID([16]byte{2})
+)
+
+func TestTracesMarshal_static(t *testing.T) {
+	p := kafkaTracesProducer{
+		router:    messageRouter{topic: "spans"},
+		marshaler: newPdataTracesMarshaler(otlp.NewProtobufTracesMarshaler(), defaultEncoding),
+	}
+	messages, err := p.marshal(tracesWithResources(map[string]string{"tenant": "a"}, map[string]string{"tenant": "b"}))
+	require.NoError(t, err)
+	require.Len(t, messages, 1)
+	assert.Equal(t, "spans", messages[0].Topic)
+	assert.Nil(t, messages[0].Key)
+}
+
+func TestTracesMarshal_partitionByTraceID(t *testing.T) {
+	p := kafkaTracesProducer{
+		router:             messageRouter{topic: "spans"},
+		partitionByTraceID: true,
+		marshaler:          newPdataTracesMarshaler(otlp.NewProtobufTracesMarshaler(), defaultEncoding),
+	}
+	td := tracesWithResources(map[string]string{"tenant": "a"}, map[string]string{"tenant": "b"})
+	messages, err := p.marshal(td)
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+
+	unmarshaler := otlp.NewProtobufTracesUnmarshaler()
+	for i, traceID := range []pdata.TraceID{traceID1, traceID2} {
+		assert.Equal(t, "spans", messages[i].Topic)
+		assert.Equal(t, sarama.ByteEncoder(traceID.HexString()), messages[i].Key)
+
+		bts, err := messages[i].Value.Encode()
+		require.NoError(t, err)
+		part, err := unmarshaler.UnmarshalTraces(bts)
+		require.NoError(t, err)
+		// Each trace has a span on both resources.
+		require.Equal(t, 2, part.ResourceSpans().Len())
+		require.Equal(t, 2, part.SpanCount())
+		for j := 0; j < part.ResourceSpans().Len(); j++ {
+			rs := part.ResourceSpans().At(j)
+			assert.Equal(t, 1, rs.InstrumentationLibrarySpans().Len())
+			assert.Equal(t, "library", rs.InstrumentationLibrarySpans().At(0).InstrumentationLibrary().Name())
+			assert.Equal(t, traceID, rs.InstrumentationLibrarySpans().At(0).Spans().At(0).TraceID())
+		}
+	}
+}
+
+func TestTracesMarshal_jaegerKeepsItsKey(t *testing.T) {
+	p := kafkaTracesProducer{
+		router:    messageRouter{topic: "spans", keyAttribute: "tenant"},
+		marshaler: jaegerMarshaler{marshaler: jaegerProtoSpanMarshaler{}},
+	}
+	messages, err := p.marshal(tracesWithResources(map[string]string{"tenant": "a"}))
+	require.NoError(t, err)
+	require.Len(t, messages, 2)
+	assert.Equal(t, sarama.ByteEncoder(traceID1.HexString()), messages[0].Key)
+	assert.Equal(t, sarama.ByteEncoder(traceID2.HexString()), messages[1].Key)
+}
+
+func TestMetricsMarshal_routing(t *testing.T) {
+	p := kafkaMetricsProducer{
+		router:    messageRouter{topic: "metrics", topicFromAttribute: "kafka.topic", keyAttribute: "tenant"},
+		marshaler: newPdataMetricsMarshaler(otlp.NewProtobufMetricsMarshaler(), defaultEncoding),
+	}
+	md := pdata.NewMetrics()
+	for _, attrs := range []map[string]string{
+		{"tenant": "a", "kafka.topic": "metrics_a"},
+		{"tenant": "b"},
+		{"tenant": "a", "kafka.topic": "metrics_a"},
+		{},
+	} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		for k, v := range attrs {
+			rm.Resource().Attributes().InsertString(k, v)
+		}
+		rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("metric")
+	}
+
+	messages, err := p.marshal(md)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+
+	unmarshaler := otlp.NewProtobufMetricsUnmarshaler()
+	expected := []struct {
+		topic   string
+		key     sarama.Encoder
+		metrics int
+	}{
+		{topic: "metrics_a", key: sarama.ByteEncoder("a"), metrics: 2},
+		{topic: "metrics", key: sarama.ByteEncoder("b"), metrics: 1},
+		{topic: "metrics", key: nil, metrics: 1},
+	}
+	for i, e := range expected {
+		assert.Equal(t, e.topic, messages[i].Topic)
+		assert.Equal(t, e.key, messages[i].Key)
+		bts, err := messages[i].Value.Encode()
+		require.NoError(t, err)
+		part, err := unmarshaler.UnmarshalMetrics(bts)
+		require.NoError(t, err)
+		assert.Equal(t, e.metrics, part.MetricCount())
+	}
+}
+
+func TestLogsMarshal_routing(t *testing.T) {
+	p := kafkaLogsProducer{
+		router:    messageRouter{topic: "logs", topicFromAttribute: "kafka.topic"},
+		marshaler: newRawLogsMarshaler(),
+	}
+	ld := pdata.NewLogs()
+	for _, topic := range []string{"logs_a", "", "logs_a"} {
+		rl := ld.ResourceLogs().AppendEmpty()
+		if topic != "" {
+			rl.Resource().Attributes().InsertString("kafka.topic", topic)
+		}
+		rl.InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty().Body().SetStringVal("body")
+	}
+
+	messages, err := p.marshal(ld)
+	require.NoError(t, err)
+	require.Len(t, messages, 3)
+	assert.Equal(t, "logs_a", messages[0].Topic)
+	assert.Equal(t, "logs_a", messages[1].Topic)
+	assert.Equal(t, "logs", messages[2].Topic)
+	for _, m := range messages {
+		assert.Nil(t, m.Key)
+	}
+}
+
+// tracesWithResources creates a resource per attribute map, each holding a span of traceID1
+// and a span of traceID2.
+func tracesWithResources(resourceAttrs ...map[string]string) pdata.Traces {
+	td := pdata.NewTraces()
+	for _, attrs := range resourceAttrs {
+		rs := td.ResourceSpans().AppendEmpty()
+		for k, v := range attrs {
+			rs.Resource().Attributes().InsertString(k, v)
+		}
+		ils := rs.InstrumentationLibrarySpans().AppendEmpty()
+		ils.InstrumentationLibrary().SetName("library")
+		for i, traceID := range []pdata.TraceID{traceID1, traceID2} {
+			span := ils.Spans().AppendEmpty()
+			span.SetTraceID(traceID)
+			span.SetSpanID(pdata.NewSpanID([8]byte{byte(i + 1)}))
+		}
+	}
+	return td
+}
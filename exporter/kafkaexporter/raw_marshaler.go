@@ -0,0 +1,70 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/kafkaexporter"
+
+import (
+	"github.com/Shopify/sarama"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// rawLogsMarshaler sends each log record as a message holding only its body.
+type rawLogsMarshaler struct{}
+
+var _ LogsMarshaler = rawLogsMarshaler{}
+
+func newRawLogsMarshaler() LogsMarshaler {
+	return rawLogsMarshaler{}
+}
+
+func (r rawLogsMarshaler) Marshal(ld pdata.Logs, topic string) ([]*sarama.ProducerMessage, error) {
+	var messages []*sarama.ProducerMessage
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				body := logBodyAsBytes(logs.At(k).Body())
+				if len(body) == 0 {
+					continue
+				}
+				messages = append(messages, &sarama.ProducerMessage{
+					Topic: topic,
+					Value: sarama.ByteEncoder(body),
+				})
+			}
+		}
+	}
+	return messages, nil
+}
+
+func (r rawLogsMarshaler) Encoding() string {
+	return "raw"
+}
+
+// logBodyAsBytes returns strings and bytes bodies as they are, and any other body
+// in its string representation, which is JSON for maps and arrays.
+func logBodyAsBytes(body pdata.AttributeValue) []byte {
+	switch body.Type() {
+	case pdata.AttributeValueTypeEmpty:
+		return nil
+	case pdata.AttributeValueTypeString:
+		return []byte(body.StringVal())
+	case pdata.AttributeValueTypeBytes:
+		return body.BytesVal()
+	default:
+		return []byte(body.AsString())
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkaexporter
+
+import (
+	"testing"
+
+	"github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestRawLogsMarshaler(t *testing.T) {
+	ld := pdata.NewLogs()
+	logs := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs()
+	logs.AppendEmpty().Body().SetStringVal("string body")
+	logs.AppendEmpty().Body().SetBytesVal([]byte("bytes body"))
+	logs.AppendEmpty().Body().SetIntVal(42)
+	mapBody := logs.AppendEmpty().Body()
+	pdata.NewAttributeValueMap().CopyTo(mapBody)
+	mapBody.MapVal().InsertString("key", "value")
+	// Log records without a body are not sent.
+	logs.AppendEmpty()
+
+	m := newRawLogsMarshaler()
+	assert.Equal(t, "raw", m.Encoding())
+	messages, err := m.Marshal(ld, "topic")
+	require.NoError(t, err)
+
+	expected := []string{"string body", "bytes body", "42", `{"key":"value"}`}
+	require.Len(t, messages, len(expected))
+	for i, msg := range messages {
+		assert.Equal(t, "topic", msg.Topic)
+		assert.Equal(t, sarama.ByteEncoder(expected[i]), msg.Value)
+	}
+}
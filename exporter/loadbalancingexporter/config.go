@@ -17,6 +17,8 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 import (
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/exporter/otlpexporter"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
 
 // Config defines configuration for the exporter.
@@ -24,6 +26,10 @@ type Config struct {
 	config.ExporterSettings `mapstructure:",squash"`
 	Protocol                Protocol         `mapstructure:"protocol"`
 	Resolver                ResolverSettings `mapstructure:"resolver"`
+
+	// RoutingKey determines how the data is assigned to the backends: "traceID" (default), "service",
+	// or the name of any other resource attribute. Metrics are always routed by resource attributes.
+	RoutingKey string `mapstructure:"routing_key"`
 }
 
 // Protocol holds the individual protocol-specific settings. Only OTLP is supported at the moment.
@@ -35,6 +41,8 @@ type Protocol struct {
 type ResolverSettings struct {
 	Static *StaticResolver `mapstructure:"static"`
 	DNS    *DNSResolver    `mapstructure:"dns"`
+	SRV    *SRVResolver    `mapstructure:"srv"`
+	K8sSvc *K8sSvcResolver `mapstructure:"k8s"`
 }
 
 // StaticResolver defines the configuration for the resolver providing a fixed list of backends
@@ -47,3 +55,20 @@ type DNSResolver struct {
 	Hostname string `mapstructure:"hostname"`
 	Port     string `mapstructure:"port"`
 }
+
+// SRVResolver defines the configuration for the DNS SRV resolver
+type SRVResolver struct {
+	// Hostname is the name of the SRV record to query, such as "_otlp._tcp.backends.example.com"
+	Hostname string `mapstructure:"hostname"`
+}
+
+// K8sSvcResolver defines the configuration for the resolver watching the endpoints of a Kubernetes service
+type K8sSvcResolver struct {
+	k8sconfig.APIConfig `mapstructure:",squash"`
+
+	// Service is the name of the service to watch, optionally followed by its namespace, as in "backends.observability"
+	Service string `mapstructure:"service"`
+
+	// Ports is the list of ports to use for each endpoint of the service. Default: 4317.
+	Ports []int32 `mapstructure:"ports"`
+}
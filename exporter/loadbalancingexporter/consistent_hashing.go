@@ -17,8 +17,6 @@ package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry
 import (
 	"hash/crc32"
 	"sort"
-
-	"go.opentelemetry.io/collector/model/pdata"
 )
 
 const maxPositions uint32 = 36000 // 360 degrees with two decimal places
@@ -48,11 +46,10 @@ func newHashRing(endpoints []string) *hashRing {
 	}
 }
 
-// endpointFor calculates which backend is responsible for the given traceID
-func (h *hashRing) endpointFor(traceID pdata.TraceID) string {
-	b := traceID.Bytes()
+// endpointFor calculates which backend is responsible for the given identifier, such as a trace ID
+func (h *hashRing) endpointFor(identifier []byte) string {
 	hasher := crc32.NewIEEE()
-	hasher.Write(b[:])
+	hasher.Write(identifier)
 	hash := hasher.Sum32()
 	pos := hash % maxPositions
 
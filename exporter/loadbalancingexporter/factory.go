@@ -38,6 +38,7 @@ func NewFactory() component.ExporterFactory {
 		createDefaultConfig,
 		exporterhelper.WithTraces(createTracesExporter),
 		exporterhelper.WithLogs(createLogExporter),
+		exporterhelper.WithMetrics(createMetricsExporter),
 	)
 }
 
@@ -60,3 +61,7 @@ func createTracesExporter(_ context.Context, params component.ExporterCreateSett
 func createLogExporter(_ context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.LogsExporter, error) {
 	return newLogsExporter(params, cfg)
 }
+
+func createMetricsExporter(_ context.Context, params component.ExporterCreateSettings, cfg config.Exporter) (component.MetricsExporter, error) {
+	return newMetricsExporter(params, cfg)
+}
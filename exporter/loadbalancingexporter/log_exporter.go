@@ -93,7 +93,8 @@ func (e *logExporterImp) consumeLog(ctx context.Context, ld pdata.Logs) error {
 		balancingKey = random()
 	}
 
-	endpoint := e.loadBalancer.Endpoint(balancingKey)
+	key := balancingKey.Bytes()
+	endpoint := e.loadBalancer.Endpoint(key[:])
 	exp, err := e.loadBalancer.Exporter(endpoint)
 	if err != nil {
 		return err
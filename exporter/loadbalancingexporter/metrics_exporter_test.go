@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenthelper"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestNewMetricsExporter(t *testing.T) {
+	for _, tt := range []struct {
+		desc   string
+		config *Config
+		err    error
+	}{
+		{
+			"simple",
+			simpleConfig(),
+			nil,
+		},
+		{
+			"empty",
+			&Config{},
+			errNoResolver,
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// test
+			_, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), tt.config)
+
+			// verify
+			require.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockMetricsExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	sink := new(consumertest.MetricsSink)
+	lb.exporters["endpoint-1"] = newMockMetricsExporter(sink.ConsumeMetrics)
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics("svc-a", "svc-b"))
+
+	// verify
+	assert.Nil(t, res)
+	assert.Len(t, sink.AllMetrics(), 2)
+}
+
+func TestConsumeMetricsUnexpectedExporterType(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockExporter(), nil
+	}
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), simpleConfig(), componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newMetricsExporter(componenttest.NewNopExporterCreateSettings(), simpleConfig())
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	// pre-load an exporter here, so that we don't use the actual OTLP exporter
+	lb.exporters["endpoint-1"] = newNopMockExporter()
+	lb.res = &mockResolver{
+		triggerCallbacks: true,
+		onResolve: func(ctx context.Context) ([]string, error) {
+			return []string{"endpoint-1"}, nil
+		},
+	}
+	p.loadBalancer = lb
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+	defer p.Shutdown(context.Background())
+
+	// test
+	res := p.ConsumeMetrics(context.Background(), simpleMetrics("svc-a"))
+
+	// verify
+	assert.Error(t, res)
+	assert.EqualError(t, res, fmt.Sprintf("unable to export metrics, unexpected exporter type: expected *component.MetricsExporter but got %T", newNopMockExporter()))
+}
+
+func TestSplitMetricsByResource(t *testing.T) {
+	for _, tt := range []struct {
+		desc      string
+		attribute string
+		expected  [][]byte
+	}{
+		{
+			"all resource attributes",
+			"",
+			[][]byte{
+				[]byte("host.name=host-1;service.name=svc-a;"),
+				[]byte("host.name=host-2;service.name=svc-a;"),
+				[]byte("service.name=svc-b;"),
+			},
+		},
+		{
+			"single resource attribute",
+			"service.name",
+			[][]byte{
+				[]byte("svc-a"),
+				[]byte("svc-b"),
+			},
+		},
+		{
+			"missing resource attribute",
+			"host.name",
+			[][]byte{
+				[]byte("host-1"),
+				[]byte("host-2"),
+				[]byte("service.name=svc-b;"),
+			},
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			// prepare
+			md := simpleMetrics("svc-a", "svc-a", "svc-b")
+			md.ResourceMetrics().At(0).Resource().Attributes().InsertString("host.name", "host-1")
+			md.ResourceMetrics().At(1).Resource().Attributes().InsertString("host.name", "host-2")
+
+			// test
+			routed := splitMetricsByResource(md, tt.attribute)
+
+			// verify
+			var keys [][]byte
+			numResources := 0
+			for _, batch := range routed {
+				keys = append(keys, batch.key)
+				numResources += batch.metrics.ResourceMetrics().Len()
+			}
+			assert.Equal(t, tt.expected, keys)
+			assert.Equal(t, 3, numResources)
+		})
+	}
+}
+
+func simpleMetrics(services ...string) pdata.Metrics {
+	metrics := pdata.NewMetrics()
+	for _, svc := range services {
+		rm := metrics.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().InsertString("service.name", svc)
+		rm.InstrumentationLibraryMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+	}
+	return metrics
+}
+
+type mockMetricsExporter struct {
+	component.Component
+	ConsumeMetricsFn func(ctx context.Context, md pdata.Metrics) error
+}
+
+func (e *mockMetricsExporter) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (e *mockMetricsExporter) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
+	if e.ConsumeMetricsFn == nil {
+		return nil
+	}
+	return e.ConsumeMetricsFn(ctx, md)
+}
+
+func newMockMetricsExporter(consumeMetricsFn func(ctx context.Context, md pdata.Metrics) error) component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component:        componenthelper.New(),
+		ConsumeMetricsFn: consumeMetricsFn,
+	}
+}
+
+func newNopMockMetricsExporter() component.MetricsExporter {
+	return &mockMetricsExporter{
+		Component: componenthelper.New(),
+		ConsumeMetricsFn: func(ctx context.Context, md pdata.Metrics) error {
+			return nil
+		},
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/tag"
+	"go.uber.org/zap"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ resolver = (*k8sResolver)(nil)
+
+const (
+	defaultK8sNamespace   = "default"
+	defaultK8sSyncTimeout = 10 * time.Second
+)
+
+var (
+	errNoSvc          = errors.New("no service specified to resolve the backends")
+	errK8sSyncTimeout = errors.New("timed out waiting for the endpoints of the service to be synced")
+)
+
+// k8sResolver resolves the backends from the Endpoints object of a Kubernetes service,
+// typically a headless service in front of the backend collectors
+type k8sResolver struct {
+	logger *zap.Logger
+
+	client      kubernetes.Interface
+	svcName     string
+	svcNs       string
+	ports       []int32
+	syncTimeout time.Duration
+
+	lister corelisters.EndpointsLister
+
+	endpoints         []string
+	onChangeCallbacks []func([]string)
+
+	stopCh             chan (struct{})
+	informerWg         sync.WaitGroup
+	updateLock         sync.Mutex
+	changeCallbackLock sync.RWMutex
+}
+
+// newK8sResolver creates a resolver for the given service, in the format "name" or "name.namespace"
+func newK8sResolver(logger *zap.Logger, client kubernetes.Interface, service string, ports []int32) (*k8sResolver, error) {
+	if len(service) == 0 {
+		return nil, errNoSvc
+	}
+
+	name, namespace := service, defaultK8sNamespace
+	if i := strings.Index(service, "."); i >= 0 {
+		name, namespace = service[:i], service[i+1:]
+	}
+
+	if len(ports) == 0 {
+		ports = []int32{4317}
+	}
+
+	return &k8sResolver{
+		logger:      logger,
+		client:      client,
+		svcName:     name,
+		svcNs:       namespace,
+		ports:       ports,
+		syncTimeout: defaultK8sSyncTimeout,
+		stopCh:      make(chan struct{}),
+	}, nil
+}
+
+func (r *k8sResolver) start(ctx context.Context) error {
+	factory := informers.NewSharedInformerFactoryWithOptions(r.client, 0,
+		informers.WithNamespace(r.svcNs),
+		informers.WithTweakListOptions(func(opts *metav1.ListOptions) {
+			opts.FieldSelector = fields.OneTermEqualSelector("metadata.name", r.svcName).String()
+		}),
+	)
+	endpointsInformer := factory.Core().V1().Endpoints()
+	r.lister = endpointsInformer.Lister()
+
+	informer := endpointsInformer.Informer()
+	informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    func(interface{}) { r.onEndpointsChange() },
+		UpdateFunc: func(interface{}, interface{}) { r.onEndpointsChange() },
+		DeleteFunc: func(interface{}) { r.onEndpointsChange() },
+	})
+
+	// the informer is run here instead of by the factory, so that the shutdown can wait for it:
+	// it only returns once its event handlers have stopped
+	r.informerWg.Add(1)
+	go func() {
+		defer r.informerWg.Done()
+		informer.Run(r.stopCh)
+	}()
+
+	syncCtx, cancel := context.WithTimeout(ctx, r.syncTimeout)
+	defer cancel()
+	if !cache.WaitForCacheSync(syncCtx.Done(), informer.HasSynced) {
+		return errK8sSyncTimeout
+	}
+
+	_, err := r.resolve(ctx)
+	return err
+}
+
+func (r *k8sResolver) shutdown(ctx context.Context) error {
+	r.changeCallbackLock.Lock()
+	r.onChangeCallbacks = nil
+	r.changeCallbackLock.Unlock()
+
+	close(r.stopCh)
+	r.informerWg.Wait()
+	return nil
+}
+
+func (r *k8sResolver) onEndpointsChange() {
+	if _, err := r.resolve(context.Background()); err != nil {
+		r.logger.Warn("failed to resolve", zap.Error(err))
+	}
+}
+
+func (r *k8sResolver) resolve(ctx context.Context) ([]string, error) {
+	// the context to use for all metrics in this function
+	mCtx, _ := tag.New(ctx, tag.Upsert(tag.MustNewKey("resolver"), "k8s"))
+
+	if r.lister == nil {
+		// not started yet, there's nothing to resolve from
+		return nil, nil
+	}
+
+	endpoints, err := r.lister.Endpoints(r.svcNs).Get(r.svcName)
+	if err != nil && !apierrors.IsNotFound(err) {
+		failedCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "false"))
+		stats.Record(failedCtx, mNumResolutions.M(1))
+		return nil, err
+	}
+
+	// from this point, we don't fail anymore
+	successCtx, _ := tag.New(mCtx, tag.Upsert(tag.MustNewKey("success"), "true"))
+	stats.Record(successCtx, mNumResolutions.M(1))
+
+	backends := []string{}
+	if endpoints != nil {
+		for _, subset := range endpoints.Subsets {
+			for _, addr := range subset.Addresses {
+				for _, port := range r.ports {
+					backends = append(backends, net.JoinHostPort(addr.IP, fmt.Sprintf("%d", port)))
+				}
+			}
+		}
+	}
+
+	// keep it always in the same order
+	sort.Strings(backends)
+
+	r.updateLock.Lock()
+	defer r.updateLock.Unlock()
+
+	if equalStringSlice(r.endpoints, backends) {
+		return r.endpoints, nil
+	}
+
+	// the list has changed!
+	r.endpoints = backends
+	stats.Record(mCtx, mNumBackends.M(int64(len(backends))))
+
+	// propagate the change
+	r.changeCallbackLock.RLock()
+	for _, callback := range r.onChangeCallbacks {
+		callback(r.endpoints)
+	}
+	r.changeCallbackLock.RUnlock()
+
+	return r.endpoints, nil
+}
+
+func (r *k8sResolver) onChange(f func([]string)) {
+	r.changeCallbackLock.Lock()
+	defer r.changeCallbackLock.Unlock()
+	r.onChangeCallbacks = append(r.onChangeCallbacks, f)
+}
@@ -0,0 +1,172 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testEndpoints(name, namespace string, ips ...string) *corev1.Endpoints {
+	var addresses []corev1.EndpointAddress
+	for _, ip := range ips {
+		addresses = append(addresses, corev1.EndpointAddress{IP: ip})
+	}
+	return &corev1.Endpoints{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+		Subsets:    []corev1.EndpointSubset{{Addresses: addresses}},
+	}
+}
+
+func TestNewK8sResolver(t *testing.T) {
+	for _, tt := range []struct {
+		service           string
+		ports             []int32
+		expectedName      string
+		expectedNamespace string
+		expectedPorts     []int32
+	}{
+		{"backends", nil, "backends", "default", []int32{4317}},
+		{"backends.observability", []int32{55690, 55700}, "backends", "observability", []int32{55690, 55700}},
+	} {
+		t.Run(tt.service, func(t *testing.T) {
+			res, err := newK8sResolver(zap.NewNop(), fake.NewSimpleClientset(), tt.service, tt.ports)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedName, res.svcName)
+			assert.Equal(t, tt.expectedNamespace, res.svcNs)
+			assert.Equal(t, tt.expectedPorts, res.ports)
+		})
+	}
+}
+
+func TestErrNoSvc(t *testing.T) {
+	// test
+	res, err := newK8sResolver(zap.NewNop(), fake.NewSimpleClientset(), "", nil)
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoSvc, err)
+}
+
+func TestInitialK8sResolution(t *testing.T) {
+	// prepare
+	client := fake.NewSimpleClientset(
+		testEndpoints("backends", "observability", "10.0.0.2", "10.0.0.1", "fd00::1"),
+		testEndpoints("others", "observability", "10.0.0.3"),
+	)
+	res, err := newK8sResolver(zap.NewNop(), client, "backends.observability", []int32{4317, 55690})
+	require.NoError(t, err)
+
+	// test
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// verify
+	assert.Equal(t, []string{
+		"10.0.0.1:4317", "10.0.0.1:55690",
+		"10.0.0.2:4317", "10.0.0.2:55690",
+		"[fd00::1]:4317", "[fd00::1]:55690",
+	}, resolved)
+}
+
+func TestK8sResolverWatchesEndpoints(t *testing.T) {
+	// prepare
+	client := fake.NewSimpleClientset(testEndpoints("backends", "default", "10.0.0.1"))
+	res, err := newK8sResolver(zap.NewNop(), client, "backends", nil)
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		mu.Lock()
+		defer mu.Unlock()
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// test
+	_, err = client.CoreV1().Endpoints("default").Update(context.Background(), testEndpoints("backends", "default", "10.0.0.1", "10.0.0.2"), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return assert.ObjectsAreEqual([]string{"10.0.0.1:4317", "10.0.0.2:4317"}, resolved)
+	}, time.Second, 10*time.Millisecond)
+
+	// test
+	require.NoError(t, client.CoreV1().Endpoints("default").Delete(context.Background(), "backends", metav1.DeleteOptions{}))
+
+	// verify
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(resolved) == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestK8sResolverStopsWatchingOnShutdown(t *testing.T) {
+	// prepare
+	client := fake.NewSimpleClientset(testEndpoints("backends", "default", "10.0.0.1"))
+	res, err := newK8sResolver(zap.NewNop(), client, "backends", nil)
+	require.NoError(t, err)
+	require.NoError(t, res.start(context.Background()))
+
+	// test
+	require.NoError(t, res.shutdown(context.Background()))
+	_, err = client.CoreV1().Endpoints("default").Update(context.Background(), testEndpoints("backends", "default", "10.0.0.1", "10.0.0.2"), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// verify
+	assert.Never(t, func() bool {
+		res.updateLock.Lock()
+		defer res.updateLock.Unlock()
+		return len(res.endpoints) != 1
+	}, 100*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestK8sResolverMissingService(t *testing.T) {
+	// prepare
+	res, err := newK8sResolver(zap.NewNop(), fake.NewSimpleClientset(), "backends", nil)
+	require.NoError(t, err)
+
+	counter := 0
+	res.onChange(func([]string) {
+		counter++
+	})
+
+	// test
+	err = res.start(context.Background())
+	defer res.shutdown(context.Background())
+
+	// verify
+	assert.NoError(t, err)
+	assert.Equal(t, 0, counter)
+}
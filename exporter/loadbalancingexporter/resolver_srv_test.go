@@ -0,0 +1,138 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestInitialSRVResolution(t *testing.T) {
+	// prepare
+	res, err := newSRVResolver(zap.NewNop(), "_otlp._tcp.service-1")
+	require.NoError(t, err)
+
+	res.resolver = &mockSRVResolver{
+		onLookupSRV: func(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+			assert.Equal(t, "", service)
+			assert.Equal(t, "", proto)
+			assert.Equal(t, "_otlp._tcp.service-1", name)
+			return "", []*net.SRV{
+				{Target: "backend-2.example.com.", Port: 4317},
+				{Target: "backend-1.example.com.", Port: 55690},
+				{Target: "::1", Port: 4317},
+			}, nil
+		},
+	}
+
+	// test
+	var resolved []string
+	res.onChange(func(endpoints []string) {
+		resolved = endpoints
+	})
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	// verify
+	assert.Equal(t, []string{"[::1]:4317", "backend-1.example.com:55690", "backend-2.example.com:4317"}, resolved)
+}
+
+func TestErrNoSRVHostname(t *testing.T) {
+	// test
+	res, err := newSRVResolver(zap.NewNop(), "")
+
+	// verify
+	assert.Nil(t, res)
+	assert.Equal(t, errNoHostname, err)
+}
+
+func TestCantResolveSRV(t *testing.T) {
+	// prepare
+	res, err := newSRVResolver(zap.NewNop(), "_otlp._tcp.service-1")
+	require.NoError(t, err)
+
+	expectedErr := errors.New("some expected error")
+	res.resolver = &mockSRVResolver{
+		onLookupSRV: func(context.Context, string, string, string) (string, []*net.SRV, error) {
+			return "", nil, expectedErr
+		},
+	}
+
+	// test
+	err = res.start(context.Background())
+
+	// verify
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestSRVPeriodicallyResolve(t *testing.T) {
+	// prepare
+	res, err := newSRVResolver(zap.NewNop(), "_otlp._tcp.service-1")
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	records := []*net.SRV{{Target: "backend-1", Port: 4317}}
+	res.resolver = &mockSRVResolver{
+		onLookupSRV: func(context.Context, string, string, string) (string, []*net.SRV, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			return "", records, nil
+		},
+	}
+	res.resInterval = 10 * time.Millisecond
+
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	res.onChange(func(backends []string) {
+		wg.Done()
+	})
+
+	// test
+	require.NoError(t, res.start(context.Background()))
+	defer res.shutdown(context.Background())
+
+	mu.Lock()
+	records = []*net.SRV{{Target: "backend-1", Port: 4317}, {Target: "backend-2", Port: 4317}}
+	mu.Unlock()
+
+	// wait for the initial resolution and the one picking up the new record
+	wg.Wait()
+
+	// verify
+	res.updateLock.Lock()
+	defer res.updateLock.Unlock()
+	assert.Equal(t, []string{"backend-1:4317", "backend-2:4317"}, res.endpoints)
+}
+
+var _ srvNetResolver = (*mockSRVResolver)(nil)
+
+type mockSRVResolver struct {
+	onLookupSRV func(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+}
+
+func (m *mockSRVResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	if m.onLookupSRV != nil {
+		return m.onLookupSRV(ctx, service, proto, name)
+	}
+	return "", nil, nil
+}
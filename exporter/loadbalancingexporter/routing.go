@@ -0,0 +1,78 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/loadbalancingexporter"
+
+import (
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+)
+
+const (
+	traceIDRoutingKey = "traceID"
+	svcRoutingKey     = "service"
+)
+
+// routingAttribute returns the resource attribute to route the data by,
+// or an empty string when the data should be routed by its trace ID
+func routingAttribute(routingKey string) string {
+	switch routingKey {
+	case "", traceIDRoutingKey:
+		return ""
+	case svcRoutingKey:
+		return conventions.AttributeServiceName
+	default:
+		return routingKey
+	}
+}
+
+// resourceAttributeKey returns the value of the given attribute of the resource, if present
+func resourceAttributeKey(resource pdata.Resource, attribute string) ([]byte, bool) {
+	if attribute == "" {
+		return nil, false
+	}
+
+	value, ok := resource.Attributes().Get(attribute)
+	if !ok {
+		return nil, false
+	}
+
+	return []byte(value.AsString()), true
+}
+
+// resourceIdentity builds a key out of all the attributes of the resource, sorted by their names,
+// so that the same resource always yields the same key
+func resourceIdentity(resource pdata.Resource) []byte {
+	attrs := resource.Attributes()
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pdata.AttributeValue) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(v.AsString())
+		sb.WriteByte(';')
+	}
+
+	return []byte(sb.String())
+}
@@ -0,0 +1,53 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadbalancingexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestRoutingAttribute(t *testing.T) {
+	for _, tt := range []struct {
+		routingKey string
+		expected   string
+	}{
+		{"", ""},
+		{"traceID", ""},
+		{"service", "service.name"},
+		{"k8s.pod.name", "k8s.pod.name"},
+	} {
+		t.Run(tt.routingKey, func(t *testing.T) {
+			assert.Equal(t, tt.expected, routingAttribute(tt.routingKey))
+		})
+	}
+}
+
+func TestResourceIdentityIsStable(t *testing.T) {
+	// prepare
+	first := pdata.NewResource()
+	first.Attributes().InsertString("service.name", "svc-a")
+	first.Attributes().InsertInt("process.pid", 42)
+
+	second := pdata.NewResource()
+	second.Attributes().InsertInt("process.pid", 42)
+	second.Attributes().InsertString("service.name", "svc-a")
+
+	// test and verify
+	assert.Equal(t, []byte("process.pid=42;service.name=svc-a;"), resourceIdentity(first))
+	assert.Equal(t, resourceIdentity(first), resourceIdentity(second))
+}
@@ -42,6 +42,9 @@ var (
 type traceExporterImp struct {
 	loadBalancer loadBalancer
 
+	// the resource attribute to route the spans by, empty when routing by trace ID
+	routingAttribute string
+
 	stopped    bool
 	shutdownWg sync.WaitGroup
 }
@@ -59,7 +62,8 @@ func newTracesExporter(params component.ExporterCreateSettings, cfg config.Expor
 	}
 
 	return &traceExporterImp{
-		loadBalancer: lb,
+		loadBalancer:     lb,
+		routingAttribute: routingAttribute(cfg.(*Config).RoutingKey),
 	}, nil
 }
 
@@ -86,21 +90,31 @@ func (e *traceExporterImp) Shutdown(context.Context) error {
 
 func (e *traceExporterImp) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
 	var errs error
-	batches := batchpersignal.SplitTraces(td)
+	routed, remaining := splitTracesByAttribute(td, e.routingAttribute)
+	for _, batch := range routed {
+		errs = multierr.Append(errs, e.consumeTrace(ctx, batch.key, batch.traces))
+	}
+
+	batches := batchpersignal.SplitTraces(remaining)
 	for _, batch := range batches {
-		errs = multierr.Append(errs, e.consumeTrace(ctx, batch))
+		errs = multierr.Append(errs, e.consumeTraceByID(ctx, batch))
 	}
 
 	return errs
 }
 
-func (e *traceExporterImp) consumeTrace(ctx context.Context, td pdata.Traces) error {
+func (e *traceExporterImp) consumeTraceByID(ctx context.Context, td pdata.Traces) error {
 	traceID := traceIDFromTraces(td)
 	if traceID == pdata.InvalidTraceID() {
 		return errNoTracesInBatch
 	}
 
-	endpoint := e.loadBalancer.Endpoint(traceID)
+	key := traceID.Bytes()
+	return e.consumeTrace(ctx, key[:], td)
+}
+
+func (e *traceExporterImp) consumeTrace(ctx context.Context, key []byte, td pdata.Traces) error {
+	endpoint := e.loadBalancer.Endpoint(key)
 	exp, err := e.loadBalancer.Exporter(endpoint)
 	if err != nil {
 		return err
@@ -146,3 +160,41 @@ func traceIDFromTraces(td pdata.Traces) pdata.TraceID {
 
 	return spans.At(0).TraceID()
 }
+
+// routedTraces holds the spans sharing the same routing key
+type routedTraces struct {
+	key    []byte
+	traces pdata.Traces
+}
+
+// splitTracesByAttribute groups the resource spans by the value of the given resource attribute.
+// The resource spans lacking the attribute are returned separately, to be routed by their trace IDs.
+func splitTracesByAttribute(td pdata.Traces, attribute string) ([]routedTraces, pdata.Traces) {
+	if attribute == "" {
+		return nil, td
+	}
+
+	var routed []routedTraces
+	positions := map[string]int{}
+	remaining := pdata.NewTraces()
+
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		key, ok := resourceAttributeKey(rs.Resource(), attribute)
+		if !ok {
+			rs.CopyTo(remaining.ResourceSpans().AppendEmpty())
+			continue
+		}
+
+		pos, found := positions[string(key)]
+		if !found {
+			pos = len(routed)
+			positions[string(key)] = pos
+			routed = append(routed, routedTraces{key: key, traces: pdata.NewTraces()})
+		}
+		rs.CopyTo(routed[pos].traces.ResourceSpans().AppendEmpty())
+	}
+
+	return routed, remaining
+}
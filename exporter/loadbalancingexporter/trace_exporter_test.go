@@ -284,6 +284,75 @@ func TestBatchWithTwoTraces(t *testing.T) {
 	assert.Len(t, sink.AllTraces(), 2)
 }
 
+func TestBatchRoutedByService(t *testing.T) {
+	componentFactory := func(ctx context.Context, endpoint string) (component.Exporter, error) {
+		return newNopMockTracesExporter(), nil
+	}
+	cfg := simpleConfig()
+	cfg.RoutingKey = "service"
+	lb, err := newLoadBalancer(componenttest.NewNopExporterCreateSettings(), cfg, componentFactory)
+	require.NotNil(t, lb)
+	require.NoError(t, err)
+
+	p, err := newTracesExporter(componenttest.NewNopExporterCreateSettings(), cfg)
+	require.NotNil(t, p)
+	require.NoError(t, err)
+
+	p.loadBalancer = lb
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	sink := new(consumertest.TracesSink)
+	lb.exporters["endpoint-1"] = newMockTracesExporter(sink.ConsumeTraces)
+
+	// two traces from the same service, and one without a service name
+	first := simpleTraces()
+	first.ResourceSpans().At(0).Resource().Attributes().InsertString("service.name", "svc-a")
+	second := simpleTraceWithID(pdata.NewTraceID([16]byte{2, 3, 4, 5}))
+	second.ResourceSpans().At(0).Resource().Attributes().InsertString("service.name", "svc-a")
+	third := simpleTraceWithID(pdata.NewTraceID([16]byte{3, 4, 5, 6}))
+	batch := pdata.NewTraces()
+	first.ResourceSpans().MoveAndAppendTo(batch.ResourceSpans())
+	second.ResourceSpans().MoveAndAppendTo(batch.ResourceSpans())
+	third.ResourceSpans().MoveAndAppendTo(batch.ResourceSpans())
+
+	// test
+	err = p.ConsumeTraces(context.Background(), batch)
+
+	// verify
+	assert.NoError(t, err)
+	require.Len(t, sink.AllTraces(), 2)
+	assert.Equal(t, 2, sink.AllTraces()[0].ResourceSpans().Len())
+	assert.Equal(t, 1, sink.AllTraces()[1].ResourceSpans().Len())
+}
+
+func TestSplitTracesByAttribute(t *testing.T) {
+	// prepare
+	batch := pdata.NewTraces()
+	for _, svc := range []string{"svc-a", "svc-b", "svc-a"} {
+		rs := batch.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString("service.name", svc)
+		rs.InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty().SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4}))
+	}
+	batch.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+
+	// test
+	routed, remaining := splitTracesByAttribute(batch, "service.name")
+
+	// verify
+	require.Len(t, routed, 2)
+	assert.Equal(t, []byte("svc-a"), routed[0].key)
+	assert.Equal(t, 2, routed[0].traces.ResourceSpans().Len())
+	assert.Equal(t, []byte("svc-b"), routed[1].key)
+	assert.Equal(t, 1, routed[1].traces.ResourceSpans().Len())
+	assert.Equal(t, 1, remaining.ResourceSpans().Len())
+
+	// routing by trace ID leaves the batch untouched
+	routed, remaining = splitTracesByAttribute(batch, "")
+	assert.Empty(t, routed)
+	assert.Equal(t, batch, remaining)
+}
+
 func TestNoTracesInBatch(t *testing.T) {
 	for _, tt := range []struct {
 		desc  string
@@ -16,8 +16,11 @@ package attraction // import "github.com/open-telemetry/opentelemetry-collector-
 
 import (
 	"fmt"
+	"math"
 	"regexp"
+	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	"go.opentelemetry.io/collector/model/pdata"
 
@@ -27,7 +30,7 @@ import (
 // Settings specifies the processor settings.
 type Settings struct {
 	// Actions specifies the list of attributes to act on.
-	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT}.
+	// The set of actions are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT, CONVERT, TRUNCATE, CONCAT}.
 	// This is a required field.
 	Actions []ActionKeyValue `mapstructure:"actions"`
 }
@@ -56,8 +59,24 @@ type ActionKeyValue struct {
 	// the value. If the attribute doesn't exist, no action is performed.
 	FromAttribute string `mapstructure:"from_attribute"`
 
+	// ConvertedType specifies the type the value of the attribute is converted to
+	// by the action CONVERT. The set of values are {int, double, string, bool}.
+	ConvertedType string `mapstructure:"converted_type"`
+
+	// MaxLength specifies the maximum length in bytes of the string value of the
+	// attribute for the action TRUNCATE. It must be greater than zero.
+	MaxLength int `mapstructure:"max_length"`
+
+	// FromAttributes specifies the attributes whose values are joined by the
+	// action CONCAT, in the given order. All the attributes must exist for the
+	// value to be set.
+	FromAttributes []string `mapstructure:"from_attributes"`
+
+	// Separator is the string placed between the values joined by the action CONCAT.
+	Separator string `mapstructure:"separator"`
+
 	// Action specifies the type of action to perform.
-	// The set of values are {INSERT, UPDATE, UPSERT, DELETE, HASH}.
+	// The set of values are {INSERT, UPDATE, UPSERT, DELETE, HASH, EXTRACT, CONVERT, TRUNCATE, CONCAT}.
 	// Both lower case and upper case are supported.
 	// INSERT -  Inserts the key/value to attributes when the key does not exist.
 	//           No action is applied to attributes where the key already exists.
@@ -77,6 +96,13 @@ type ActionKeyValue struct {
 	// EXTRACT - Extracts values using a regular expression rule from the input
 	//           'key' to target keys specified in the 'rule'. If a target key
 	//           already exists, it will be overridden.
+	// CONVERT - Converts the value of an existing attribute to the type
+	//           specified by 'converted_type'. The attribute is left as is
+	//           if its value can't be converted.
+	// TRUNCATE - Truncates the string value of an existing attribute to
+	//           'max_length' bytes.
+	// CONCAT  - Joins the values of the 'from_attributes' with the 'separator'
+	//           and upserts the result as a string to the key.
 	// This is a required field.
 	Action Action `mapstructure:"action"`
 }
@@ -110,6 +136,25 @@ const (
 	// 'key' to target keys specified in the 'rule'. If a target key already
 	// exists, it will be overridden.
 	EXTRACT Action = "extract"
+
+	// CONVERT converts the value of an existing attribute to the type specified
+	// by 'converted_type'. The attribute is left as is if its value can't be converted.
+	CONVERT Action = "convert"
+
+	// TRUNCATE truncates the string value of an existing attribute to 'max_length' bytes.
+	TRUNCATE Action = "truncate"
+
+	// CONCAT joins the values of the 'from_attributes' with the 'separator' and
+	// upserts the result as a string to the key.
+	CONCAT Action = "concat"
+)
+
+// The types values can be converted to by the action CONVERT.
+const (
+	convertToInt    = "int"
+	convertToDouble = "double"
+	convertToString = "string"
+	convertToBool   = "bool"
 )
 
 type attributeAction struct {
@@ -127,6 +172,10 @@ type attributeAction struct {
 	// and could impact performance.
 	Action         Action
 	AttributeValue *pdata.AttributeValue
+	ConvertedType  string
+	MaxLength      int
+	FromAttributes []string
+	Separator      string
 }
 
 // AttrProc is an attribute processor.
@@ -152,6 +201,16 @@ func NewAttrProc(settings *Settings) (*AttrProc, error) {
 			Action: a.Action,
 		}
 
+		if a.Action != CONVERT && a.ConvertedType != "" {
+			return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use the \"converted_type\" field. This must not be specified for %d-th action", a.Action, i)
+		}
+		if a.Action != TRUNCATE && a.MaxLength != 0 {
+			return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use the \"max_length\" field. This must not be specified for %d-th action", a.Action, i)
+		}
+		if a.Action != CONCAT && (len(a.FromAttributes) != 0 || a.Separator != "") {
+			return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use \"from_attributes\" or \"separator\" field. These must not be specified for %d-th action", a.Action, i)
+		}
+
 		switch a.Action {
 		case INSERT, UPDATE, UPSERT:
 			if a.Value == nil && a.FromAttribute == "" {
@@ -203,6 +262,32 @@ func NewAttrProc(settings *Settings) (*AttrProc, error) {
 			}
 			action.Regex = re
 			action.AttrNames = attrNames
+		case CONVERT, TRUNCATE, CONCAT:
+			if a.Value != nil || a.FromAttribute != "" || a.RegexPattern != "" {
+				return nil, fmt.Errorf("error creating AttrProc. Action \"%s\" does not use \"value\", \"pattern\" or \"from_attribute\" field. These must not be specified for %d-th action", a.Action, i)
+			}
+			switch a.Action {
+			case CONVERT:
+				switch a.ConvertedType {
+				case convertToInt, convertToDouble, convertToString, convertToBool:
+				case "":
+					return nil, fmt.Errorf("error creating AttrProc due to missing required field \"converted_type\" for action \"%s\" at the %d-th action", a.Action, i)
+				default:
+					return nil, fmt.Errorf("error creating AttrProc due to unsupported \"converted_type\" %q at the %d-th action", a.ConvertedType, i)
+				}
+				action.ConvertedType = a.ConvertedType
+			case TRUNCATE:
+				if a.MaxLength <= 0 {
+					return nil, fmt.Errorf("error creating AttrProc. Field \"max_length\" must be greater than zero for action \"%s\" at the %d-th action", a.Action, i)
+				}
+				action.MaxLength = a.MaxLength
+			case CONCAT:
+				if len(a.FromAttributes) == 0 {
+					return nil, fmt.Errorf("error creating AttrProc due to missing required field \"from_attributes\" for action \"%s\" at the %d-th action", a.Action, i)
+				}
+				action.FromAttributes = a.FromAttributes
+				action.Separator = a.Separator
+			}
 		default:
 			return nil, fmt.Errorf("error creating AttrProc due to unsupported action %q at the %d-th actions", a.Action, i)
 		}
@@ -244,6 +329,12 @@ func (ap *AttrProc) Process(attrs pdata.AttributeMap) {
 			hashAttribute(action, attrs)
 		case EXTRACT:
 			extractAttributes(action, attrs)
+		case CONVERT:
+			convertAttribute(action, attrs)
+		case TRUNCATE:
+			truncateAttribute(action, attrs)
+		case CONCAT:
+			concatAttributes(action, attrs)
 		}
 	}
 }
@@ -284,3 +375,112 @@ func extractAttributes(action attributeAction, attrs pdata.AttributeMap) {
 		attrs.UpsertString(action.AttrNames[i], matches[i])
 	}
 }
+
+func convertAttribute(action attributeAction, attrs pdata.AttributeMap) {
+	value, found := attrs.Get(action.Key)
+	if !found {
+		return
+	}
+
+	if action.ConvertedType == convertToString {
+		if value.Type() != pdata.AttributeValueTypeString {
+			attrs.UpdateString(action.Key, value.AsString())
+		}
+		return
+	}
+
+	converted, ok := convertValue(value, action.ConvertedType)
+	if ok {
+		attrs.Update(action.Key, converted)
+	}
+}
+
+// convertValue converts a string, int, double or bool value to an int, double or
+// bool value. False is returned if the value can't be converted.
+func convertValue(value pdata.AttributeValue, to string) (pdata.AttributeValue, bool) {
+	switch value.Type() {
+	case pdata.AttributeValueTypeString:
+		s := strings.TrimSpace(value.StringVal())
+		switch to {
+		case convertToInt:
+			if i, err := strconv.ParseInt(s, 10, 64); err == nil {
+				return pdata.NewAttributeValueInt(i), true
+			}
+		case convertToDouble:
+			if f, err := strconv.ParseFloat(s, 64); err == nil {
+				return pdata.NewAttributeValueDouble(f), true
+			}
+		case convertToBool:
+			if b, err := strconv.ParseBool(s); err == nil {
+				return pdata.NewAttributeValueBool(b), true
+			}
+		}
+	case pdata.AttributeValueTypeInt:
+		switch to {
+		case convertToInt:
+			return value, false
+		case convertToDouble:
+			return pdata.NewAttributeValueDouble(float64(value.IntVal())), true
+		case convertToBool:
+			return pdata.NewAttributeValueBool(value.IntVal() != 0), true
+		}
+	case pdata.AttributeValueTypeDouble:
+		switch to {
+		case convertToInt:
+			// NaN, infinite and out of range values have no int representation
+			f := value.DoubleVal()
+			if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return value, false
+			}
+			return pdata.NewAttributeValueInt(int64(f)), true
+		case convertToBool:
+			return pdata.NewAttributeValueBool(value.DoubleVal() != 0), true
+		}
+	case pdata.AttributeValueTypeBool:
+		var i int64
+		if value.BoolVal() {
+			i = 1
+		}
+		switch to {
+		case convertToInt:
+			return pdata.NewAttributeValueInt(i), true
+		case convertToDouble:
+			return pdata.NewAttributeValueDouble(float64(i)), true
+		}
+	}
+	return value, false
+}
+
+func truncateAttribute(action attributeAction, attrs pdata.AttributeMap) {
+	value, found := attrs.Get(action.Key)
+
+	// Truncating values only functions on strings.
+	if !found || value.Type() != pdata.AttributeValueTypeString {
+		return
+	}
+
+	s := value.StringVal()
+	if len(s) <= action.MaxLength {
+		return
+	}
+
+	// Don't split a multi-byte character, the value would no longer be valid UTF-8.
+	end := action.MaxLength
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	value.SetStringVal(s[:end])
+}
+
+func concatAttributes(action attributeAction, attrs pdata.AttributeMap) {
+	values := make([]string, 0, len(action.FromAttributes))
+	for _, key := range action.FromAttributes {
+		value, found := attrs.Get(key)
+		// If one of the attributes isn't found, the value is not set.
+		if !found {
+			return
+		}
+		values = append(values, value.AsString())
+	}
+	attrs.UpsertString(action.Key, strings.Join(values, action.Separator))
+}
@@ -628,6 +628,195 @@ func TestAttributes_HashValue(t *testing.T) {
 	}
 }
 
+func TestAttributes_Convert(t *testing.T) {
+	testCases := []struct {
+		name          string
+		convertedType string
+		input         pdata.AttributeValue
+		expected      pdata.AttributeValue
+	}{
+		{"string to int", "int", pdata.NewAttributeValueString(" 404"), pdata.NewAttributeValueInt(404)},
+		{"invalid string to int", "int", pdata.NewAttributeValueString("4o4"), pdata.NewAttributeValueString("4o4")},
+		{"string to double", "double", pdata.NewAttributeValueString("0.25"), pdata.NewAttributeValueDouble(0.25)},
+		{"string to bool", "bool", pdata.NewAttributeValueString("true"), pdata.NewAttributeValueBool(true)},
+		{"invalid string to bool", "bool", pdata.NewAttributeValueString("yes"), pdata.NewAttributeValueString("yes")},
+		{"int to string", "string", pdata.NewAttributeValueInt(404), pdata.NewAttributeValueString("404")},
+		{"int to double", "double", pdata.NewAttributeValueInt(2), pdata.NewAttributeValueDouble(2)},
+		{"int to bool", "bool", pdata.NewAttributeValueInt(0), pdata.NewAttributeValueBool(false)},
+		{"double to string", "string", pdata.NewAttributeValueDouble(0.25), pdata.NewAttributeValueString("0.25")},
+		{"double to int", "int", pdata.NewAttributeValueDouble(2.75), pdata.NewAttributeValueInt(2)},
+		{"infinity to int", "int", pdata.NewAttributeValueDouble(math.Inf(1)), pdata.NewAttributeValueDouble(math.Inf(1))},
+		{"negative infinity to int", "int", pdata.NewAttributeValueDouble(math.Inf(-1)), pdata.NewAttributeValueDouble(math.Inf(-1))},
+		{"too large double to int", "int", pdata.NewAttributeValueDouble(1e19), pdata.NewAttributeValueDouble(1e19)},
+		{"too small double to int", "int", pdata.NewAttributeValueDouble(-1e19), pdata.NewAttributeValueDouble(-1e19)},
+		{"min int double to int", "int", pdata.NewAttributeValueDouble(math.MinInt64), pdata.NewAttributeValueInt(math.MinInt64)},
+		{"bool to string", "string", pdata.NewAttributeValueBool(true), pdata.NewAttributeValueString("true")},
+		{"bool to int", "int", pdata.NewAttributeValueBool(true), pdata.NewAttributeValueInt(1)},
+		{"bool to double", "double", pdata.NewAttributeValueBool(false), pdata.NewAttributeValueDouble(0)},
+		{"int to int", "int", pdata.NewAttributeValueInt(1), pdata.NewAttributeValueInt(1)},
+	}
+
+	for _, tc := range testCases {
+		ap, err := NewAttrProc(&Settings{
+			Actions: []ActionKeyValue{
+				{Key: "code", ConvertedType: tc.convertedType, Action: CONVERT},
+			},
+		})
+		require.NoError(t, err)
+		require.NotNil(t, ap)
+
+		runIndividualTestCase(t, testCase{
+			name: tc.name,
+			inputAttributes: map[string]pdata.AttributeValue{
+				"code": tc.input,
+				"boo":  pdata.NewAttributeValueString("ghosts are scary"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"code": tc.expected,
+				"boo":  pdata.NewAttributeValueString("ghosts are scary"),
+			},
+		}, ap)
+	}
+
+	ap, err := NewAttrProc(&Settings{
+		Actions: []ActionKeyValue{
+			{Key: "code", ConvertedType: "int", Action: CONVERT},
+		},
+	})
+	require.NoError(t, err)
+	runIndividualTestCase(t, testCase{
+		name:               "missing attribute",
+		inputAttributes:    map[string]pdata.AttributeValue{},
+		expectedAttributes: map[string]pdata.AttributeValue{},
+	}, ap)
+
+	// NaN isn't equal to itself, so the kept value is checked directly
+	attrMap := pdata.NewAttributeMap()
+	attrMap.InsertDouble("code", math.NaN())
+	ap.Process(attrMap)
+	v, ok := attrMap.Get("code")
+	require.True(t, ok)
+	assert.Equal(t, pdata.AttributeValueTypeDouble, v.Type())
+	assert.True(t, math.IsNaN(v.DoubleVal()))
+}
+
+func TestAttributes_Truncate(t *testing.T) {
+	testCases := []testCase{
+		{
+			name:               "missing attribute",
+			inputAttributes:    map[string]pdata.AttributeValue{},
+			expectedAttributes: map[string]pdata.AttributeValue{},
+		},
+		{
+			name: "short value",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"db.statement": pdata.NewAttributeValueString("SELECT"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"db.statement": pdata.NewAttributeValueString("SELECT"),
+			},
+		},
+		{
+			name: "long value",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"db.statement": pdata.NewAttributeValueString("SELECT * FROM users"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"db.statement": pdata.NewAttributeValueString("SELECT *"),
+			},
+		},
+		{
+			name: "multi-byte characters",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"db.statement": pdata.NewAttributeValueString("SELECT \u00e9t\u00e9"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"db.statement": pdata.NewAttributeValueString("SELECT "),
+			},
+		},
+		{
+			name: "non string value",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"db.statement": pdata.NewAttributeValueInt(123456789),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"db.statement": pdata.NewAttributeValueInt(123456789),
+			},
+		},
+	}
+
+	cfg := &Settings{
+		Actions: []ActionKeyValue{
+			{Key: "db.statement", MaxLength: 8, Action: TRUNCATE},
+		},
+	}
+
+	ap, err := NewAttrProc(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ap)
+
+	for _, tt := range testCases {
+		runIndividualTestCase(t, tt, ap)
+	}
+}
+
+func TestAttributes_Concat(t *testing.T) {
+	testCases := []testCase{
+		{
+			name: "missing source attribute",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"http.method": pdata.NewAttributeValueString("GET"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"http.method": pdata.NewAttributeValueString("GET"),
+			},
+		},
+		{
+			name: "all source attributes",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"http.method":      pdata.NewAttributeValueString("GET"),
+				"http.route":       pdata.NewAttributeValueString("/users"),
+				"http.status_code": pdata.NewAttributeValueInt(200),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"http.method":      pdata.NewAttributeValueString("GET"),
+				"http.route":       pdata.NewAttributeValueString("/users"),
+				"http.status_code": pdata.NewAttributeValueInt(200),
+				"operation":        pdata.NewAttributeValueString("GET /users 200"),
+			},
+		},
+		{
+			name: "existing target attribute",
+			inputAttributes: map[string]pdata.AttributeValue{
+				"http.method":      pdata.NewAttributeValueString("POST"),
+				"http.route":       pdata.NewAttributeValueString("/users"),
+				"http.status_code": pdata.NewAttributeValueInt(201),
+				"operation":        pdata.NewAttributeValueString("unknown"),
+			},
+			expectedAttributes: map[string]pdata.AttributeValue{
+				"http.method":      pdata.NewAttributeValueString("POST"),
+				"http.route":       pdata.NewAttributeValueString("/users"),
+				"http.status_code": pdata.NewAttributeValueInt(201),
+				"operation":        pdata.NewAttributeValueString("POST /users 201"),
+			},
+		},
+	}
+
+	cfg := &Settings{
+		Actions: []ActionKeyValue{
+			{Key: "operation", FromAttributes: []string{"http.method", "http.route", "http.status_code"}, Separator: " ", Action: CONCAT},
+		},
+	}
+
+	ap, err := NewAttrProc(cfg)
+	require.NoError(t, err)
+	require.NotNil(t, ap)
+
+	for _, tt := range testCases {
+		runIndividualTestCase(t, tt, ap)
+	}
+}
+
 func TestAttributes_FromAttributeNoChange(t *testing.T) {
 	tc := testCase{
 		name: "FromAttributeNoChange",
@@ -834,6 +1023,69 @@ func TestInvalidConfig(t *testing.T) {
 			},
 			errorString: "error creating AttrProc. Field \"pattern\" contains at least one unnamed matcher group at the 0-th actions",
 		},
+		{
+			name: "missing converted type",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Action: CONVERT},
+			},
+			errorString: "error creating AttrProc due to missing required field \"converted_type\" for action \"convert\" at the 0-th action",
+		},
+		{
+			name: "unsupported converted type",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", ConvertedType: "map", Action: CONVERT},
+			},
+			errorString: "error creating AttrProc due to unsupported \"converted_type\" \"map\" at the 0-th action",
+		},
+		{
+			name: "converted type for upsert",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Value: "1", ConvertedType: "int", Action: UPSERT},
+			},
+			errorString: "error creating AttrProc. Action \"upsert\" does not use the \"converted_type\" field. This must not be specified for 0-th action",
+		},
+		{
+			name: "set value for convert",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Value: "1", ConvertedType: "int", Action: CONVERT},
+			},
+			errorString: "error creating AttrProc. Action \"convert\" does not use \"value\", \"pattern\" or \"from_attribute\" field. These must not be specified for 0-th action",
+		},
+		{
+			name: "missing max length",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Action: TRUNCATE},
+			},
+			errorString: "error creating AttrProc. Field \"max_length\" must be greater than zero for action \"truncate\" at the 0-th action",
+		},
+		{
+			name: "max length for hash",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", MaxLength: 10, Action: HASH},
+			},
+			errorString: "error creating AttrProc. Action \"hash\" does not use the \"max_length\" field. This must not be specified for 0-th action",
+		},
+		{
+			name: "missing from attributes",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", Separator: "/", Action: CONCAT},
+			},
+			errorString: "error creating AttrProc due to missing required field \"from_attributes\" for action \"concat\" at the 0-th action",
+		},
+		{
+			name: "from attributes for insert",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", FromAttributes: []string{"a", "b"}, FromAttribute: "a", Action: INSERT},
+			},
+			errorString: "error creating AttrProc. Action \"insert\" does not use \"from_attributes\" or \"separator\" field. These must not be specified for 0-th action",
+		},
+		{
+			name: "from attribute for concat",
+			actionLists: []ActionKeyValue{
+				{Key: "aa", FromAttributes: []string{"a", "b"}, FromAttribute: "a", Action: CONCAT},
+			},
+			errorString: "error creating AttrProc. Action \"concat\" does not use \"value\", \"pattern\" or \"from_attribute\" field. These must not be specified for 0-th action",
+		},
 	}
 
 	for _, tc := range testcase {
@@ -853,6 +1105,9 @@ func TestValidConfiguration(t *testing.T) {
 			{Key: "three", FromAttribute: "two", Action: "upDaTE"},
 			{Key: "five", FromAttribute: "two", Action: "upsert"},
 			{Key: "two", RegexPattern: "^\\/api\\/v1\\/document\\/(?P<documentId>.*)\\/update$", Action: "EXTRact"},
+			{Key: "six", ConvertedType: "int", Action: "Convert"},
+			{Key: "seven", MaxLength: 8, Action: "TRUNCATE"},
+			{Key: "eight", FromAttributes: []string{"six", "seven"}, Separator: "/", Action: "concat"},
 		},
 	}
 	ap, err := NewAttrProc(cfg)
@@ -868,6 +1123,9 @@ func TestValidConfiguration(t *testing.T) {
 		{Key: "three", FromAttribute: "two", Action: UPDATE},
 		{Key: "five", FromAttribute: "two", Action: UPSERT},
 		{Key: "two", Regex: compiledRegex, AttrNames: []string{"", "documentId"}, Action: EXTRACT},
+		{Key: "six", ConvertedType: "int", Action: CONVERT},
+		{Key: "seven", MaxLength: 8, Action: TRUNCATE},
+		{Key: "eight", FromAttributes: []string{"six", "seven"}, Separator: "/", Action: CONCAT},
 	}, ap.actions)
 
 }
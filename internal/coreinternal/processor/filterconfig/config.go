@@ -71,7 +71,7 @@ type MatchProperties struct {
 	// Config configures the matching patterns used when matching span properties.
 	filterset.Config `mapstructure:",squash"`
 
-	// Note: For spans, one of Services, SpanNames, Attributes, Resources or Libraries must be specified with a
+	// Note: For spans, one of Services, SpanNames, SpanKinds, Attributes, Resources or Libraries must be specified with a
 	// non-empty value for a valid configuration.
 
 	// For logs, one of LogNames, Attributes, Resources or Libraries must be specified with a
@@ -87,6 +87,12 @@ type MatchProperties struct {
 	// This is an optional field.
 	SpanNames []string `mapstructure:"span_names"`
 
+	// SpanKinds specify the list of items to match span kind against.
+	// A match occurs if the span kind matches at least one item in this list.
+	// Span kinds are matched against their OTLP names, e.g. "SPAN_KIND_SERVER".
+	// This is an optional field.
+	SpanKinds []string `mapstructure:"span_kinds"`
+
 	// LogNames is a list of strings that the LogRecord's name field must match
 	// against.
 	LogNames []string `mapstructure:"log_names"`
@@ -114,9 +120,9 @@ func (mp *MatchProperties) ValidateForSpans() error {
 		return errors.New("log_names should not be specified for trace spans")
 	}
 
-	if len(mp.Services) == 0 && len(mp.SpanNames) == 0 && len(mp.Attributes) == 0 &&
+	if len(mp.Services) == 0 && len(mp.SpanNames) == 0 && len(mp.SpanKinds) == 0 && len(mp.Attributes) == 0 &&
 		len(mp.Libraries) == 0 && len(mp.Resources) == 0 {
-		return errors.New(`at least one of "services", "span_names", "attributes", "libraries" or "resources" field must be specified`)
+		return errors.New(`at least one of "services", "span_names", "span_kinds", "attributes", "libraries" or "resources" field must be specified`)
 	}
 
 	return nil
@@ -124,8 +130,8 @@ func (mp *MatchProperties) ValidateForSpans() error {
 
 // ValidateForLogs validates properties for logs.
 func (mp *MatchProperties) ValidateForLogs() error {
-	if len(mp.SpanNames) > 0 || len(mp.Services) > 0 {
-		return errors.New("neither services nor span_names should be specified for log records")
+	if len(mp.SpanNames) > 0 || len(mp.Services) > 0 || len(mp.SpanKinds) > 0 {
+		return errors.New("neither services, span_names nor span_kinds should be specified for log records")
 	}
 
 	if len(mp.LogNames) == 0 && len(mp.Attributes) == 0 && len(mp.Libraries) == 0 && len(mp.Resources) == 0 {
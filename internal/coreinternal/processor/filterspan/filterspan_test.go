@@ -42,14 +42,14 @@ func TestSpan_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 		{
 			name:        "empty_property",
 			property:    filterconfig.MatchProperties{},
-			errorString: "at least one of \"services\", \"span_names\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
+			errorString: "at least one of \"services\", \"span_names\", \"span_kinds\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
 		},
 		{
 			name: "empty_service_span_names_and_attributes",
 			property: filterconfig.MatchProperties{
 				Services: []string{},
 			},
-			errorString: "at least one of \"services\", \"span_names\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
+			errorString: "at least one of \"services\", \"span_names\", \"span_kinds\", \"attributes\", \"libraries\" or \"resources\" field must be specified",
 		},
 		{
 			name: "log_properties",
@@ -89,6 +89,14 @@ func TestSpan_validateMatchesConfiguration_InvalidConfig(t *testing.T) {
 			},
 			errorString: "error creating span name filters: error parsing regexp: missing closing ]: `[`",
 		},
+		{
+			name: "invalid_regexp_pattern_span_kind",
+			property: filterconfig.MatchProperties{
+				Config:    *createConfig(filterset.Regexp),
+				SpanKinds: []string{"["},
+			},
+			errorString: "error creating span kind filters: error parsing regexp: missing closing ]: `[`",
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -143,10 +151,19 @@ func TestSpan_Matching_False(t *testing.T) {
 				Attributes: []filterconfig.Attribute{},
 			},
 		},
+		{
+			name: "span_kind_doesnt_match",
+			properties: &filterconfig.MatchProperties{
+				Config:     *createConfig(filterset.Strict),
+				SpanKinds:  []string{pdata.SpanKindClient.String()},
+				Attributes: []filterconfig.Attribute{},
+			},
+		},
 	}
 
 	span := pdata.NewSpan()
 	span.SetName("spanName")
+	span.SetKind(pdata.SpanKindServer)
 	library := pdata.NewInstrumentationLibrary()
 	resource := pdata.NewResource()
 
@@ -217,10 +234,27 @@ func TestSpan_Matching_True(t *testing.T) {
 				Attributes: []filterconfig.Attribute{},
 			},
 		},
+		{
+			name: "span_kind_match_strict",
+			properties: &filterconfig.MatchProperties{
+				Config:     *createConfig(filterset.Strict),
+				SpanKinds:  []string{pdata.SpanKindServer.String()},
+				Attributes: []filterconfig.Attribute{},
+			},
+		},
+		{
+			name: "span_kind_match_regexp",
+			properties: &filterconfig.MatchProperties{
+				Config:     *createConfig(filterset.Regexp),
+				SpanKinds:  []string{"SPAN_KIND_(SERVER|CONSUMER)"},
+				Attributes: []filterconfig.Attribute{},
+			},
+		},
 	}
 
 	span := pdata.NewSpan()
 	span.SetName("spanName")
+	span.SetKind(pdata.SpanKindServer)
 	span.Attributes().InsertString("keyString", "arithmetic")
 	span.Attributes().InsertInt("keyInt", 123)
 	span.Attributes().InsertDouble("keyDouble", 3245.6)
@@ -52,6 +52,12 @@ func (scs *SharedComponents) GetOrAdd(key interface{}, create func() component.C
 	return newComp
 }
 
+// Remove removes the instance of the given key, if any, without shutting it down.
+// It is used to drop an instance whose creation failed.
+func (scs *SharedComponents) Remove(key interface{}) {
+	delete(scs.comps, key)
+}
+
 // SharedComponent ensures that the wrapped component is started and stopped only once.
 // When stopped it is removed from the SharedComponents map.
 type SharedComponent struct {
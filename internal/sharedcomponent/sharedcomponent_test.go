@@ -49,6 +49,21 @@ func TestSharedComponents_GetOrAdd(t *testing.T) {
 	assert.NotSame(t, got, comps.GetOrAdd(id, createNop))
 }
 
+func TestSharedComponents_Remove(t *testing.T) {
+	nop := componenthelper.New()
+	createNop := func() component.Component { return nop }
+
+	comps := NewSharedComponents()
+	got := comps.GetOrAdd(id, createNop)
+	comps.Remove(id)
+	assert.Len(t, comps.comps, 0)
+	assert.NotSame(t, got, comps.GetOrAdd(id, createNop))
+
+	// Removing a missing key is a no-op.
+	comps.Remove("missing")
+	assert.Len(t, comps.comps, 1)
+}
+
 func TestSharedComponent(t *testing.T) {
 	wantErr := errors.New("my error")
 	calledStart := 0
@@ -0,0 +1,80 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
+)
+
+type metricAttributesProcessor struct {
+	attrProc *attraction.AttrProc
+}
+
+// newMetricAttributesProcessor returns a processor that modifies the attributes
+// of the data points of a metric. To construct the attributes processors, the
+// use of the factory methods are required in order to validate the inputs.
+func newMetricAttributesProcessor(attrProc *attraction.AttrProc) *metricAttributesProcessor {
+	return &metricAttributesProcessor{
+		attrProc: attrProc,
+	}
+}
+
+func (a *metricAttributesProcessor) processMetrics(_ context.Context, md pdata.Metrics) (pdata.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				a.processMetric(metrics.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (a *metricAttributesProcessor) processMetric(metric pdata.Metric) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.Process(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.Process(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.Process(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.Process(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			a.attrProc.Process(dps.At(i).Attributes())
+		}
+	}
+}
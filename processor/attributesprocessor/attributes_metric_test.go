@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package attributesprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
+)
+
+// generateMetricData returns a metric of each data type, each with a data point
+// holding the attributes.
+func generateMetricData(attrs map[string]pdata.AttributeValue) pdata.Metrics {
+	md := pdata.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	pdata.NewAttributeMapFromMap(attrs).CopyTo(gauge.Gauge().DataPoints().AppendEmpty().Attributes())
+
+	sum := metrics.AppendEmpty()
+	sum.SetDataType(pdata.MetricDataTypeSum)
+	pdata.NewAttributeMapFromMap(attrs).CopyTo(sum.Sum().DataPoints().AppendEmpty().Attributes())
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	pdata.NewAttributeMapFromMap(attrs).CopyTo(histogram.Histogram().DataPoints().AppendEmpty().Attributes())
+
+	exponentialHistogram := metrics.AppendEmpty()
+	exponentialHistogram.SetDataType(pdata.MetricDataTypeExponentialHistogram)
+	pdata.NewAttributeMapFromMap(attrs).CopyTo(exponentialHistogram.ExponentialHistogram().DataPoints().AppendEmpty().Attributes())
+
+	summary := metrics.AppendEmpty()
+	summary.SetDataType(pdata.MetricDataTypeSummary)
+	pdata.NewAttributeMapFromMap(attrs).CopyTo(summary.Summary().DataPoints().AppendEmpty().Attributes())
+
+	return md
+}
+
+func TestMetricProcessor_DataPointAttributes(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "attribute1", Action: attraction.INSERT, Value: 123},
+		{Key: "attribute2", Action: attraction.DELETE},
+		{Key: "attribute3", Action: attraction.CONVERT, ConvertedType: "int"},
+	}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NotNil(t, mp)
+
+	md := generateMetricData(map[string]pdata.AttributeValue{
+		"attribute2": pdata.NewAttributeValueString("removed"),
+		"attribute3": pdata.NewAttributeValueString("404"),
+	})
+	assert.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+
+	expected := generateMetricData(map[string]pdata.AttributeValue{
+		"attribute1": pdata.NewAttributeValueInt(123),
+		"attribute3": pdata.NewAttributeValueInt(404),
+	})
+	metrics := md.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	expectedMetrics := expected.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+	require.Equal(t, expectedMetrics.Len(), metrics.Len())
+	for i := 0; i < metrics.Len(); i++ {
+		attrs := dataPointAttributes(metrics.At(i))
+		attrs.Sort()
+		expectedAttrs := dataPointAttributes(expectedMetrics.At(i))
+		expectedAttrs.Sort()
+		assert.Equal(t, expectedAttrs, attrs, metrics.At(i).DataType().String())
+	}
+}
+
+func dataPointAttributes(metric pdata.Metric) pdata.AttributeMap {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		return metric.Gauge().DataPoints().At(0).Attributes()
+	case pdata.MetricDataTypeSum:
+		return metric.Sum().DataPoints().At(0).Attributes()
+	case pdata.MetricDataTypeHistogram:
+		return metric.Histogram().DataPoints().At(0).Attributes()
+	case pdata.MetricDataTypeExponentialHistogram:
+		return metric.ExponentialHistogram().DataPoints().At(0).Attributes()
+	default:
+		return metric.Summary().DataPoints().At(0).Attributes()
+	}
+}
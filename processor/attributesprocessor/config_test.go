@@ -201,4 +201,15 @@ func TestLoadingConfig(t *testing.T) {
 		},
 	})
 
+	p11 := cfg.Processors[config.NewComponentIDWithName(typeStr, "convert_truncate_concat")]
+	assert.Equal(t, p11, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "convert_truncate_concat")),
+		Settings: attraction.Settings{
+			Actions: []attraction.ActionKeyValue{
+				{Key: "http.status_code", ConvertedType: "int", Action: attraction.CONVERT},
+				{Key: "db.statement", MaxLength: 512, Action: attraction.TRUNCATE},
+				{Key: "operation", FromAttributes: []string{"http.method", "http.route"}, Separator: " ", Action: attraction.CONCAT},
+			},
+		},
+	})
 }
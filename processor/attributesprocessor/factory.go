@@ -41,6 +41,7 @@ func NewFactory() component.ProcessorFactory {
 		typeStr,
 		createDefaultConfig,
 		processorhelper.WithTraces(createTracesProcessor),
+		processorhelper.WithMetrics(createMetricsProcessor),
 		processorhelper.WithLogs(createLogProcessor))
 }
 
@@ -110,3 +111,28 @@ func createLogProcessor(
 		newLogAttributesProcessor(attrProc, include, exclude).processLogs,
 		processorhelper.WithCapabilities(processorCapabilities))
 }
+
+func createMetricsProcessor(
+	_ context.Context,
+	_ component.ProcessorCreateSettings,
+	cfg config.Processor,
+	nextConsumer consumer.Metrics,
+) (component.MetricsProcessor, error) {
+	oCfg := cfg.(*Config)
+	if len(oCfg.Actions) == 0 {
+		return nil, fmt.Errorf("error creating \"attributes\" processor due to missing required field \"actions\" of processor %v", cfg.ID())
+	}
+	if oCfg.Include != nil || oCfg.Exclude != nil {
+		return nil, fmt.Errorf("error creating \"attributes\" processor: include and exclude aren't supported for metrics of processor %v", cfg.ID())
+	}
+	attrProc, err := attraction.NewAttrProc(&oCfg.Settings)
+	if err != nil {
+		return nil, fmt.Errorf("error creating \"attributes\" processor: %w of processor %v", err, cfg.ID())
+	}
+
+	return processorhelper.NewMetricsProcessor(
+		cfg,
+		nextConsumer,
+		newMetricAttributesProcessor(attrProc).processMetrics,
+		processorhelper.WithCapabilities(processorCapabilities))
+}
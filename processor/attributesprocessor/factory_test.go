@@ -19,14 +19,14 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
-	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/attraction"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
 )
 
 func TestFactory_Type(t *testing.T) {
@@ -88,13 +88,52 @@ func TestFactoryCreateTracesProcessor(t *testing.T) {
 	assert.Error(t, err)
 }
 
-func TestFactory_CreateMetricsProcessor(t *testing.T) {
+func TestFactoryCreateMetricsProcessor_EmptyActions(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
+	mp, err := factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, mp)
+}
+
+func TestFactoryCreateMetricsProcessor_IncludeExclude(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "a key", Action: attraction.DELETE},
+	}
+	oCfg.Include = &filterconfig.MatchProperties{
+		Config:   *createConfig(filterset.Strict),
+		Services: []string{"svcA"},
+	}
+	mp, err := factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, mp)
+}
 
-	mp, err := factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, nil)
-	require.Nil(t, mp)
-	assert.Equal(t, err, componenterror.ErrDataTypeIsNotSupported)
+func TestFactoryCreateMetricsProcessor(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Key: "a key", Action: attraction.DELETE},
+	}
+
+	mp, err := factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.NotNil(t, mp)
+	assert.NoError(t, err)
+
+	mp, err = factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, nil)
+	assert.Nil(t, mp)
+	assert.Error(t, err)
+
+	oCfg.Actions = []attraction.ActionKeyValue{
+		{Action: attraction.DELETE},
+	}
+	mp, err = factory.CreateMetricsProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.Nil(t, mp)
+	assert.Error(t, err)
 }
 
 func TestFactoryCreateLogsProcessor_EmptyActions(t *testing.T) {
@@ -29,6 +29,8 @@ type Config struct {
 	Metrics MetricFilters `mapstructure:"metrics"`
 
 	Logs LogFilters `mapstructure:"logs"`
+
+	Spans filterconfig.MatchConfig `mapstructure:"spans"`
 }
 
 // MetricFilters filters by Metric properties.
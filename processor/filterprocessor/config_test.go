@@ -26,6 +26,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filtermetric"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
 	fsregexp "github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset/regexp"
 )
 
@@ -188,6 +189,40 @@ func TestLoadingConfigStrictLogs(t *testing.T) {
 	}
 }
 
+// TestLoadingConfigTraces tests loading testdata/config_traces.yaml
+func TestLoadingConfigTraces(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.Nil(t, err)
+
+	factory := NewFactory()
+	factories.Processors[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config_traces.yaml"), factories)
+
+	assert.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "spans")),
+		Spans: filterconfig.MatchConfig{
+			Include: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Strict},
+				Services:  []string{"checkout"},
+				SpanKinds: []string{"SPAN_KIND_SERVER", "SPAN_KIND_CONSUMER"},
+			},
+			Exclude: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Regexp},
+				SpanNames: []string{"^/health.*"},
+				Attributes: []filterconfig.Attribute{
+					{Key: "http.target", Value: "^/(ready|live)$"},
+				},
+				Resources: []filterconfig.Attribute{
+					{Key: "deployment.environment", Value: "dev.*"},
+				},
+			},
+		},
+	}, cfg.Processors[config.NewComponentIDWithName(typeStr, "spans")])
+}
+
 // TestLoadingConfigRegexp tests loading testdata/config_regexp.yaml
 func TestLoadingConfigRegexp(t *testing.T) {
 	// list of filters used repeatedly on testdata/config.yaml
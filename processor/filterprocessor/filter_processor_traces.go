@@ -0,0 +1,82 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterspan"
+)
+
+type filterSpanProcessor struct {
+	cfg     *Config
+	include filterspan.Matcher
+	exclude filterspan.Matcher
+	logger  *zap.Logger
+}
+
+func newFilterSpansProcessor(logger *zap.Logger, cfg *Config) (*filterSpanProcessor, error) {
+	include, err := filterspan.NewMatcher(cfg.Spans.Include)
+	if err != nil {
+		logger.Error(
+			"filterspan: Error creating include spans matcher", zap.Error(err),
+		)
+		return nil, err
+	}
+
+	exclude, err := filterspan.NewMatcher(cfg.Spans.Exclude)
+	if err != nil {
+		logger.Error(
+			"filterspan: Error creating exclude spans matcher", zap.Error(err),
+		)
+		return nil, err
+	}
+
+	return &filterSpanProcessor{
+		cfg:     cfg,
+		include: include,
+		exclude: exclude,
+		logger:  logger,
+	}, nil
+}
+
+// processTraces filters the given spans based off the filterSpanProcessor's filters.
+func (fsp *filterSpanProcessor) processTraces(_ context.Context, td pdata.Traces) (pdata.Traces, error) {
+	if fsp.include == nil && fsp.exclude == nil {
+		return td, nil
+	}
+
+	td.ResourceSpans().RemoveIf(func(rs pdata.ResourceSpans) bool {
+		resource := rs.Resource()
+		rs.InstrumentationLibrarySpans().RemoveIf(func(ils pdata.InstrumentationLibrarySpans) bool {
+			library := ils.InstrumentationLibrary()
+			ils.Spans().RemoveIf(func(span pdata.Span) bool {
+				return filterspan.SkipSpan(fsp.include, fsp.exclude, span, resource, library)
+			})
+			// Filter out empty InstrumentationLibrarySpans
+			return ils.Spans().Len() == 0
+		})
+		// Filter out empty ResourceSpans
+		return rs.InstrumentationLibrarySpans().Len() == 0
+	})
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
@@ -0,0 +1,237 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterset"
+)
+
+type spanWithResource struct {
+	service string
+	spans   []testSpan
+}
+
+type testSpan struct {
+	name       string
+	kind       pdata.SpanKind
+	attributes map[string]pdata.AttributeValue
+}
+
+var (
+	inSpansForTwoServices = []spanWithResource{
+		{
+			service: "checkout",
+			spans: []testSpan{
+				{name: "/health", kind: pdata.SpanKindServer},
+				{name: "POST /cart", kind: pdata.SpanKindServer},
+				{name: "SELECT cart", kind: pdata.SpanKindClient, attributes: map[string]pdata.AttributeValue{
+					"db.system": pdata.NewAttributeValueString("postgresql"),
+				}},
+			},
+		},
+		{
+			service: "frontend",
+			spans: []testSpan{
+				{name: "GET /", kind: pdata.SpanKindServer},
+			},
+		},
+	}
+
+	standardSpanTests = []struct {
+		name    string
+		inc     *filterconfig.MatchProperties
+		exc     *filterconfig.MatchProperties
+		inSpans pdata.Traces
+		outSN   [][]string // output span names per Resource
+	}{
+		{
+			name:    "emptyFilterInclude",
+			inc:     nil,
+			inSpans: testResourceSpans(inSpansForTwoServices),
+			outSN: [][]string{
+				{"/health", "POST /cart", "SELECT cart"},
+				{"GET /"},
+			},
+		},
+		{
+			name: "includeService",
+			inc: &filterconfig.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Strict},
+				Services: []string{"checkout"},
+			},
+			inSpans: testResourceSpans(inSpansForTwoServices),
+			outSN: [][]string{
+				{"/health", "POST /cart", "SELECT cart"},
+			},
+		},
+		{
+			name: "excludeSpanNameRegexp",
+			exc: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Regexp},
+				SpanNames: []string{"^/health.*"},
+			},
+			inSpans: testResourceSpans(inSpansForTwoServices),
+			outSN: [][]string{
+				{"POST /cart", "SELECT cart"},
+				{"GET /"},
+			},
+		},
+		{
+			name: "includeSpanKind",
+			inc: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Strict},
+				SpanKinds: []string{pdata.SpanKindClient.String()},
+			},
+			inSpans: testResourceSpans(inSpansForTwoServices),
+			outSN: [][]string{
+				{"SELECT cart"},
+			},
+		},
+		{
+			name: "includeServiceExcludeAttribute",
+			inc: &filterconfig.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Strict},
+				Services: []string{"checkout"},
+			},
+			exc: &filterconfig.MatchProperties{
+				Config:     filterset.Config{MatchType: filterset.Strict},
+				Attributes: []filterconfig.Attribute{{Key: "db.system"}},
+			},
+			inSpans: testResourceSpans(inSpansForTwoServices),
+			outSN: [][]string{
+				{"/health", "POST /cart"},
+			},
+		},
+		{
+			name: "excludeResource",
+			exc: &filterconfig.MatchProperties{
+				Config:    filterset.Config{MatchType: filterset.Strict},
+				Resources: []filterconfig.Attribute{{Key: conventions.AttributeServiceName, Value: "frontend"}},
+			},
+			inSpans: testResourceSpans(inSpansForTwoServices),
+			outSN: [][]string{
+				{"/health", "POST /cart", "SELECT cart"},
+			},
+		},
+	}
+)
+
+func TestFilterSpanProcessor(t *testing.T) {
+	for _, test := range standardSpanTests {
+		t.Run(test.name, func(t *testing.T) {
+			// next stores the results of the filter span processor
+			next := new(consumertest.TracesSink)
+			cfg := &Config{
+				ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+				Spans: filterconfig.MatchConfig{
+					Include: test.inc,
+					Exclude: test.exc,
+				},
+			}
+			factory := NewFactory()
+			fsp, err := factory.CreateTracesProcessor(
+				context.Background(),
+				componenttest.NewNopProcessorCreateSettings(),
+				cfg,
+				next,
+			)
+			assert.NotNil(t, fsp)
+			assert.Nil(t, err)
+
+			caps := fsp.Capabilities()
+			assert.True(t, caps.MutatesData)
+			ctx := context.Background()
+			assert.NoError(t, fsp.Start(ctx, nil))
+
+			cErr := fsp.ConsumeTraces(context.Background(), test.inSpans)
+			assert.Nil(t, cErr)
+			got := next.AllTraces()
+
+			require.Len(t, got, 1)
+			rSpans := got[0].ResourceSpans()
+			assert.Equal(t, len(test.outSN), rSpans.Len())
+
+			for i, wantOut := range test.outSN {
+				gotSpans := rSpans.At(i).InstrumentationLibrarySpans().At(0).Spans()
+				assert.Equal(t, len(wantOut), gotSpans.Len())
+				for idx := range wantOut {
+					assert.Equal(t, wantOut[idx], gotSpans.At(idx).Name())
+				}
+			}
+			assert.NoError(t, fsp.Shutdown(ctx))
+		})
+	}
+}
+
+func TestFilterSpanProcessorDropsAll(t *testing.T) {
+	next := new(consumertest.TracesSink)
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Spans: filterconfig.MatchConfig{
+			Exclude: &filterconfig.MatchProperties{
+				Config:   filterset.Config{MatchType: filterset.Regexp},
+				Services: []string{".*"},
+			},
+		},
+	}
+	fsp, err := NewFactory().CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, next)
+	require.NoError(t, err)
+
+	assert.NoError(t, fsp.ConsumeTraces(context.Background(), testResourceSpans(inSpansForTwoServices)))
+	assert.Len(t, next.AllTraces(), 0)
+}
+
+func TestFilterSpanProcessorInvalidConfig(t *testing.T) {
+	cfg := &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		Spans: filterconfig.MatchConfig{
+			Include: &filterconfig.MatchProperties{
+				Config: filterset.Config{MatchType: filterset.Strict},
+			},
+		},
+	}
+	fsp, err := NewFactory().CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, fsp)
+}
+
+func testResourceSpans(swrs []spanWithResource) pdata.Traces {
+	td := pdata.NewTraces()
+
+	for _, swr := range swrs {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().InsertString(conventions.AttributeServiceName, swr.service)
+		ss := rs.InstrumentationLibrarySpans().AppendEmpty().Spans()
+		for _, ts := range swr.spans {
+			s := ss.AppendEmpty()
+			s.SetName(ts.name)
+			s.SetKind(ts.kind)
+			pdata.NewAttributeMapFromMap(ts.attributes).CopyTo(s.Attributes())
+		}
+	}
+	return td
+}
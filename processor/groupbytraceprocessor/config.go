@@ -39,12 +39,21 @@ type Config struct {
 	// DiscardOrphans instructs the processor to discard traces without the root span.
 	// This typically indicates that the trace is incomplete.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	DiscardOrphans bool `mapstructure:"discard_orphans"`
 
+	// OrphanAttribute is the name of a boolean span attribute to be set to true on every span of
+	// traces without the root span, allowing the next components to treat them differently.
+	// Ignored when DiscardOrphans is set.
+	// Default: "" (orphans are not marked).
+	OrphanAttribute string `mapstructure:"orphan_attribute"`
+
 	// StoreOnDisk tells the processor to keep only the trace ID in memory, serializing the trace spans to disk.
 	// Useful when the duration to wait for traces to complete is high.
+	// The spans are stored using a storage extension, such as the file_storage extension.
 	// Default: false.
-	// Not yet implemented, and an error will be returned when this option is used.
 	StoreOnDisk bool `mapstructure:"store_on_disk"`
+
+	// StorageID is the ID of the storage extension to use when StoreOnDisk is enabled.
+	// When not set, the only storage extension configured in the collector is used.
+	StorageID *config.ComponentID `mapstructure:"storage"`
 }
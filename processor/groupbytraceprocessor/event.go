@@ -40,6 +40,9 @@ const (
 
 	// traceID to be removed
 	traceRemoved
+
+	// traceID recovered from the storage
+	traceRecovered
 )
 
 var (
@@ -85,6 +88,8 @@ type eventMachine struct {
 	onTraceReleased func(rss []pdata.ResourceSpans) error
 	onTraceRemoved  func(traceID pdata.TraceID) error
 
+	onTraceRecovered func(traceID pdata.TraceID, worker *eventMachineWorker) error
+
 	onError func(event)
 
 	// shutdown sync
@@ -213,6 +218,22 @@ func (em *eventMachine) handleEvent(e event, w *eventMachineWorker) {
 		em.handleEventWithObservability("onTraceRemoved", func() error {
 			return em.onTraceRemoved(payload)
 		})
+	case traceRecovered:
+		if em.onTraceRecovered == nil {
+			em.logger.Debug("onTraceRecovered not set, skipping event")
+			em.callOnError(e)
+			return
+		}
+		payload, ok := e.payload.(pdata.TraceID)
+		if !ok {
+			// the payload had an unexpected type!
+			em.callOnError(e)
+			return
+		}
+
+		em.handleEventWithObservability("onTraceRecovered", func() error {
+			return em.onTraceRecovered(payload, w)
+		})
 	default:
 		em.logger.Info("unknown event type", zap.Any("event", e.typ))
 		em.callOnError(e)
@@ -241,6 +262,19 @@ func (em *eventMachine) consume(td pdata.Traces) error {
 	return nil
 }
 
+// recover routes a trace recovered from the storage to the worker responsible for its traceID.
+func (em *eventMachine) recover(traceID pdata.TraceID) {
+	var bucket uint64
+	if len(em.workers) != 1 {
+		bucket = workerIndexForTraceID(traceID, len(em.workers))
+	}
+
+	em.workers[bucket].fire(event{
+		typ:     traceRecovered,
+		payload: traceID,
+	})
+}
+
 func workerIndexForTraceID(traceID pdata.TraceID, numWorkers int) uint64 {
 	hash := hashPool.Get().(*maphash.Hash)
 	defer func() {
@@ -19,6 +19,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 )
 
@@ -47,33 +48,17 @@ func TestCreateTestProcessor(t *testing.T) {
 	assert.NotNil(t, p)
 }
 
-func TestCreateTestProcessorWithNotImplementedOptions(t *testing.T) {
-	// prepare
-	f := NewFactory()
+func TestCreateTestProcessorWithDiskStorage(t *testing.T) {
+	c := createDefaultConfig().(*Config)
+	c.StoreOnDisk = true
+
 	next := &mockProcessor{}
 
 	// test
-	for _, tt := range []struct {
-		config      *Config
-		expectedErr error
-	}{
-		{
-			&Config{
-				DiscardOrphans: true,
-			},
-			errDiscardOrphansNotSupported,
-		},
-		{
-			&Config{
-				StoreOnDisk: true,
-			},
-			errDiskStorageNotSupported,
-		},
-	} {
-		p, err := f.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), tt.config, next)
+	p, err := createTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), c, next)
 
-		// verify
-		assert.Error(t, tt.expectedErr, err)
-		assert.Nil(t, p)
-	}
+	// verify
+	assert.NoError(t, err)
+	require.NotNil(t, p)
+	assert.IsType(t, &diskStorage{}, p.(*groupByTraceProcessor).st)
 }
@@ -25,9 +25,11 @@ var (
 	mNumTracesConf      = stats.Int64("processor_groupbytrace_conf_num_traces", "Maximum number of traces to hold in the internal storage", stats.UnitDimensionless)
 	mNumEventsInQueue   = stats.Int64("processor_groupbytrace_num_events_in_queue", "Number of events currently in the queue", stats.UnitDimensionless)
 	mNumTracesInMemory  = stats.Int64("processor_groupbytrace_num_traces_in_memory", "Number of traces currently in the in-memory storage", stats.UnitDimensionless)
+	mNumTracesOnDisk    = stats.Int64("processor_groupbytrace_num_traces_on_disk", "Number of traces currently in the on-disk storage", stats.UnitDimensionless)
 	mTracesEvicted      = stats.Int64("processor_groupbytrace_traces_evicted", "Traces evicted from the internal buffer", stats.UnitDimensionless)
 	mReleasedSpans      = stats.Int64("processor_groupbytrace_spans_released", "Spans released to the next consumer", stats.UnitDimensionless)
 	mReleasedTraces     = stats.Int64("processor_groupbytrace_traces_released", "Traces released to the next consumer", stats.UnitDimensionless)
+	mOrphanTraces       = stats.Int64("processor_groupbytrace_traces_orphaned", "Traces released without a root span", stats.UnitDimensionless)
 	mIncompleteReleases = stats.Int64("processor_groupbytrace_incomplete_releases", "Releases that are suspected to have been incomplete", stats.UnitDimensionless)
 	mEventLatency       = stats.Int64("processor_groupbytrace_event_latency", "How long the queue events are taking to be processed", stats.UnitMilliseconds)
 )
@@ -53,6 +55,12 @@ func MetricViews() []*view.View {
 			Description: mNumTracesInMemory.Description(),
 			Aggregation: view.LastValue(),
 		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mNumTracesOnDisk.Name()),
+			Measure:     mNumTracesOnDisk,
+			Description: mNumTracesOnDisk.Description(),
+			Aggregation: view.LastValue(),
+		},
 		{
 			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mTracesEvicted.Name()),
 			Measure:     mTracesEvicted,
@@ -72,6 +80,12 @@ func MetricViews() []*view.View {
 			Description: mReleasedTraces.Description(),
 			Aggregation: view.Sum(),
 		},
+		{
+			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mOrphanTraces.Name()),
+			Measure:     mOrphanTraces,
+			Description: mOrphanTraces.Description(),
+			Aggregation: view.Sum(),
+		},
 		{
 			Name:        obsreport.BuildProcessorCustomMetricName(string(typeStr), mIncompleteReleases.Name()),
 			Measure:     mIncompleteReleases,
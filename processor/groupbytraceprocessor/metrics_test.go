@@ -25,9 +25,11 @@ func TestProcessorMetrics(t *testing.T) {
 		"processor/groupbytrace/processor_groupbytrace_conf_num_traces",
 		"processor/groupbytrace/processor_groupbytrace_num_events_in_queue",
 		"processor/groupbytrace/processor_groupbytrace_num_traces_in_memory",
+		"processor/groupbytrace/processor_groupbytrace_num_traces_on_disk",
 		"processor/groupbytrace/processor_groupbytrace_traces_evicted",
 		"processor/groupbytrace/processor_groupbytrace_spans_released",
 		"processor/groupbytrace/processor_groupbytrace_traces_released",
+		"processor/groupbytrace/processor_groupbytrace_traces_orphaned",
 		"processor/groupbytrace/processor_groupbytrace_incomplete_releases",
 		"processor/groupbytrace/processor_groupbytrace_event_latency",
 	}
@@ -75,6 +75,7 @@ func newGroupByTraceProcessor(logger *zap.Logger, st storage, nextConsumer consu
 	eventMachine.onTraceExpired = sp.onTraceExpired
 	eventMachine.onTraceReleased = sp.onTraceReleased
 	eventMachine.onTraceRemoved = sp.onTraceRemoved
+	eventMachine.onTraceRecovered = sp.onTraceRecovered
 
 	return sp
 }
@@ -92,14 +93,22 @@ func (sp *groupByTraceProcessor) Capabilities() consumer.Capabilities {
 }
 
 // Start is invoked during service startup.
-func (sp *groupByTraceProcessor) Start(context.Context, component.Host) error {
+func (sp *groupByTraceProcessor) Start(ctx context.Context, host component.Host) error {
 	// start these metrics, as it might take a while for them to receive their first event
 	stats.Record(context.Background(), mTracesEvicted.M(0))
 	stats.Record(context.Background(), mIncompleteReleases.M(0))
+	stats.Record(context.Background(), mOrphanTraces.M(0))
 	stats.Record(context.Background(), mNumTracesConf.M(int64(sp.config.NumTraces)))
 
 	sp.eventMachine.startInBackground()
-	return sp.st.start()
+	if err := sp.st.start(ctx, host); err != nil {
+		return err
+	}
+
+	if rst, ok := sp.st.(recoverableStorage); ok {
+		sp.reprocessRecoveredTraces(rst)
+	}
+	return nil
 }
 
 // Shutdown is invoked during service shutdown.
@@ -124,8 +133,31 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 
 	// at this point, we determined that we haven't seen the trace yet, so, record the
 	// traceID in the map and the spans to the storage
+	sp.track(traceID, worker)
+
+	// we have the traceID in the memory, place the spans in the storage too
+	if err := sp.addSpans(traceID, trace.td); err != nil {
+		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
+	}
+
+	sp.scheduleRelease(traceID, worker)
+	return nil
+}
 
-	// place the trace ID in the buffer, and check if an item had to be evicted
+func (sp *groupByTraceProcessor) onTraceRecovered(traceID pdata.TraceID, worker *eventMachineWorker) error {
+	if worker.buffer.contains(traceID) {
+		sp.logger.Debug("recovered trace is already in memory storage")
+		return nil
+	}
+
+	// the spans are in the storage already, only the traceID has to be tracked again
+	sp.track(traceID, worker)
+	sp.scheduleRelease(traceID, worker)
+	return nil
+}
+
+// track places the trace ID in the worker's buffer, removing the trace evicted from the buffer, if any
+func (sp *groupByTraceProcessor) track(traceID pdata.TraceID, worker *eventMachineWorker) {
 	evicted := worker.buffer.put(traceID)
 	if !evicted.IsEmpty() {
 		// delete from the storage
@@ -139,12 +171,9 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 		sp.logger.Info("trace evicted: in order to avoid this in the future, adjust the wait duration and/or number of traces to keep in memory",
 			zap.String("traceID", evicted.HexString()))
 	}
+}
 
-	// we have the traceID in the memory, place the spans in the storage too
-	if err := sp.addSpans(traceID, trace.td); err != nil {
-		return fmt.Errorf("couldn't add spans to existing trace: %w", err)
-	}
-
+func (sp *groupByTraceProcessor) scheduleRelease(traceID pdata.TraceID, worker *eventMachineWorker) {
 	sp.logger.Debug("scheduled to release trace", zap.Duration("duration", sp.config.WaitDuration))
 
 	time.AfterFunc(sp.config.WaitDuration, func() {
@@ -154,7 +183,6 @@ func (sp *groupByTraceProcessor) onTraceReceived(trace tracesWithID, worker *eve
 			payload: traceID,
 		})
 	})
-	return nil
 }
 
 func (sp *groupByTraceProcessor) onTraceExpired(traceID pdata.TraceID, worker *eventMachineWorker) error {
@@ -214,6 +242,20 @@ func (sp *groupByTraceProcessor) onTraceReleased(rss []pdata.ResourceSpans) erro
 		trs := trace.ResourceSpans().AppendEmpty()
 		rs.CopyTo(trs)
 	}
+
+	if !hasRootSpan(trace) {
+		stats.Record(context.Background(), mOrphanTraces.M(1))
+
+		if sp.config.DiscardOrphans {
+			sp.logger.Debug("discarding trace without root span")
+			return nil
+		}
+
+		if sp.config.OrphanAttribute != "" {
+			markSpans(trace, sp.config.OrphanAttribute)
+		}
+	}
+
 	stats.Record(context.Background(),
 		mReleasedSpans.M(int64(trace.SpanCount())),
 		mReleasedTraces.M(1),
@@ -228,6 +270,37 @@ func (sp *groupByTraceProcessor) onTraceReleased(rss []pdata.ResourceSpans) erro
 	return nil
 }
 
+// hasRootSpan returns whether the given trace contains a span without a parent
+func hasRootSpan(td pdata.Traces) bool {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				if spans.At(k).ParentSpanID().IsEmpty() {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// markSpans sets the given boolean attribute to true on all spans of the trace
+func markSpans(td pdata.Traces, attribute string) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				spans.At(k).Attributes().UpsertBool(attribute, true)
+			}
+		}
+	}
+}
+
 func (sp *groupByTraceProcessor) onTraceRemoved(traceID pdata.TraceID) error {
 	trace, err := sp.st.delete(traceID)
 	if err != nil {
@@ -241,6 +314,22 @@ func (sp *groupByTraceProcessor) onTraceRemoved(traceID pdata.TraceID) error {
 	return nil
 }
 
+// reprocessRecoveredTraces takes the traces that were left in the storage by a previous run of the processor
+// and tracks them again in the workers' buffers, so that they are released once the wait duration expires.
+// The traces are kept in the storage until then, so that they are recovered again if the processor stops first.
+func (sp *groupByTraceProcessor) reprocessRecoveredTraces(st recoverableStorage) {
+	traceIDs := st.recovered()
+	if len(traceIDs) == 0 {
+		return
+	}
+
+	sp.logger.Info("reprocessing traces recovered from the storage", zap.Int("traces", len(traceIDs)))
+
+	for _, traceID := range traceIDs {
+		sp.eventMachine.recover(traceID)
+	}
+}
+
 func (sp *groupByTraceProcessor) addSpans(traceID pdata.TraceID, trace pdata.Traces) error {
 	sp.logger.Debug("creating trace at the storage", zap.String("traceID", traceID.HexString()))
 	return sp.st.createOrAppend(traceID, trace)
@@ -542,6 +542,84 @@ func TestAsyncOnRelease(t *testing.T) {
 	close(blockCh)
 }
 
+func TestOrphanTraces(t *testing.T) {
+	for _, tt := range []struct {
+		name           string
+		config         Config
+		root           bool
+		expectReleased bool
+		expectMarked   bool
+	}{
+		{
+			name:           "complete trace is released",
+			config:         Config{DiscardOrphans: true, OrphanAttribute: "orphan"},
+			root:           true,
+			expectReleased: true,
+		},
+		{
+			name:           "orphan is released by default",
+			config:         Config{},
+			expectReleased: true,
+		},
+		{
+			name:   "orphan is discarded",
+			config: Config{DiscardOrphans: true},
+		},
+		{
+			name:           "orphan is marked",
+			config:         Config{OrphanAttribute: "orphan"},
+			expectReleased: true,
+			expectMarked:   true,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			// prepare
+			var released []pdata.Traces
+			next := &mockProcessor{
+				onTraces: func(_ context.Context, td pdata.Traces) error {
+					released = append(released, td)
+					return nil
+				},
+			}
+			sp := newGroupByTraceProcessor(zap.NewNop(), newMemoryStorage(), next, tt.config)
+
+			trace := simpleTraces()
+			span := trace.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+			if !tt.root {
+				span.SetParentSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4}))
+			}
+
+			// test
+			require.NoError(t, sp.onTraceReleased([]pdata.ResourceSpans{trace.ResourceSpans().At(0)}))
+
+			// verify
+			if !tt.expectReleased {
+				// the release is async, give it a chance to happen
+				time.Sleep(10 * time.Millisecond)
+				next.mutex.Lock()
+				defer next.mutex.Unlock()
+				assert.Len(t, released, 0)
+				return
+			}
+
+			require.Eventually(t, func() bool {
+				next.mutex.Lock()
+				defer next.mutex.Unlock()
+				return len(released) == 1
+			}, time.Second, 5*time.Millisecond)
+
+			next.mutex.Lock()
+			defer next.mutex.Unlock()
+			attrs := released[0].ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Attributes()
+			marker, found := attrs.Get("orphan")
+			assert.Equal(t, tt.expectMarked, found)
+			if tt.expectMarked {
+				assert.True(t, marker.BoolVal())
+			}
+		})
+	}
+}
+
 func BenchmarkConsumeTracesCompleteOnFirstBatch(b *testing.B) {
 	// prepare
 	config := Config{
@@ -601,7 +679,7 @@ type mockStorage struct {
 	onCreateOrAppend func(pdata.TraceID, pdata.Traces) error
 	onGet            func(pdata.TraceID) ([]pdata.ResourceSpans, error)
 	onDelete         func(pdata.TraceID) ([]pdata.ResourceSpans, error)
-	onStart          func() error
+	onStart          func(context.Context, component.Host) error
 	onShutdown       func() error
 }
 
@@ -625,9 +703,9 @@ func (st *mockStorage) delete(traceID pdata.TraceID) ([]pdata.ResourceSpans, err
 	}
 	return nil, nil
 }
-func (st *mockStorage) start() error {
+func (st *mockStorage) start(ctx context.Context, host component.Host) error {
 	if st.onStart != nil {
-		return st.onStart()
+		return st.onStart(ctx, host)
 	}
 	return nil
 }
@@ -15,6 +15,9 @@
 package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
 
 import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
@@ -35,8 +38,16 @@ type storage interface {
 	delete(pdata.TraceID) ([]pdata.ResourceSpans, error)
 
 	// start gives the storage the opportunity to initialize any resources or procedures
-	start() error
+	start(context.Context, component.Host) error
 
 	// shutdown signals the storage that the processor is shutting down
 	shutdown() error
 }
+
+// recoverableStorage is a storage that is able to keep traces across restarts of the processor.
+type recoverableStorage interface {
+	storage
+
+	// recovered returns the IDs of the traces that were found in the storage when it was started
+	recovered() []pdata.TraceID
+}
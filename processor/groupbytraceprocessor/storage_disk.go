@@ -0,0 +1,419 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/groupbytraceprocessor"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	extstorage "go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// indexHeadKey is the key holding the ID of the most recently added trace. Each trace in the storage also
+// has an index entry linking it to the traces added right before and after it, so that the traces can be
+// listed when the storage is started again, while adding or removing a trace only updates a few keys,
+// along with its batches.
+const indexHeadKey = "index/head"
+
+var (
+	errNoStorageExtension        = errors.New("option 'store_on_disk' requires a storage extension")
+	errMultipleStorageExtensions = errors.New("multiple storage extensions found, use the option 'storage' to select one")
+	errInvalidIndexEntry         = errors.New("invalid trace index entry found in the storage")
+)
+
+// diskStorage keeps only the trace IDs in memory, serializing the spans to a storage extension.
+// Each batch of spans received for a trace is stored under its own key, avoiding
+// a read-modify-write cycle whenever spans are appended to an existing trace.
+type diskStorage struct {
+	sync.RWMutex
+	logger      *zap.Logger
+	id          config.ComponentID
+	extensionID *config.ComponentID
+	client      extstorage.Client
+	marshaler   pdata.TracesMarshaler
+	unmarshaler pdata.TracesUnmarshaler
+
+	// the traces in the storage, and the most recently added one
+	traces map[pdata.TraceID]*traceEntry
+	head   pdata.TraceID
+
+	// the traces found in the storage upon start
+	recoveredTraceIDs []pdata.TraceID
+
+	stopped                   bool
+	stoppedLock               sync.RWMutex
+	metricsCollectionInterval time.Duration
+}
+
+// traceEntry tracks a trace in the storage
+type traceEntry struct {
+	// the number of batches stored for the trace
+	batches int
+
+	// the traces added right before and after this one, empty at the ends of the index
+	older pdata.TraceID
+	newer pdata.TraceID
+}
+
+var _ recoverableStorage = (*diskStorage)(nil)
+
+func newDiskStorage(logger *zap.Logger, id config.ComponentID, extensionID *config.ComponentID) *diskStorage {
+	return &diskStorage{
+		logger:                    logger,
+		id:                        id,
+		extensionID:               extensionID,
+		marshaler:                 otlp.NewProtobufTracesMarshaler(),
+		unmarshaler:               otlp.NewProtobufTracesUnmarshaler(),
+		traces:                    make(map[pdata.TraceID]*traceEntry),
+		metricsCollectionInterval: time.Second,
+	}
+}
+
+func (st *diskStorage) createOrAppend(traceID pdata.TraceID, td pdata.Traces) error {
+	buf, err := st.marshaler.MarshalTraces(td)
+	if err != nil {
+		return err
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	entry, ok := st.traces[traceID]
+	if ok {
+		// the index only lists the traces, the batches appended later are found when recovering the trace
+		if err := st.client.Set(context.Background(), batchKey(traceID, entry.batches), buf); err != nil {
+			return err
+		}
+		entry.batches++
+		return nil
+	}
+
+	ops := append(st.link(traceID), extstorage.SetOperation(batchKey(traceID, 0), buf))
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		st.unlink(traceID)
+		return err
+	}
+	st.traces[traceID].batches = 1
+
+	return nil
+}
+
+func (st *diskStorage) get(traceID pdata.TraceID) ([]pdata.ResourceSpans, error) {
+	st.RLock()
+	defer st.RUnlock()
+
+	entry, ok := st.traces[traceID]
+	if !ok {
+		return nil, nil
+	}
+
+	return st.read(traceID, entry.batches)
+}
+
+// delete will remove the trace from the storage, returning its contents
+func (st *diskStorage) delete(traceID pdata.TraceID) ([]pdata.ResourceSpans, error) {
+	st.Lock()
+	defer st.Unlock()
+
+	entry, ok := st.traces[traceID]
+	if !ok {
+		return nil, nil
+	}
+
+	result, err := st.read(traceID, entry.batches)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep the index as it was, in case the removal fails
+	head := st.head
+	older, newer := st.traces[entry.older], st.traces[entry.newer]
+	var olderCopy, newerCopy traceEntry
+	if older != nil {
+		olderCopy = *older
+	}
+	if newer != nil {
+		newerCopy = *newer
+	}
+
+	ops := st.unlink(traceID)
+	for i := 0; i < entry.batches; i++ {
+		ops = append(ops, extstorage.DeleteOperation(batchKey(traceID, i)))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		st.head = head
+		st.traces[traceID] = entry
+		if older != nil {
+			*older = olderCopy
+		}
+		if newer != nil {
+			*newer = newerCopy
+		}
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// link adds the trace to the in-memory index as the most recently added one, returning the operations
+// persisting the change. The caller is expected to hold the lock.
+func (st *diskStorage) link(traceID pdata.TraceID) []extstorage.Operation {
+	entry := &traceEntry{older: st.head}
+	st.traces[traceID] = entry
+	ops := []extstorage.Operation{st.entryOperation(traceID, entry)}
+
+	if head, ok := st.traces[st.head]; ok {
+		head.newer = traceID
+		ops = append(ops, st.entryOperation(st.head, head))
+	}
+
+	id := traceID.Bytes()
+	st.head = traceID
+	return append(ops, extstorage.SetOperation(indexHeadKey, id[:]))
+}
+
+// unlink removes the trace from the in-memory index, returning the operations persisting the change,
+// which also delete the index entry of the trace. The caller is expected to hold the lock.
+func (st *diskStorage) unlink(traceID pdata.TraceID) []extstorage.Operation {
+	entry := st.traces[traceID]
+	delete(st.traces, traceID)
+	ops := []extstorage.Operation{extstorage.DeleteOperation(indexKey(traceID))}
+
+	if older, ok := st.traces[entry.older]; ok {
+		older.newer = entry.newer
+		ops = append(ops, st.entryOperation(entry.older, older))
+	}
+
+	if newer, ok := st.traces[entry.newer]; ok {
+		newer.older = entry.older
+		return append(ops, st.entryOperation(entry.newer, newer))
+	}
+
+	// the trace was the most recently added one
+	st.head = entry.older
+	if st.head.IsEmpty() {
+		return append(ops, extstorage.DeleteOperation(indexHeadKey))
+	}
+	id := st.head.Bytes()
+	return append(ops, extstorage.SetOperation(indexHeadKey, id[:]))
+}
+
+// entryOperation returns the operation persisting the index entry of the trace
+func (st *diskStorage) entryOperation(traceID pdata.TraceID, entry *traceEntry) extstorage.Operation {
+	older, newer := entry.older.Bytes(), entry.newer.Bytes()
+	return extstorage.SetOperation(indexKey(traceID), append(older[:], newer[:]...))
+}
+
+// read retrieves the n batches stored for the given trace. The caller is expected to hold the lock.
+func (st *diskStorage) read(traceID pdata.TraceID, n int) ([]pdata.ResourceSpans, error) {
+	ops := make([]extstorage.Operation, n)
+	for i := 0; i < n; i++ {
+		ops[i] = extstorage.GetOperation(batchKey(traceID, i))
+	}
+	if err := st.client.Batch(context.Background(), ops...); err != nil {
+		return nil, err
+	}
+
+	var result []pdata.ResourceSpans
+	for _, op := range ops {
+		if op.Value == nil {
+			continue
+		}
+
+		td, err := st.unmarshaler.UnmarshalTraces(op.Value)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't unmarshal trace %q: %w", traceID.HexString(), err)
+		}
+
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			result = append(result, rss.At(i))
+		}
+	}
+
+	return result, nil
+}
+
+func (st *diskStorage) start(ctx context.Context, host component.Host) error {
+	client, err := st.getStorageClient(ctx, host)
+	if err != nil {
+		return err
+	}
+	st.client = client
+
+	if err := st.loadIndex(ctx); err != nil {
+		return err
+	}
+
+	go st.periodicMetrics()
+	return nil
+}
+
+// shutdown closes the storage. The traces still in the storage are recovered the next time the storage is started.
+func (st *diskStorage) shutdown() error {
+	st.stoppedLock.Lock()
+	defer st.stoppedLock.Unlock()
+	st.stopped = true
+
+	if st.client == nil {
+		return nil
+	}
+
+	st.RLock()
+	defer st.RUnlock()
+
+	if len(st.traces) > 0 {
+		st.logger.Info("keeping the in-flight traces in the storage", zap.Int("traces", len(st.traces)))
+	}
+	return st.client.Close(context.Background())
+}
+
+func (st *diskStorage) recovered() []pdata.TraceID {
+	return st.recoveredTraceIDs
+}
+
+// loadIndex restores the in-memory index of traces persisted by a previous run of the storage, following the
+// index entries from the most recently added trace. The number of batches of each trace isn't persisted,
+// the batches are looked up in the storage instead.
+func (st *diskStorage) loadIndex(ctx context.Context) error {
+	buf, err := st.client.Get(ctx, indexHeadKey)
+	if err != nil {
+		return err
+	}
+	if buf == nil {
+		return nil
+	}
+	if len(buf) != 16 {
+		return errInvalidIndexEntry
+	}
+
+	st.Lock()
+	defer st.Unlock()
+
+	st.head = traceIDFromBytes(buf)
+	for traceID := st.head; !traceID.IsEmpty(); {
+		if _, ok := st.traces[traceID]; ok {
+			return errInvalidIndexEntry
+		}
+
+		buf, err := st.client.Get(ctx, indexKey(traceID))
+		if err != nil {
+			return err
+		}
+		if len(buf) != 32 {
+			return errInvalidIndexEntry
+		}
+		entry := &traceEntry{
+			older: traceIDFromBytes(buf[:16]),
+			newer: traceIDFromBytes(buf[16:]),
+		}
+
+		for {
+			buf, err := st.client.Get(ctx, batchKey(traceID, entry.batches))
+			if err != nil {
+				return err
+			}
+			if buf == nil {
+				break
+			}
+			entry.batches++
+		}
+
+		st.traces[traceID] = entry
+		st.recoveredTraceIDs = append(st.recoveredTraceIDs, traceID)
+		traceID = entry.older
+	}
+
+	// the traces are recovered in the order they were added
+	for i, j := 0, len(st.recoveredTraceIDs)-1; i < j; i, j = i+1, j-1 {
+		st.recoveredTraceIDs[i], st.recoveredTraceIDs[j] = st.recoveredTraceIDs[j], st.recoveredTraceIDs[i]
+	}
+
+	return nil
+}
+
+func (st *diskStorage) getStorageClient(ctx context.Context, host component.Host) (extstorage.Client, error) {
+	var storageExtension extstorage.Extension
+	for id, ext := range host.GetExtensions() {
+		se, ok := ext.(extstorage.Extension)
+		if !ok {
+			continue
+		}
+
+		if st.extensionID != nil {
+			if id == *st.extensionID {
+				storageExtension = se
+				break
+			}
+			continue
+		}
+
+		if storageExtension != nil {
+			return nil, errMultipleStorageExtensions
+		}
+		storageExtension = se
+	}
+
+	if storageExtension == nil {
+		return nil, errNoStorageExtension
+	}
+
+	return storageExtension.GetClient(ctx, component.KindProcessor, st.id, "")
+}
+
+func (st *diskStorage) periodicMetrics() {
+	numTraces := st.count()
+	stats.Record(context.Background(), mNumTracesOnDisk.M(int64(numTraces)))
+
+	st.stoppedLock.RLock()
+	stopped := st.stopped
+	st.stoppedLock.RUnlock()
+	if stopped {
+		return
+	}
+
+	time.AfterFunc(st.metricsCollectionInterval, func() {
+		st.periodicMetrics()
+	})
+}
+
+func (st *diskStorage) count() int {
+	st.RLock()
+	defer st.RUnlock()
+	return len(st.traces)
+}
+
+func batchKey(traceID pdata.TraceID, n int) string {
+	return fmt.Sprintf("%s/%d", traceID.HexString(), n)
+}
+
+func indexKey(traceID pdata.TraceID) string {
+	return "index/" + traceID.HexString()
+}
+
+func traceIDFromBytes(buf []byte) pdata.TraceID {
+	var id [16]byte
+	copy(id[:], buf)
+	return pdata.NewTraceID(id)
+}
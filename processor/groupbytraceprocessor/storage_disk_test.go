@@ -0,0 +1,317 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package groupbytraceprocessor
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/storagetest"
+)
+
+var testProcessorID = config.NewComponentID(typeStr)
+
+func startedDiskStorage(t *testing.T, directory string) *diskStorage {
+	st := newDiskStorage(zap.NewNop(), testProcessorID, nil)
+	require.NoError(t, st.start(context.Background(), storagetest.NewStorageHost(t, directory, "storage")))
+	return st
+}
+
+func TestDiskCreateAndGetTrace(t *testing.T) {
+	// prepare
+	st := startedDiskStorage(t, t.TempDir())
+	defer st.shutdown()
+
+	traceIDs := []pdata.TraceID{
+		pdata.NewTraceID([16]byte{1, 2, 3, 4}),
+		pdata.NewTraceID([16]byte{2, 3, 4, 5}),
+	}
+
+	// test
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+
+	// verify
+	assert.Equal(t, 2, st.count())
+	for _, traceID := range traceIDs {
+		expected := []pdata.ResourceSpans{simpleTracesWithID(traceID).ResourceSpans().At(0)}
+
+		retrieved, err := st.get(traceID)
+		require.NoError(t, err)
+		assert.Equal(t, expected, retrieved)
+	}
+}
+
+func TestDiskGetNonExistingTrace(t *testing.T) {
+	// prepare
+	st := startedDiskStorage(t, t.TempDir())
+	defer st.shutdown()
+
+	// test
+	retrieved, err := st.get(pdata.NewTraceID([16]byte{1, 2, 3, 4}))
+
+	// verify
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDiskDeleteTrace(t *testing.T) {
+	// prepare
+	st := startedDiskStorage(t, t.TempDir())
+	defer st.shutdown()
+
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+	require.NoError(t, st.createOrAppend(traceID, trace))
+
+	// test
+	deleted, err := st.delete(traceID)
+
+	// verify
+	require.NoError(t, err)
+	assert.Equal(t, []pdata.ResourceSpans{trace.ResourceSpans().At(0)}, deleted)
+	assert.Equal(t, 0, st.count())
+
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDiskAppendSpans(t *testing.T) {
+	// prepare
+	st := startedDiskStorage(t, t.TempDir())
+	defer st.shutdown()
+
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	first := simpleTracesWithID(traceID)
+	second := simpleTracesWithID(traceID)
+	second.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).SetName("second-name")
+
+	// test
+	require.NoError(t, st.createOrAppend(traceID, first))
+	require.NoError(t, st.createOrAppend(traceID, second))
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	require.Len(t, retrieved, 2)
+	assert.Equal(t, first.ResourceSpans().At(0), retrieved[0])
+	assert.Equal(t, second.ResourceSpans().At(0), retrieved[1])
+	assert.Equal(t, 1, st.count())
+}
+
+func TestDiskRecoversTracesAfterRestart(t *testing.T) {
+	// prepare
+	directory := t.TempDir()
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	trace := simpleTracesWithID(traceID)
+
+	st := startedDiskStorage(t, directory)
+	require.NoError(t, st.createOrAppend(traceID, trace))
+	require.NoError(t, st.createOrAppend(traceID, trace))
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = startedDiskStorage(t, directory)
+	defer st.shutdown()
+
+	// verify
+	assert.Equal(t, []pdata.TraceID{traceID}, st.recovered())
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 2)
+}
+
+func TestDiskRecoversTracesAfterCrash(t *testing.T) {
+	// prepare
+	directory := t.TempDir()
+	kept := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	released := pdata.NewTraceID([16]byte{2, 3, 4, 5})
+
+	st := startedDiskStorage(t, directory)
+	require.NoError(t, st.createOrAppend(kept, simpleTracesWithID(kept)))
+	require.NoError(t, st.createOrAppend(released, simpleTracesWithID(released)))
+	require.NoError(t, st.createOrAppend(kept, simpleTracesWithID(kept)))
+	_, err := st.delete(released)
+	require.NoError(t, err)
+
+	// simulate a crash: the storage is never shut down, only its client is released
+	require.NoError(t, st.client.Close(context.Background()))
+
+	// test
+	st = startedDiskStorage(t, directory)
+	defer st.shutdown()
+
+	// verify
+	assert.Equal(t, []pdata.TraceID{kept}, st.recovered())
+	retrieved, err := st.get(kept)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 2)
+
+	retrieved, err = st.get(released)
+	require.NoError(t, err)
+	assert.Nil(t, retrieved)
+}
+
+func TestDiskStorageRequiresExtension(t *testing.T) {
+	st := newDiskStorage(zap.NewNop(), testProcessorID, nil)
+	assert.Equal(t, errNoStorageExtension, st.start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestDiskStorageWithMultipleExtensions(t *testing.T) {
+	host := storagetest.NewStorageHost(t, t.TempDir(), "first", "second")
+
+	st := newDiskStorage(zap.NewNop(), testProcessorID, nil)
+	assert.Equal(t, errMultipleStorageExtensions, st.start(context.Background(), host))
+
+	extensionID := config.NewComponentIDWithName("nop", "second")
+	st = newDiskStorage(zap.NewNop(), testProcessorID, &extensionID)
+	require.NoError(t, st.start(context.Background(), host))
+	assert.NoError(t, st.shutdown())
+}
+
+func TestDiskRecoversTracesInOrder(t *testing.T) {
+	// prepare
+	directory := t.TempDir()
+	traceIDs := []pdata.TraceID{
+		pdata.NewTraceID([16]byte{1}),
+		pdata.NewTraceID([16]byte{2}),
+		pdata.NewTraceID([16]byte{3}),
+		pdata.NewTraceID([16]byte{4}),
+		pdata.NewTraceID([16]byte{5}),
+	}
+
+	st := startedDiskStorage(t, directory)
+	for _, traceID := range traceIDs {
+		require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	}
+
+	// remove the oldest, the newest and one in the middle of the index
+	for _, i := range []int{0, 4, 2} {
+		_, err := st.delete(traceIDs[i])
+		require.NoError(t, err)
+	}
+	sixth := pdata.NewTraceID([16]byte{6})
+	require.NoError(t, st.createOrAppend(sixth, simpleTracesWithID(sixth)))
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = startedDiskStorage(t, directory)
+	defer st.shutdown()
+
+	// verify
+	assert.Equal(t, []pdata.TraceID{traceIDs[1], traceIDs[3], sixth}, st.recovered())
+	assert.Equal(t, 3, st.count())
+}
+
+func TestDiskStorageWithInvalidIndex(t *testing.T) {
+	// prepare
+	directory := t.TempDir()
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+
+	st := startedDiskStorage(t, directory)
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.client.Set(context.Background(), indexKey(traceID), []byte{1, 2, 3}))
+	require.NoError(t, st.shutdown())
+
+	// test
+	st = newDiskStorage(zap.NewNop(), testProcessorID, nil)
+	err := st.start(context.Background(), storagetest.NewStorageHost(t, directory, "storage"))
+
+	// verify
+	assert.Equal(t, errInvalidIndexEntry, err)
+}
+
+func TestProcessorReleasesRecoveredTraces(t *testing.T) {
+	// prepare
+	directory := t.TempDir()
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+	traces := simpleTracesWithID(traceID)
+
+	st := startedDiskStorage(t, directory)
+	require.NoError(t, st.createOrAppend(traceID, traces))
+	require.NoError(t, st.shutdown())
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+	next := &mockProcessor{
+		onTraces: func(_ context.Context, received pdata.Traces) error {
+			assert.Equal(t, traces, received)
+			wg.Done()
+			return nil
+		},
+	}
+	config := Config{
+		WaitDuration: time.Millisecond,
+		NumTraces:    10,
+		NumWorkers:   1,
+	}
+	st = newDiskStorage(zap.NewNop(), testProcessorID, nil)
+	p := newGroupByTraceProcessor(zap.NewNop(), st, next, config)
+
+	// test
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx, storagetest.NewStorageHost(t, directory, "storage")))
+	defer p.Shutdown(ctx)
+
+	// verify
+	wg.Wait()
+	assert.Eventually(t, func() bool {
+		return st.count() == 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestProcessorKeepsRecoveredTracesUntilReleased(t *testing.T) {
+	// prepare
+	directory := t.TempDir()
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4})
+
+	st := startedDiskStorage(t, directory)
+	require.NoError(t, st.createOrAppend(traceID, simpleTracesWithID(traceID)))
+	require.NoError(t, st.shutdown())
+
+	config := Config{
+		WaitDuration: time.Hour,
+		NumTraces:    10,
+		NumWorkers:   1,
+	}
+	st = newDiskStorage(zap.NewNop(), testProcessorID, nil)
+	p := newGroupByTraceProcessor(zap.NewNop(), st, &mockProcessor{}, config)
+
+	// test
+	ctx := context.Background()
+	require.NoError(t, p.Start(ctx, storagetest.NewStorageHost(t, directory, "storage")))
+
+	// verify
+	retrieved, err := st.get(traceID)
+	require.NoError(t, err)
+	assert.Len(t, retrieved, 1)
+
+	// the trace is recovered again when the processor stops before releasing it
+	require.NoError(t, p.Shutdown(ctx))
+	st = startedDiskStorage(t, directory)
+	defer st.shutdown()
+	assert.Equal(t, []pdata.TraceID{traceID}, st.recovered())
+}
@@ -63,6 +63,10 @@ type ExtractConfig struct {
 	//   k8s.pod.name, k8s.pod.uid, k8s.deployment.name, k8s.cluster.name,
 	//   k8s.node.name, k8s.namespace.name and k8s.pod.start_time
 	//
+	// Node metadata fields k8s.node.uid, cloud.availability_zone and cloud.region
+	// are extracted from the node the pod is running on. The zone and region are
+	// read from the node's topology labels.
+	//
 	// Specifying anything other than these values will result in an error.
 	// By default all of the fields are extracted and added to spans and metrics.
 	Metadata []string `mapstructure:"metadata"`
@@ -122,7 +126,7 @@ type FieldExtractConfig struct {
 	KeyRegex string `mapstructure:"key_regex"`
 	Regex    string `mapstructure:"regex"`
 	// From represents the source of the labels/annotations.
-	// Allowed values are "pod" and "namespace". The default is pod.
+	// Allowed values are "pod", "namespace" and "node". The default is pod.
 	From string `mapstructure:"from"`
 }
 
@@ -53,6 +53,31 @@
 //   - k8s.node.name
 // Not all the attributes are guaranteed to be added. For example `k8s.cluster.name` usually is not provided by k8s API,
 // so likely it won't be set as an attribute.
+//
+// The following pod owner attributes can be enabled with `metadata` configuration as well. They are resolved from
+// the pod's owner references, so a pod only gets the attributes of the workload that actually owns it:
+//   - k8s.deployment.uid
+//   - k8s.replicaset.name
+//   - k8s.replicaset.uid
+//   - k8s.statefulset.name
+//   - k8s.statefulset.uid
+//   - k8s.daemonset.name
+//   - k8s.daemonset.uid
+//   - k8s.job.name
+//   - k8s.job.uid
+//   - k8s.cronjob.name
+//   - k8s.cronjob.uid
+// `k8s.deployment.name` and `k8s.deployment.uid` are looked up through the owning ReplicaSet, and `k8s.cronjob.name`
+// and `k8s.cronjob.uid` through the owning Job, so enabling them starts an additional informer for ReplicaSets or Jobs.
+//
+// The following attributes are taken from the node the pod is running on. When the pod is not identified,
+// the node named in the `k8s.node.name` resource attribute is used instead:
+//   - k8s.node.uid
+//   - cloud.availability_zone - from the `topology.kubernetes.io/zone` node label
+//   - cloud.region - from the `topology.kubernetes.io/region` node label
+// The deprecated `failure-domain.beta.kubernetes.io/zone` and `failure-domain.beta.kubernetes.io/region` labels are
+// used if the GA labels are not set. Nodes are only watched when one of these attributes or a node label/annotation
+// is configured.
 
 // The following container level attributes require additional attributes to identify a particular container in a pod:
 //   1. Container spec attributes - will be set only if container identifying attribute `k8s.container.name` is set
@@ -68,7 +93,7 @@
 //This config represents a list of annotations/labels that are extracted from pods/namespaces and added to spans, metrics and logs.
 //Each item is specified as a config of tag_name (representing the tag name to tag the spans with),
 //key (representing the key used to extract value) and from (representing the kubernetes object used to extract the value).
-//The "from" field has only two possible values "pod" and "namespace" and defaults to "pod" if none is specified.
+//The "from" field has three possible values "pod", "namespace" and "node" and defaults to "pod" if none is specified.
 //
 //A few examples to use this config are as follows:
 //annotations:
@@ -87,10 +112,18 @@
 //	  key: label2
 //	  regex: field=(?P<value>.+)
 //	  from: pod
+//  - tag_name: l3 # extracts value of label from the pod's node with key `topology.kubernetes.io/zone` and inserts it as a tag with key `l3`
+//	  key: topology.kubernetes.io/zone
+//	  from: node
 
 // RBAC
 //
-// TODO: mention the required RBAC rules.
+// Resolving `k8s.deployment.*` attributes requires get, list and watch permissions on `replicasets` in the `apps`
+// API group, and resolving `k8s.cronjob.*` attributes requires the same permissions on `jobs` in the `batch` API group.
+// Node attributes, labels and annotations require get, list and watch permissions on `nodes`.
+// If the replicasets or jobs can't be listed, an error is logged and the other attributes are still added.
+//
+// TODO: mention the other required RBAC rules.
 //
 // Config
 //
@@ -16,14 +16,16 @@ package kube // import "github.com/open-telemetry/opentelemetry-collector-contri
 
 import (
 	"fmt"
-	"regexp"
 	"strings"
 	"sync"
 	"time"
 
 	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
 	"go.uber.org/zap"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -36,15 +38,17 @@ import (
 
 // WatchClient is the main interface provided by this package to a kubernetes cluster.
 type WatchClient struct {
-	m                 sync.RWMutex
-	deleteMut         sync.Mutex
-	logger            *zap.Logger
-	kc                kubernetes.Interface
-	informer          cache.SharedInformer
-	namespaceInformer cache.SharedInformer
-	deploymentRegex   *regexp.Regexp
-	deleteQueue       []deleteRequest
-	stopCh            chan struct{}
+	m                  sync.RWMutex
+	deleteMut          sync.Mutex
+	logger             *zap.Logger
+	kc                 kubernetes.Interface
+	informer           cache.SharedInformer
+	namespaceInformer  cache.SharedInformer
+	nodeInformer       cache.SharedInformer
+	replicasetInformer cache.SharedInformer
+	jobInformer        cache.SharedInformer
+	deleteQueue        []deleteRequest
+	stopCh             chan struct{}
 
 	// A map containing Pod related data, used to associate them with resources.
 	// Key can be either an IP address or Pod UID
@@ -57,27 +61,37 @@ type WatchClient struct {
 	// A map containing Namespace related data, used to associate them with resources.
 	// Key is namespace name
 	Namespaces map[string]*Namespace
-}
 
-// Extract deployment name from the pod name. Pod name is created using
-// format: [deployment-name]-[Random-String-For-ReplicaSet]-[Random-String-For-Pod]
-var dRegex = regexp.MustCompile(`^(.*)-[0-9a-zA-Z]*-[0-9a-zA-Z]*$`)
+	// A map containing Node related data, used to associate them with resources.
+	// Key is node name
+	Nodes map[string]*Node
+
+	// A map containing ReplicaSet related data, used to resolve the owners of pods.
+	// Key is replicaset UID
+	ReplicaSets map[string]*ReplicaSet
+
+	// A map containing Job related data, used to resolve the owners of pods.
+	// Key is job UID
+	Jobs map[string]*Job
+}
 
 // New initializes a new k8s Client.
-func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, associations []Association, exclude Excludes, newClientSet APIClientsetProvider, newInformer InformerProvider, newNamespaceInformer InformerProviderNamespace) (Client, error) {
+func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules, filters Filters, associations []Association, exclude Excludes, newClientSet APIClientsetProvider, newInformer InformerProvider, newNamespaceInformer InformerProviderNamespace, newReplicaSetInformer InformerProviderReplicaSet, newJobInformer InformerProviderJob, newNodeInformer InformerProviderNode) (Client, error) {
 	c := &WatchClient{
-		logger:          logger,
-		Rules:           rules,
-		Filters:         filters,
-		Associations:    associations,
-		Exclude:         exclude,
-		deploymentRegex: dRegex,
-		stopCh:          make(chan struct{}),
+		logger:       logger,
+		Rules:        rules,
+		Filters:      filters,
+		Associations: associations,
+		Exclude:      exclude,
+		stopCh:       make(chan struct{}),
 	}
 	go c.deleteLoop(time.Second*30, defaultPodDeleteGracePeriod)
 
 	c.Pods = map[PodIdentifier]*Pod{}
 	c.Namespaces = map[string]*Namespace{}
+	c.Nodes = map[string]*Node{}
+	c.ReplicaSets = map[string]*ReplicaSet{}
+	c.Jobs = map[string]*Job{}
 	if newClientSet == nil {
 		newClientSet = k8sconfig.MakeClient
 	}
@@ -105,16 +119,47 @@ func New(logger *zap.Logger, apiCfg k8sconfig.APIConfig, rules ExtractionRules,
 		newNamespaceInformer = newNamespaceSharedInformer
 	}
 
+	if newReplicaSetInformer == nil {
+		newReplicaSetInformer = newReplicaSetSharedInformer
+	}
+
+	if newJobInformer == nil {
+		newJobInformer = newJobSharedInformer
+	}
+
+	if newNodeInformer == nil {
+		newNodeInformer = newNodeSharedInformer
+	}
+
 	c.informer = newInformer(c.kc, c.Filters.Namespace, labelSelector, fieldSelector)
 	if c.extractNamespaceLabelsAnnotations() {
 		c.namespaceInformer = newNamespaceInformer(c.kc)
 	} else {
 		c.namespaceInformer = NewNoOpInformer(c.kc)
 	}
+
+	if needNodeAttributes(c.Rules) {
+		c.nodeInformer = newNodeInformer(c.kc, c.Filters.Node)
+	} else {
+		c.nodeInformer = NewNoOpInformer(c.kc)
+	}
+
+	if c.Rules.Deployment || c.Rules.DeploymentUID {
+		c.replicasetInformer = newReplicaSetInformer(c.kc, c.Filters.Namespace)
+	} else {
+		c.replicasetInformer = NewNoOpInformer(c.kc)
+	}
+
+	if c.Rules.CronJobName || c.Rules.CronJobUID {
+		c.jobInformer = newJobInformer(c.kc, c.Filters.Namespace)
+	} else {
+		c.jobInformer = NewNoOpInformer(c.kc)
+	}
 	return c, err
 }
 
 // Start registers pod event handlers and starts watching the kubernetes cluster for pod changes.
+// The deployments and cronjobs owning pods are resolved once the replicasets and jobs are known.
 func (c *WatchClient) Start() {
 	c.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    c.handlePodAdd,
@@ -128,6 +173,48 @@ func (c *WatchClient) Start() {
 		DeleteFunc: c.handleNamespaceDelete,
 	})
 	go c.namespaceInformer.Run(c.stopCh)
+	c.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleNodeAdd,
+		UpdateFunc: c.handleNodeUpdate,
+		DeleteFunc: c.handleNodeDelete,
+	})
+	go c.nodeInformer.Run(c.stopCh)
+	c.replicasetInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleReplicaSetAdd,
+		UpdateFunc: c.handleReplicaSetUpdate,
+		DeleteFunc: c.handleReplicaSetDelete,
+	})
+	go c.replicasetInformer.Run(c.stopCh)
+	c.jobInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    c.handleJobAdd,
+		UpdateFunc: c.handleJobUpdate,
+		DeleteFunc: c.handleJobDelete,
+	})
+	go c.jobInformer.Run(c.stopCh)
+	go c.waitForOwnersSync(ownersSyncTimeout)
+}
+
+// waitForOwnersSync waits for the replicaset and job informers to sync, logging an error
+// if they didn't sync within the timeout, e.g. when the permissions to list them are missing.
+func (c *WatchClient) waitForOwnersSync(timeout time.Duration) {
+	stopCh := make(chan struct{})
+	go func() {
+		select {
+		case <-c.stopCh:
+		case <-time.After(timeout):
+		}
+		close(stopCh)
+	}()
+	if cache.WaitForCacheSync(stopCh, c.replicasetInformer.HasSynced, c.jobInformer.HasSynced) {
+		return
+	}
+	select {
+	case <-c.stopCh:
+	default:
+		c.logger.Error("replicaset and job informers failed to sync, the k8s.deployment.* and k8s.cronjob.* attributes "+
+			"can't be resolved; check the get, list and watch permissions on replicasets and jobs",
+			zap.Duration("timeout", timeout))
+	}
 }
 
 // Stop signals the the k8s watcher/informer to stop watching for new events.
@@ -203,6 +290,98 @@ func (c *WatchClient) handleNamespaceDelete(obj interface{}) {
 	}
 }
 
+func (c *WatchClient) handleNodeAdd(obj interface{}) {
+	observability.RecordNodeAdded()
+	if node, ok := obj.(*api_v1.Node); ok {
+		c.addOrUpdateNode(node)
+	} else {
+		c.logger.Error("object received was not of type api_v1.Node", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleNodeUpdate(old, new interface{}) {
+	observability.RecordNodeUpdated()
+	if node, ok := new.(*api_v1.Node); ok {
+		c.addOrUpdateNode(node)
+	} else {
+		c.logger.Error("object received was not of type api_v1.Node", zap.Any("received", new))
+	}
+}
+
+func (c *WatchClient) handleNodeDelete(obj interface{}) {
+	observability.RecordNodeDeleted()
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if node, ok := obj.(*api_v1.Node); ok {
+		c.m.Lock()
+		// Pods running on a deleted node are deleted as well, so there is no need
+		// to keep the node around for a grace period.
+		delete(c.Nodes, node.Name)
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type api_v1.Node", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleReplicaSetAdd(obj interface{}) {
+	if replicaset, ok := obj.(*apps_v1.ReplicaSet); ok {
+		c.addOrUpdateReplicaSet(replicaset)
+	} else {
+		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleReplicaSetUpdate(old, new interface{}) {
+	if replicaset, ok := new.(*apps_v1.ReplicaSet); ok {
+		c.addOrUpdateReplicaSet(replicaset)
+	} else {
+		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", new))
+	}
+}
+
+func (c *WatchClient) handleReplicaSetDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if replicaset, ok := obj.(*apps_v1.ReplicaSet); ok {
+		c.m.Lock()
+		delete(c.ReplicaSets, string(replicaset.UID))
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type apps_v1.ReplicaSet", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleJobAdd(obj interface{}) {
+	if job, ok := obj.(*batch_v1.Job); ok {
+		c.addOrUpdateJob(job)
+	} else {
+		c.logger.Error("object received was not of type batch_v1.Job", zap.Any("received", obj))
+	}
+}
+
+func (c *WatchClient) handleJobUpdate(old, new interface{}) {
+	if job, ok := new.(*batch_v1.Job); ok {
+		c.addOrUpdateJob(job)
+	} else {
+		c.logger.Error("object received was not of type batch_v1.Job", zap.Any("received", new))
+	}
+}
+
+func (c *WatchClient) handleJobDelete(obj interface{}) {
+	if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = tombstone.Obj
+	}
+	if job, ok := obj.(*batch_v1.Job); ok {
+		c.m.Lock()
+		delete(c.Jobs, string(job.UID))
+		c.m.Unlock()
+	} else {
+		c.logger.Error("object received was not of type batch_v1.Job", zap.Any("received", obj))
+	}
+}
+
 func (c *WatchClient) deleteLoop(interval time.Duration, gracePeriod time.Duration) {
 	// This loop runs after N seconds and deletes pods from cache.
 	// It iterates over the delete queue and deletes all that aren't
@@ -252,12 +431,43 @@ func (c *WatchClient) GetPod(identifier PodIdentifier) (*Pod, bool) {
 		if pod.Ignore {
 			return nil, false
 		}
+		if len(pod.pendingOwners) > 0 {
+			pod = c.resolvePendingOwners(identifier, pod)
+		}
 		return pod, ok
 	}
 	observability.RecordIPLookupMiss()
 	return nil, false
 }
 
+// resolvePendingOwners adds the attributes of the pod owners that couldn't be resolved when the pod was added,
+// because the replicaset or job owning the pod wasn't known yet. Pods are never modified once stored, so the
+// resolved pod is a copy replacing the stored one.
+func (c *WatchClient) resolvePendingOwners(identifier PodIdentifier, pod *Pod) *Pod {
+	tags := map[string]string{}
+	pending := c.extractPodOwnerAttributes(pod.pendingOwners, tags)
+	if len(pending) == len(pod.pendingOwners) {
+		return pod
+	}
+
+	resolved := *pod
+	resolved.Attributes = make(map[string]string, len(pod.Attributes)+len(tags))
+	for k, v := range pod.Attributes {
+		resolved.Attributes[k] = v
+	}
+	for k, v := range tags {
+		resolved.Attributes[k] = v
+	}
+	resolved.pendingOwners = pending
+
+	c.m.Lock()
+	if c.Pods[identifier] == pod {
+		c.Pods[identifier] = &resolved
+	}
+	c.m.Unlock()
+	return &resolved
+}
+
 // GetNamespace takes a namespace and returns the namespace object the namespace is associated with.
 func (c *WatchClient) GetNamespace(namespace string) (*Namespace, bool) {
 	c.m.RLock()
@@ -269,7 +479,20 @@ func (c *WatchClient) GetNamespace(namespace string) (*Namespace, bool) {
 	return nil, false
 }
 
-func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
+// GetNode takes a node name and returns the node object the name is associated with.
+func (c *WatchClient) GetNode(nodeName string) (*Node, bool) {
+	c.m.RLock()
+	node, ok := c.Nodes[nodeName]
+	c.m.RUnlock()
+	if ok {
+		return node, ok
+	}
+	return nil, false
+}
+
+// extractPodAttributes returns the attributes of the pod, along with the owners of the pod
+// whose own owners can't be resolved yet.
+func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) (map[string]string, []meta_v1.OwnerReference) {
 	tags := map[string]string{}
 	if c.Rules.PodName {
 		tags[conventions.AttributeK8SPodName] = pod.Name
@@ -291,13 +514,7 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 		tags[conventions.AttributeK8SPodUID] = string(uid)
 	}
 
-	if c.Rules.Deployment {
-		// format: [deployment-name]-[Random-String-For-ReplicaSet]-[Random-String-For-Pod]
-		parts := c.deploymentRegex.FindStringSubmatch(pod.Name)
-		if len(parts) == 2 {
-			tags[conventions.AttributeK8SDeploymentName] = parts[1]
-		}
-	}
+	pendingOwners := c.extractPodOwnerAttributes(pod.OwnerReferences, tags)
 
 	if c.Rules.Node {
 		tags[tagNodeName] = pod.Spec.NodeName
@@ -341,7 +558,93 @@ func (c *WatchClient) extractPodAttributes(pod *api_v1.Pod) map[string]string {
 			}
 		}
 	}
-	return tags
+	return tags, pendingOwners
+}
+
+// extractPodOwnerAttributes adds the attributes of the objects owning the pod. Deployments and
+// cronjobs are resolved through the replicasets and jobs owning the pod; the owners whose replicaset
+// or job isn't known yet are returned, to be resolved later.
+func (c *WatchClient) extractPodOwnerAttributes(ownerRefs []meta_v1.OwnerReference, tags map[string]string) []meta_v1.OwnerReference {
+	var pending []meta_v1.OwnerReference
+	for _, ref := range ownerRefs {
+		switch ref.Kind {
+		case "ReplicaSet":
+			if c.Rules.ReplicaSetName {
+				tags[conventions.AttributeK8SReplicaSetName] = ref.Name
+			}
+			if c.Rules.ReplicaSetUID {
+				tags[conventions.AttributeK8SReplicaSetUID] = string(ref.UID)
+			}
+			if !c.Rules.Deployment && !c.Rules.DeploymentUID {
+				continue
+			}
+			replicaset, ok := c.getReplicaSet(string(ref.UID))
+			if !ok {
+				pending = append(pending, ref)
+				continue
+			}
+			if replicaset.Deployment.Name != "" {
+				if c.Rules.Deployment {
+					tags[conventions.AttributeK8SDeploymentName] = replicaset.Deployment.Name
+				}
+				if c.Rules.DeploymentUID {
+					tags[conventions.AttributeK8SDeploymentUID] = replicaset.Deployment.UID
+				}
+			}
+		case "StatefulSet":
+			if c.Rules.StatefulSetName {
+				tags[conventions.AttributeK8SStatefulSetName] = ref.Name
+			}
+			if c.Rules.StatefulSetUID {
+				tags[conventions.AttributeK8SStatefulSetUID] = string(ref.UID)
+			}
+		case "DaemonSet":
+			if c.Rules.DaemonSetName {
+				tags[conventions.AttributeK8SDaemonSetName] = ref.Name
+			}
+			if c.Rules.DaemonSetUID {
+				tags[conventions.AttributeK8SDaemonSetUID] = string(ref.UID)
+			}
+		case "Job":
+			if c.Rules.JobName {
+				tags[conventions.AttributeK8SJobName] = ref.Name
+			}
+			if c.Rules.JobUID {
+				tags[conventions.AttributeK8SJobUID] = string(ref.UID)
+			}
+			if !c.Rules.CronJobName && !c.Rules.CronJobUID {
+				continue
+			}
+			job, ok := c.getJob(string(ref.UID))
+			if !ok {
+				pending = append(pending, ref)
+				continue
+			}
+			if job.CronJob.Name != "" {
+				if c.Rules.CronJobName {
+					tags[conventions.AttributeK8SCronJobName] = job.CronJob.Name
+				}
+				if c.Rules.CronJobUID {
+					tags[conventions.AttributeK8SCronJobUID] = job.CronJob.UID
+				}
+			}
+		}
+	}
+	return pending
+}
+
+func (c *WatchClient) getReplicaSet(uid string) (*ReplicaSet, bool) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	replicaset, ok := c.ReplicaSets[uid]
+	return replicaset, ok
+}
+
+func (c *WatchClient) getJob(uid string) (*Job, bool) {
+	c.m.RLock()
+	defer c.m.RUnlock()
+	job, ok := c.Jobs[uid]
+	return job, ok
 }
 
 func (c *WatchClient) extractPodContainersAttributes(pod *api_v1.Pod) map[string]*Container {
@@ -422,6 +725,68 @@ func (c *WatchClient) extractNamespaceAttributes(namespace *api_v1.Namespace) ma
 	return tags
 }
 
+func (c *WatchClient) extractNodeAttributes(node *api_v1.Node) map[string]string {
+	tags := map[string]string{}
+
+	if c.Rules.NodeUID {
+		tags[conventions.AttributeK8SNodeUID] = string(node.UID)
+	}
+
+	if c.Rules.Zone {
+		if zone := nodeLabel(node, nodeLabelZone, nodeLabelZoneBeta); zone != "" {
+			tags[conventions.AttributeCloudAvailabilityZone] = zone
+		}
+	}
+
+	if c.Rules.Region {
+		if region := nodeLabel(node, nodeLabelRegion, nodeLabelRegionBeta); region != "" {
+			tags[conventions.AttributeCloudRegion] = region
+		}
+	}
+
+	for _, r := range c.Rules.Labels {
+		if r.From == MetadataFromNode {
+			if r.KeyRegex != nil {
+				for k, v := range node.Labels {
+					if r.KeyRegex.MatchString(k) && v != "" {
+						name := fmt.Sprintf("k8s.node.labels.%s", k)
+						tags[name] = v
+					}
+				}
+			} else if v, ok := node.Labels[r.Key]; ok {
+				tags[r.Name] = c.extractField(v, r)
+			}
+		}
+	}
+
+	for _, r := range c.Rules.Annotations {
+		if r.From == MetadataFromNode {
+			if r.KeyRegex != nil {
+				for k, v := range node.Annotations {
+					if r.KeyRegex.MatchString(k) && v != "" {
+						name := fmt.Sprintf("k8s.node.annotations.%s", k)
+						tags[name] = v
+					}
+				}
+			} else if v, ok := node.Annotations[r.Key]; ok {
+				tags[r.Name] = c.extractField(v, r)
+			}
+		}
+	}
+
+	return tags
+}
+
+// nodeLabel returns the value of the first of the given labels set on the node.
+func nodeLabel(node *api_v1.Node, keys ...string) string {
+	for _, k := range keys {
+		if v := node.Labels[k]; v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
 func (c *WatchClient) extractField(v string, r FieldExtractionRule) string {
 	// Check if a subset of the field should be extracted with a regular expression
 	// instead of the whole field.
@@ -443,12 +808,13 @@ func (c *WatchClient) addOrUpdatePod(pod *api_v1.Pod) {
 		Address:   pod.Status.PodIP,
 		PodUID:    string(pod.UID),
 		StartTime: pod.Status.StartTime,
+		NodeName:  pod.Spec.NodeName,
 	}
 
 	if c.shouldIgnorePod(pod) {
 		newPod.Ignore = true
 	} else {
-		newPod.Attributes = c.extractPodAttributes(pod)
+		newPod.Attributes, newPod.pendingOwners = c.extractPodAttributes(pod)
 		if needContainerAttributes(c.Rules) {
 			newPod.Containers = c.extractPodContainersAttributes(pod)
 		}
@@ -475,17 +841,13 @@ func (c *WatchClient) addOrUpdatePod(pod *api_v1.Pod) {
 }
 
 func (c *WatchClient) forgetPod(pod *api_v1.Pod) {
-	c.m.RLock()
 	p, ok := c.GetPod(PodIdentifier(pod.Status.PodIP))
-	c.m.RUnlock()
 
 	if ok && p.Name == pod.Name {
 		c.appendDeleteQueue(PodIdentifier(pod.Status.PodIP), pod.Name)
 	}
 
-	c.m.RLock()
 	p, ok = c.GetPod(PodIdentifier(pod.UID))
-	c.m.RUnlock()
 
 	if ok && p.Name == pod.Name {
 		c.appendDeleteQueue(PodIdentifier(pod.UID), pod.Name)
@@ -588,6 +950,85 @@ func (c *WatchClient) extractNamespaceLabelsAnnotations() bool {
 	return false
 }
 
+func (c *WatchClient) addOrUpdateNode(node *api_v1.Node) {
+	newNode := &Node{
+		Name:      node.Name,
+		NodeUID:   string(node.UID),
+		StartTime: node.GetCreationTimestamp(),
+	}
+	newNode.Attributes = c.extractNodeAttributes(node)
+
+	c.m.Lock()
+	if node.Name != "" {
+		c.Nodes[node.Name] = newNode
+	}
+	c.m.Unlock()
+}
+
+// needNodeAttributes returns true if any attribute has to be extracted from nodes.
+func needNodeAttributes(rules ExtractionRules) bool {
+	if rules.NodeUID || rules.Zone || rules.Region {
+		return true
+	}
+
+	for _, r := range rules.Labels {
+		if r.From == MetadataFromNode {
+			return true
+		}
+	}
+
+	for _, r := range rules.Annotations {
+		if r.From == MetadataFromNode {
+			return true
+		}
+	}
+
+	return false
+}
+
 func needContainerAttributes(rules ExtractionRules) bool {
 	return rules.ContainerImageName || rules.ContainerImageTag || rules.ContainerID
 }
+
+func (c *WatchClient) addOrUpdateReplicaSet(replicaset *apps_v1.ReplicaSet) {
+	newReplicaSet := &ReplicaSet{
+		Name:      replicaset.Name,
+		Namespace: replicaset.Namespace,
+		UID:       string(replicaset.UID),
+	}
+	if ref, ok := ownerOfKind(replicaset.OwnerReferences, "Deployment"); ok {
+		newReplicaSet.Deployment = Deployment{Name: ref.Name, UID: string(ref.UID)}
+	}
+
+	c.m.Lock()
+	if replicaset.UID != "" {
+		c.ReplicaSets[string(replicaset.UID)] = newReplicaSet
+	}
+	c.m.Unlock()
+}
+
+func (c *WatchClient) addOrUpdateJob(job *batch_v1.Job) {
+	newJob := &Job{
+		Name:      job.Name,
+		Namespace: job.Namespace,
+		UID:       string(job.UID),
+	}
+	if ref, ok := ownerOfKind(job.OwnerReferences, "CronJob"); ok {
+		newJob.CronJob = CronJob{Name: ref.Name, UID: string(ref.UID)}
+	}
+
+	c.m.Lock()
+	if job.UID != "" {
+		c.Jobs[string(job.UID)] = newJob
+	}
+	c.m.Unlock()
+}
+
+func ownerOfKind(refs []meta_v1.OwnerReference, kind string) (meta_v1.OwnerReference, bool) {
+	for _, ref := range refs {
+		if ref.Kind == kind {
+			return ref, true
+		}
+	}
+	return meta_v1.OwnerReference{}, false
+}
@@ -26,6 +26,8 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"go.uber.org/zap/zaptest/observer"
+	apps_v1 "k8s.io/api/apps/v1"
+	batch_v1 "k8s.io/api/batch/v1"
 	api_v1 "k8s.io/api/core/v1"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -33,6 +35,7 @@ import (
 	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/k8sconfig"
 )
@@ -112,12 +115,12 @@ func namespaceAddAndUpdateTest(t *testing.T, c *WatchClient, handler func(obj in
 }
 
 func TestDefaultClientset(t *testing.T) {
-	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil)
+	c, err := New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, nil, nil, nil, nil, nil, nil)
 	assert.Error(t, err)
 	assert.Equal(t, "invalid authType for kubernetes: ", err.Error())
 	assert.Nil(t, c)
 
-	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil)
+	c, err = New(zap.NewNop(), k8sconfig.APIConfig{}, ExtractionRules{}, Filters{}, []Association{}, Excludes{}, newFakeAPIClientset, nil, nil, nil, nil, nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, c)
 }
@@ -133,6 +136,9 @@ func TestBadFilters(t *testing.T) {
 		newFakeAPIClientset,
 		NewFakeInformer,
 		NewFakeNamespaceInformer,
+		NewFakeReplicaSetInformer,
+		NewFakeJobInformer,
+		NewFakeNodeInformer,
 	)
 	assert.Error(t, err)
 	assert.Nil(t, c)
@@ -169,7 +175,7 @@ func TestConstructorErrors(t *testing.T) {
 			gotAPIConfig = c
 			return nil, fmt.Errorf("error creating k8s client")
 		}
-		c, err := New(zap.NewNop(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer)
+		c, err := New(zap.NewNop(), apiCfg, er, ff, []Association{}, Excludes{}, clientProvider, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, NewFakeJobInformer, NewFakeNodeInformer)
 		assert.Nil(t, c)
 		assert.Error(t, err)
 		assert.Equal(t, err.Error(), "error creating k8s client")
@@ -400,9 +406,237 @@ func TestHandlerWrongType(t *testing.T) {
 	}
 }
 
+func TestPodOwners(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{
+		Deployment:      true,
+		DeploymentUID:   true,
+		ReplicaSetName:  true,
+		StatefulSetName: true,
+		StatefulSetUID:  true,
+		DaemonSetName:   true,
+		DaemonSetUID:    true,
+		JobName:         true,
+		JobUID:          true,
+		CronJobName:     true,
+		CronJobUID:      true,
+	}, Filters{})
+
+	c.handleJobAdd(&batch_v1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "report-27304560",
+			Namespace: "ns1",
+			UID:       "job-uid",
+			OwnerReferences: []meta_v1.OwnerReference{{
+				Kind: "CronJob",
+				Name: "report",
+				UID:  "cronjob-uid",
+			}},
+		},
+	})
+	// A replicaset not owned by a deployment, e.g. created by hand.
+	c.handleReplicaSetAdd(&apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "standalone",
+			Namespace: "ns1",
+			UID:       "replicaset-uid",
+		},
+	})
+
+	testCases := []struct {
+		name       string
+		owner      meta_v1.OwnerReference
+		attributes map[string]string
+	}{{
+		name:  "statefulset",
+		owner: meta_v1.OwnerReference{Kind: "StatefulSet", Name: "db", UID: "statefulset-uid"},
+		attributes: map[string]string{
+			"k8s.statefulset.name": "db",
+			"k8s.statefulset.uid":  "statefulset-uid",
+		},
+	}, {
+		name:  "daemonset",
+		owner: meta_v1.OwnerReference{Kind: "DaemonSet", Name: "agent", UID: "daemonset-uid"},
+		attributes: map[string]string{
+			"k8s.daemonset.name": "agent",
+			"k8s.daemonset.uid":  "daemonset-uid",
+		},
+	}, {
+		name:  "cronjob",
+		owner: meta_v1.OwnerReference{Kind: "Job", Name: "report-27304560", UID: "job-uid"},
+		attributes: map[string]string{
+			"k8s.job.name":     "report-27304560",
+			"k8s.job.uid":      "job-uid",
+			"k8s.cronjob.name": "report",
+			"k8s.cronjob.uid":  "cronjob-uid",
+		},
+	}, {
+		name:  "unknown-job",
+		owner: meta_v1.OwnerReference{Kind: "Job", Name: "migration", UID: "unknown-job-uid"},
+		attributes: map[string]string{
+			"k8s.job.name": "migration",
+			"k8s.job.uid":  "unknown-job-uid",
+		},
+	}, {
+		name:  "replicaset-without-deployment",
+		owner: meta_v1.OwnerReference{Kind: "ReplicaSet", Name: "standalone", UID: "replicaset-uid"},
+		attributes: map[string]string{
+			"k8s.replicaset.name": "standalone",
+		},
+	}}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := &api_v1.Pod{
+				ObjectMeta: meta_v1.ObjectMeta{
+					// The pod name does not follow the naming of deployment pods.
+					Name:            "custom-name",
+					Namespace:       "ns1",
+					OwnerReferences: []meta_v1.OwnerReference{tc.owner},
+				},
+				Status: api_v1.PodStatus{
+					PodIP: "1.1.1.1",
+				},
+			}
+			c.handlePodAdd(pod)
+			p, ok := c.GetPod(PodIdentifier(pod.Status.PodIP))
+			require.True(t, ok)
+			assert.Equal(t, tc.attributes, p.Attributes)
+		})
+	}
+}
+
+func TestPodOwnersResolvedLater(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{
+		Deployment:     true,
+		ReplicaSetName: true,
+		JobName:        true,
+		CronJobName:    true,
+	}, Filters{})
+
+	// The pods are added before the replicaset and job owning them are known.
+	c.handlePodAdd(&api_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            "web-5d8f7c9b4-x2x7z",
+			UID:             "pod-uid",
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "ReplicaSet", Name: "web-5d8f7c9b4", UID: "replicaset-uid"}},
+		},
+		Status: api_v1.PodStatus{PodIP: "1.1.1.1"},
+	})
+	c.handlePodAdd(&api_v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            "report-27304560-abcde",
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "Job", Name: "report-27304560", UID: "job-uid"}},
+		},
+		Status: api_v1.PodStatus{PodIP: "2.2.2.2"},
+	})
+
+	p, ok := c.GetPod("1.1.1.1")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"k8s.replicaset.name": "web-5d8f7c9b4"}, p.Attributes)
+	p, ok = c.GetPod("2.2.2.2")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{"k8s.job.name": "report-27304560"}, p.Attributes)
+
+	c.handleReplicaSetAdd(&apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            "web-5d8f7c9b4",
+			UID:             "replicaset-uid",
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "Deployment", Name: "web", UID: "deployment-uid"}},
+		},
+	})
+	c.handleJobAdd(&batch_v1.Job{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            "report-27304560",
+			UID:             "job-uid",
+			OwnerReferences: []meta_v1.OwnerReference{{Kind: "CronJob", Name: "report", UID: "cronjob-uid"}},
+		},
+	})
+
+	for _, id := range []PodIdentifier{"1.1.1.1", "pod-uid"} {
+		p, ok = c.GetPod(id)
+		require.True(t, ok)
+		assert.Equal(t, map[string]string{
+			"k8s.replicaset.name": "web-5d8f7c9b4",
+			"k8s.deployment.name": "web",
+		}, p.Attributes)
+		assert.Empty(t, c.Pods[id].pendingOwners, "the resolved pod should replace the stored one")
+	}
+	p, ok = c.GetPod("2.2.2.2")
+	require.True(t, ok)
+	assert.Equal(t, map[string]string{
+		"k8s.job.name":     "report-27304560",
+		"k8s.cronjob.name": "report",
+	}, p.Attributes)
+}
+
+// unsyncedInformer is an informer which never syncs, as when listing its objects is forbidden.
+type unsyncedInformer struct {
+	*FakeInformer
+}
+
+func (f *unsyncedInformer) HasSynced() bool {
+	return false
+}
+
+func TestWaitForOwnersSync(t *testing.T) {
+	c, logs := newTestClientWithRulesAndFilters(t, ExtractionRules{Deployment: true}, Filters{})
+	c.waitForOwnersSync(time.Second)
+	assert.Equal(t, 0, logs.Len())
+
+	c.replicasetInformer = &unsyncedInformer{FakeInformer: &FakeInformer{FakeController: &FakeController{}}}
+	c.waitForOwnersSync(100 * time.Millisecond)
+	require.Equal(t, 1, logs.Len())
+	assert.Contains(t, logs.All()[0].Message, "replicaset and job informers failed to sync")
+
+	// No error is logged when the client is stopped while waiting.
+	c.Stop()
+	c.waitForOwnersSync(time.Minute)
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestReplicaSetAndJobHandlers(t *testing.T) {
+	c, logs := newTestClientWithRulesAndFilters(t, ExtractionRules{Deployment: true, CronJobName: true}, Filters{})
+
+	replicaset := &apps_v1.ReplicaSet{}
+	replicaset.Name = "rs"
+	replicaset.UID = "replicaset-uid"
+	c.handleReplicaSetAdd(replicaset)
+	require.Len(t, c.ReplicaSets, 1)
+	replicaset.OwnerReferences = []meta_v1.OwnerReference{{Kind: "Deployment", Name: "deployment", UID: "deployment-uid"}}
+	c.handleReplicaSetUpdate(nil, replicaset)
+	assert.Equal(t, &ReplicaSet{Name: "rs", UID: "replicaset-uid", Deployment: Deployment{Name: "deployment", UID: "deployment-uid"}}, c.ReplicaSets["replicaset-uid"])
+	c.handleReplicaSetDelete(cache.DeletedFinalStateUnknown{Obj: replicaset})
+	assert.Len(t, c.ReplicaSets, 0)
+
+	job := &batch_v1.Job{}
+	job.Name = "job"
+	job.UID = "job-uid"
+	c.handleJobAdd(job)
+	require.Len(t, c.Jobs, 1)
+	c.handleJobDelete(job)
+	assert.Len(t, c.Jobs, 0)
+
+	c.handleReplicaSetAdd(1)
+	c.handleJobAdd(1)
+	assert.Equal(t, 2, logs.Len())
+}
+
 func TestExtractionRules(t *testing.T) {
 	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
 
+	replicaset := &apps_v1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:      "auth-service-66f97c7b5f",
+			Namespace: "ns1",
+			UID:       "207ea729-c779-401d-8347-008ecbc137e3",
+			OwnerReferences: []meta_v1.OwnerReference{{
+				Kind: "Deployment",
+				Name: "auth-service",
+				UID:  "ffff-gggg-hhhh",
+			}},
+		},
+	}
+	c.handleReplicaSetAdd(replicaset)
+
 	pod := &api_v1.Pod{
 		ObjectMeta: meta_v1.ObjectMeta{
 			Name:              "auth-service-abc12-xyz3",
@@ -410,6 +644,11 @@ func TestExtractionRules(t *testing.T) {
 			Namespace:         "ns1",
 			CreationTimestamp: meta_v1.Now(),
 			ClusterName:       "cluster1",
+			OwnerReferences: []meta_v1.OwnerReference{{
+				Kind: "ReplicaSet",
+				Name: "auth-service-66f97c7b5f",
+				UID:  "207ea729-c779-401d-8347-008ecbc137e3",
+			}},
 			Labels: map[string]string{
 				"label1": "lv1",
 				"label2": "k1=v1 k5=v5 extra!",
@@ -442,6 +681,18 @@ func TestExtractionRules(t *testing.T) {
 		attributes: map[string]string{
 			"k8s.deployment.name": "auth-service",
 		},
+	}, {
+		name: "replicaset",
+		rules: ExtractionRules{
+			ReplicaSetName: true,
+			ReplicaSetUID:  true,
+			DeploymentUID:  true,
+		},
+		attributes: map[string]string{
+			"k8s.replicaset.name": "auth-service-66f97c7b5f",
+			"k8s.replicaset.uid":  "207ea729-c779-401d-8347-008ecbc137e3",
+			"k8s.deployment.uid":  "ffff-gggg-hhhh",
+		},
 	}, {
 		name: "metadata",
 		rules: ExtractionRules{
@@ -648,6 +899,132 @@ func TestNamespaceExtractionRules(t *testing.T) {
 	}
 }
 
+func TestNodeExtractionRules(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{}, Filters{})
+
+	node := &api_v1.Node{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              "node-a",
+			UID:               "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+			CreationTimestamp: meta_v1.Now(),
+			Labels: map[string]string{
+				"label1":                "lv1",
+				nodeLabelZone:           "us-east-1a",
+				nodeLabelRegionBeta:     "us-east-1",
+				"node.kubernetes.io/os": "linux",
+			},
+			Annotations: map[string]string{
+				"annotation1": "av1",
+			},
+		},
+	}
+
+	testCases := []struct {
+		name       string
+		rules      ExtractionRules
+		attributes map[string]string
+	}{{
+		name:       "no-rules",
+		rules:      ExtractionRules{},
+		attributes: nil,
+	}, {
+		name: "metadata",
+		rules: ExtractionRules{
+			NodeUID: true,
+			Zone:    true,
+			Region:  true,
+		},
+		attributes: map[string]string{
+			"k8s.node.uid":            "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+			"cloud.availability_zone": "us-east-1a",
+			"cloud.region":            "us-east-1",
+		},
+	}, {
+		name: "labels",
+		rules: ExtractionRules{
+			Annotations: []FieldExtractionRule{{
+				Name: "a1",
+				Key:  "annotation1",
+				From: MetadataFromNode,
+			},
+			},
+			Labels: []FieldExtractionRule{{
+				Name: "l1",
+				Key:  "label1",
+				From: MetadataFromNode,
+			}, {
+				Name: "l2",
+				Key:  "label1",
+				From: MetadataFromNamespace,
+			},
+			},
+		},
+		attributes: map[string]string{
+			"l1": "lv1",
+			"a1": "av1",
+		},
+	}, {
+		name: "all-labels",
+		rules: ExtractionRules{
+			Labels: []FieldExtractionRule{{
+				KeyRegex: regexp.MustCompile("^node.kubernetes.io/"),
+				From:     MetadataFromNode,
+			},
+			},
+		},
+		attributes: map[string]string{
+			"k8s.node.labels.node.kubernetes.io/os": "linux",
+		},
+	},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c.Rules = tc.rules
+			c.handleNodeAdd(node)
+			n, ok := c.GetNode(node.Name)
+			require.True(t, ok)
+
+			assert.Equal(t, len(tc.attributes), len(n.Attributes))
+			for k, v := range tc.attributes {
+				got, ok := n.Attributes[k]
+				assert.True(t, ok)
+				assert.Equal(t, v, got)
+			}
+		})
+	}
+}
+
+func TestNodeHandlers(t *testing.T) {
+	c, logs := newTestClientWithRulesAndFilters(t, ExtractionRules{NodeUID: true}, Filters{})
+
+	node := &api_v1.Node{}
+	node.Name = "node-a"
+	c.handleNodeAdd(node)
+	require.Len(t, c.Nodes, 1)
+	node.UID = "node-uid"
+	c.handleNodeUpdate(nil, node)
+	got, ok := c.GetNode("node-a")
+	require.True(t, ok)
+	assert.Equal(t, "node-uid", got.NodeUID)
+	assert.Equal(t, "node-uid", got.Attributes["k8s.node.uid"])
+	c.handleNodeDelete(cache.DeletedFinalStateUnknown{Obj: node})
+	assert.Len(t, c.Nodes, 0)
+	_, ok = c.GetNode("node-a")
+	assert.False(t, ok)
+
+	c.handleNodeAdd(1)
+	c.handleNodeDelete(1)
+	assert.Equal(t, 2, logs.Len())
+}
+
+func TestNodeInformerOnlyWhenNeeded(t *testing.T) {
+	c, _ := newTestClientWithRulesAndFilters(t, ExtractionRules{Node: true}, Filters{})
+	assert.IsType(t, &NoOpInformer{}, c.nodeInformer)
+
+	c, _ = newTestClientWithRulesAndFilters(t, ExtractionRules{Zone: true}, Filters{})
+	assert.IsType(t, &FakeInformer{}, c.nodeInformer)
+}
+
 func TestFilters(t *testing.T) {
 	testCases := []struct {
 		name    string
@@ -1108,6 +1485,18 @@ func TestExtractNamespaceLabelsAnnotations(t *testing.T) {
 	}
 }
 
+func TestNeedNodeAttributes(t *testing.T) {
+	assert.False(t, needNodeAttributes(ExtractionRules{Node: true}))
+	assert.True(t, needNodeAttributes(ExtractionRules{NodeUID: true}))
+	assert.True(t, needNodeAttributes(ExtractionRules{Region: true}))
+	assert.False(t, needNodeAttributes(ExtractionRules{
+		Labels: []FieldExtractionRule{{Key: "l1", From: MetadataFromNamespace}},
+	}))
+	assert.True(t, needNodeAttributes(ExtractionRules{
+		Annotations: []FieldExtractionRule{{Key: "a1", From: MetadataFromNode}},
+	}))
+}
+
 func newTestClientWithRulesAndFilters(t *testing.T, e ExtractionRules, f Filters) (*WatchClient, *observer.ObservedLogs) {
 	observedLogger, logs := observer.New(zapcore.WarnLevel)
 	logger := zap.New(observedLogger)
@@ -1117,7 +1506,7 @@ func newTestClientWithRulesAndFilters(t *testing.T, e ExtractionRules, f Filters
 			{Name: regexp.MustCompile(`jaeger-collector`)},
 		},
 	}
-	c, err := New(logger, k8sconfig.APIConfig{}, e, f, []Association{}, exclude, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer)
+	c, err := New(logger, k8sconfig.APIConfig{}, e, f, []Association{}, exclude, newFakeAPIClientset, NewFakeInformer, NewFakeNamespaceInformer, NewFakeReplicaSetInformer, NewFakeJobInformer, NewFakeNodeInformer)
 	require.NoError(t, err)
 	return c.(*WatchClient), logs
 }
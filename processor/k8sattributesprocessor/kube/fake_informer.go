@@ -59,6 +59,38 @@ func (f *FakeInformer) GetController() cache.Controller {
 	return f.FakeController
 }
 
+// NewFakeReplicaSetInformer returns a FakeInformer satisfying the InformerProviderReplicaSet type.
+func NewFakeReplicaSetInformer(
+	_ kubernetes.Interface,
+	namespace string,
+) cache.SharedInformer {
+	return &FakeInformer{
+		FakeController: &FakeController{},
+		namespace:      namespace,
+	}
+}
+
+// NewFakeJobInformer returns a FakeInformer satisfying the InformerProviderJob type.
+func NewFakeJobInformer(
+	_ kubernetes.Interface,
+	namespace string,
+) cache.SharedInformer {
+	return &FakeInformer{
+		FakeController: &FakeController{},
+		namespace:      namespace,
+	}
+}
+
+// NewFakeNodeInformer returns a FakeInformer satisfying the InformerProviderNode type.
+func NewFakeNodeInformer(
+	_ kubernetes.Interface,
+	_ string,
+) cache.SharedInformer {
+	return &FakeInformer{
+		FakeController: &FakeController{},
+	}
+}
+
 type FakeNamespaceInformer struct {
 	*FakeController
 }
@@ -28,6 +28,7 @@ import (
 
 const (
 	podNodeField            = "spec.nodeName"
+	nodeNameField           = "metadata.name"
 	ignoreAnnotation string = "opentelemetry.io/k8s-processor/ignore"
 	tagNodeName             = "k8s.node.name"
 	tagStartTime            = "k8s.pod.start_time"
@@ -35,6 +36,14 @@ const (
 	MetadataFromPod = "pod"
 	// MetadataFromNamespace is used to specify to extract metadata/labels/annotations from namespace
 	MetadataFromNamespace = "namespace"
+	// MetadataFromNode is used to specify to extract metadata/labels/annotations from node
+	MetadataFromNode = "node"
+	// Well-known node labels holding the topology of the node. The beta labels are still
+	// set by older clusters and are used when the GA labels are missing.
+	nodeLabelZone       = "topology.kubernetes.io/zone"
+	nodeLabelRegion     = "topology.kubernetes.io/region"
+	nodeLabelZoneBeta   = "failure-domain.beta.kubernetes.io/zone"
+	nodeLabelRegionBeta = "failure-domain.beta.kubernetes.io/region"
 )
 
 // PodIdentifier is a custom type to represent IP Address or Pod UID
@@ -44,18 +53,20 @@ var (
 	// TODO: move these to config with default values
 	defaultPodDeleteGracePeriod = time.Second * 120
 	watchSyncPeriod             = time.Minute * 5
+	ownersSyncTimeout           = time.Minute
 )
 
 // Client defines the main interface that allows querying pods by metadata.
 type Client interface {
 	GetPod(PodIdentifier) (*Pod, bool)
 	GetNamespace(string) (*Namespace, bool)
+	GetNode(string) (*Node, bool)
 	Start()
 	Stop()
 }
 
 // ClientProvider defines a func type that returns a new Client.
-type ClientProvider func(*zap.Logger, k8sconfig.APIConfig, ExtractionRules, Filters, []Association, Excludes, APIClientsetProvider, InformerProvider, InformerProviderNamespace) (Client, error)
+type ClientProvider func(*zap.Logger, k8sconfig.APIConfig, ExtractionRules, Filters, []Association, Excludes, APIClientsetProvider, InformerProvider, InformerProviderNamespace, InformerProviderReplicaSet, InformerProviderJob, InformerProviderNode) (Client, error)
 
 // APIClientsetProvider defines a func type that initializes and return a new kubernetes
 // Clientset object.
@@ -70,11 +81,16 @@ type Pod struct {
 	StartTime  *metav1.Time
 	Ignore     bool
 	Namespace  string
+	NodeName   string
 
 	// Containers is a map of container name to Container struct.
 	Containers map[string]*Container
 
 	DeletedAt time.Time
+
+	// pendingOwners holds the replicasets and jobs owning the pod which weren't known when the pod was added,
+	// so the deployments and cronjobs owning them are still to be resolved.
+	pendingOwners []metav1.OwnerReference
 }
 
 // Container stores resource attributes for a specific container defined by k8s pod spec.
@@ -100,6 +116,42 @@ type Namespace struct {
 	DeletedAt    time.Time
 }
 
+// Node represents a kubernetes node.
+type Node struct {
+	Name       string
+	NodeUID    string
+	Attributes map[string]string
+	StartTime  metav1.Time
+}
+
+// ReplicaSet represents a kubernetes replicaset, along with the deployment owning it.
+type ReplicaSet struct {
+	Name       string
+	Namespace  string
+	UID        string
+	Deployment Deployment
+}
+
+// Deployment represents a kubernetes deployment.
+type Deployment struct {
+	Name string
+	UID  string
+}
+
+// Job represents a kubernetes job, along with the cronjob owning it.
+type Job struct {
+	Name      string
+	Namespace string
+	UID       string
+	CronJob   CronJob
+}
+
+// CronJob represents a kubernetes cronjob.
+type CronJob struct {
+	Name string
+	UID  string
+}
+
 type deleteRequest struct {
 	// id is identifier (IP address or Pod UID) of pod to remove from pods map
 	id PodIdentifier
@@ -136,10 +188,24 @@ type FieldFilter struct {
 // from pods and added to the spans as tags.
 type ExtractionRules struct {
 	Deployment         bool
+	DeploymentUID      bool
+	ReplicaSetName     bool
+	ReplicaSetUID      bool
+	StatefulSetName    bool
+	StatefulSetUID     bool
+	DaemonSetName      bool
+	DaemonSetUID       bool
+	JobName            bool
+	JobUID             bool
+	CronJobName        bool
+	CronJobUID         bool
 	Namespace          bool
 	PodName            bool
 	PodUID             bool
 	Node               bool
+	NodeUID            bool
+	Zone               bool
+	Region             bool
 	Cluster            bool
 	StartTime          bool
 	ContainerID        bool
@@ -163,9 +229,10 @@ type FieldExtractionRule struct {
 	// Full value is extracted when no regexp is provided.
 	Regex *regexp.Regexp
 	// From determines the kubernetes object the field should be retrieved from.
-	// Currently only two values are supported,
+	// Currently only three values are supported,
 	//  - pod
 	//  - namespace
+	//  - node
 	From string
 }
 
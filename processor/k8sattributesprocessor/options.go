@@ -98,10 +98,38 @@ func WithExtractMetadata(fields ...string) Option {
 				p.rules.StartTime = true
 			case metadataDeployment, conventions.AttributeK8SDeploymentName:
 				p.rules.Deployment = true
+			case conventions.AttributeK8SDeploymentUID:
+				p.rules.DeploymentUID = true
+			case conventions.AttributeK8SReplicaSetName:
+				p.rules.ReplicaSetName = true
+			case conventions.AttributeK8SReplicaSetUID:
+				p.rules.ReplicaSetUID = true
+			case conventions.AttributeK8SStatefulSetName:
+				p.rules.StatefulSetName = true
+			case conventions.AttributeK8SStatefulSetUID:
+				p.rules.StatefulSetUID = true
+			case conventions.AttributeK8SDaemonSetName:
+				p.rules.DaemonSetName = true
+			case conventions.AttributeK8SDaemonSetUID:
+				p.rules.DaemonSetUID = true
+			case conventions.AttributeK8SJobName:
+				p.rules.JobName = true
+			case conventions.AttributeK8SJobUID:
+				p.rules.JobUID = true
+			case conventions.AttributeK8SCronJobName:
+				p.rules.CronJobName = true
+			case conventions.AttributeK8SCronJobUID:
+				p.rules.CronJobUID = true
 			case metadataCluster, conventions.AttributeK8SClusterName:
 				p.rules.Cluster = true
 			case metadataNode, conventions.AttributeK8SNodeName:
 				p.rules.Node = true
+			case conventions.AttributeK8SNodeUID:
+				p.rules.NodeUID = true
+			case conventions.AttributeCloudAvailabilityZone:
+				p.rules.Zone = true
+			case conventions.AttributeCloudRegion:
+				p.rules.Region = true
 			case conventions.AttributeContainerID:
 				p.rules.ContainerID = true
 			case conventions.AttributeContainerImageName:
@@ -151,8 +179,10 @@ func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.F
 			a.From = kube.MetadataFromPod
 		case kube.MetadataFromNamespace:
 			a.From = kube.MetadataFromNamespace
+		case kube.MetadataFromNode:
+			a.From = kube.MetadataFromNode
 		default:
-			return rules, fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace", a.From)
+			return rules, fmt.Errorf("%s is not a valid choice for From. Must be one of: pod, namespace, node", a.From)
 		}
 
 		if name == "" && a.Key != "" {
@@ -161,6 +191,8 @@ func extractFieldRules(fieldType string, fields ...FieldExtractConfig) ([]kube.F
 				name = fmt.Sprintf("k8s.pod.%s.%s", fieldType, a.Key)
 			} else if a.From == kube.MetadataFromNamespace {
 				name = fmt.Sprintf("k8s.namespace.%s.%s", fieldType, a.Key)
+			} else if a.From == kube.MetadataFromNode {
+				name = fmt.Sprintf("k8s.node.%s.%s", fieldType, a.Key)
 			}
 		}
 
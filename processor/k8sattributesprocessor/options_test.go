@@ -134,6 +134,23 @@ func TestWithExtractAnnotations(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"basic-node",
+			[]FieldExtractConfig{
+				{
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			[]kube.FieldExtractionRule{
+				{
+					Name: "k8s.node.annotations.key1",
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			"",
+		},
 		{
 			"basic-pod-keyregex",
 			[]FieldExtractConfig{
@@ -253,6 +270,23 @@ func TestWithExtractLabels(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"basic-node",
+			[]FieldExtractConfig{
+				{
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			[]kube.FieldExtractionRule{
+				{
+					Name: "k8s.node.labels.key1",
+					Key:  "key1",
+					From: kube.MetadataFromNode,
+				},
+			},
+			"",
+		},
 		{
 			"basic-pod-keyregex",
 			[]FieldExtractConfig{
@@ -335,6 +369,35 @@ func TestWithExtractMetadata(t *testing.T) {
 	assert.False(t, p.rules.StartTime)
 	assert.False(t, p.rules.Deployment)
 	assert.False(t, p.rules.Node)
+
+	p = &kubernetesprocessor{}
+	assert.NoError(t, WithExtractMetadata(
+		conventions.AttributeK8SDeploymentUID,
+		conventions.AttributeK8SReplicaSetName, conventions.AttributeK8SReplicaSetUID,
+		conventions.AttributeK8SStatefulSetName, conventions.AttributeK8SStatefulSetUID,
+		conventions.AttributeK8SDaemonSetName, conventions.AttributeK8SDaemonSetUID,
+		conventions.AttributeK8SJobName, conventions.AttributeK8SJobUID,
+		conventions.AttributeK8SCronJobName, conventions.AttributeK8SCronJobUID,
+	)(p))
+	assert.False(t, p.rules.Deployment)
+	assert.True(t, p.rules.DeploymentUID)
+	assert.True(t, p.rules.ReplicaSetName)
+	assert.True(t, p.rules.ReplicaSetUID)
+	assert.True(t, p.rules.StatefulSetName)
+	assert.True(t, p.rules.StatefulSetUID)
+	assert.True(t, p.rules.DaemonSetName)
+	assert.True(t, p.rules.DaemonSetUID)
+	assert.True(t, p.rules.JobName)
+	assert.True(t, p.rules.JobUID)
+	assert.True(t, p.rules.CronJobName)
+	assert.True(t, p.rules.CronJobUID)
+
+	p = &kubernetesprocessor{}
+	assert.NoError(t, WithExtractMetadata(conventions.AttributeK8SNodeUID, conventions.AttributeCloudAvailabilityZone, conventions.AttributeCloudRegion)(p))
+	assert.False(t, p.rules.Node)
+	assert.True(t, p.rules.NodeUID)
+	assert.True(t, p.rules.Zone)
+	assert.True(t, p.rules.Region)
 }
 
 func TestWithFilterLabels(t *testing.T) {
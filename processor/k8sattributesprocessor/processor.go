@@ -53,7 +53,7 @@ func (kp *kubernetesprocessor) initKubeClient(logger *zap.Logger, kubeClient kub
 		kubeClient = kube.New
 	}
 	if !kp.passthroughMode {
-		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, nil, nil)
+		kc, err := kubeClient(logger, kp.apiConfig, kp.rules, kp.filters, kp.podAssociations, kp.podIgnore, nil, nil, nil, nil, nil, nil)
 		if err != nil {
 			return err
 		}
@@ -117,12 +117,14 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pda
 		return
 	}
 
+	var nodeName string
 	if podIdentifierKey != "" {
 		if pod, ok := kp.kc.GetPod(podIdentifierValue); ok {
 			for key, val := range pod.Attributes {
 				resource.Attributes().InsertString(key, val)
 			}
 			kp.addContainerAttributes(resource.Attributes(), pod)
+			nodeName = pod.NodeName
 		}
 	}
 
@@ -133,6 +135,16 @@ func (kp *kubernetesprocessor) processResource(ctx context.Context, resource pda
 			resource.Attributes().InsertString(key, val)
 		}
 	}
+
+	if nodeName == "" {
+		nodeName = stringAttributeFromMap(resource.Attributes(), conventions.AttributeK8SNodeName)
+	}
+	if nodeName != "" {
+		attrsToAdd := kp.getAttributesForPodsNode(nodeName)
+		for key, val := range attrsToAdd {
+			resource.Attributes().InsertString(key, val)
+		}
+	}
 }
 
 // addContainerAttributes looks if pod has any container identifiers and adds additional container attributes
@@ -174,6 +186,14 @@ func (kp *kubernetesprocessor) getAttributesForPodsNamespace(namespace string) m
 	return ns.Attributes
 }
 
+func (kp *kubernetesprocessor) getAttributesForPodsNode(nodeName string) map[string]string {
+	node, ok := kp.kc.GetNode(nodeName)
+	if !ok {
+		return nil
+	}
+	return node.Attributes
+}
+
 // intFromAttribute extracts int value from an attribute stored as string or int
 func intFromAttribute(val pdata.AttributeValue) (int, error) {
 	switch val.Type() {
@@ -218,7 +218,7 @@ func TestProcessorBadConfig(t *testing.T) {
 }
 
 func TestProcessorBadClientProvider(t *testing.T) {
-	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ []kube.Association, _ kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace) (kube.Client, error) {
+	clientProvider := func(_ *zap.Logger, _ k8sconfig.APIConfig, _ kube.ExtractionRules, _ kube.Filters, _ []kube.Association, _ kube.Excludes, _ kube.APIClientsetProvider, _ kube.InformerProvider, _ kube.InformerProviderNamespace, _ kube.InformerProviderReplicaSet, _ kube.InformerProviderJob, _ kube.InformerProviderNode) (kube.Client, error) {
 		return nil, fmt.Errorf("bad client error")
 	}
 
@@ -284,6 +284,12 @@ func withPodUID(uid string) generateResourceFunc {
 	}
 }
 
+func withNodeName(nodeName string) generateResourceFunc {
+	return func(res pdata.Resource) {
+		res.Attributes().InsertString(conventions.AttributeK8SNodeName, nodeName)
+	}
+}
+
 func withContainerName(containerName string) generateResourceFunc {
 	return func(res pdata.Resource) {
 		res.Attributes().InsertString(conventions.AttributeK8SContainerName, containerName)
@@ -613,6 +619,59 @@ func TestPodUID(t *testing.T) {
 	})
 }
 
+func TestProcessorAddNodeAttributes(t *testing.T) {
+	m := newMultiTest(
+		t,
+		NewFactory().CreateDefaultConfig(),
+		nil,
+	)
+	m.kubernetesProcessorOperation(func(kp *kubernetesprocessor) {
+		kp.podAssociations = []kube.Association{
+			{
+				From: "resource_attribute",
+				Name: "k8s.pod.uid",
+			},
+		}
+		kp.kc.(*fakeClient).Pods["ef10d10b-2da5-4030-812e-5f45c1531227"] = &kube.Pod{
+			Name:     "PodA",
+			NodeName: "node-a",
+		}
+		kp.kc.(*fakeClient).Nodes = map[string]*kube.Node{
+			"node-a": {
+				Name: "node-a",
+				Attributes: map[string]string{
+					conventions.AttributeCloudAvailabilityZone: "us-east-1a",
+				},
+			},
+			"node-b": {
+				Name: "node-b",
+				Attributes: map[string]string{
+					conventions.AttributeCloudAvailabilityZone: "us-east-1b",
+				},
+			},
+		}
+	})
+
+	m.testConsume(context.Background(),
+		generateTraces(withPodUID("ef10d10b-2da5-4030-812e-5f45c1531227")),
+		generateMetrics(withPodUID("ef10d10b-2da5-4030-812e-5f45c1531227")),
+		generateLogs(withPodUID("ef10d10b-2da5-4030-812e-5f45c1531227")),
+		nil)
+	m.testConsume(context.Background(),
+		generateTraces(withNodeName("node-b")),
+		generateMetrics(withNodeName("node-b")),
+		generateLogs(withNodeName("node-b")),
+		nil)
+
+	m.assertBatchesLen(2)
+	m.assertResource(0, func(r pdata.Resource) {
+		assertResourceHasStringAttribute(t, r, conventions.AttributeCloudAvailabilityZone, "us-east-1a")
+	})
+	m.assertResource(1, func(r pdata.Resource) {
+		assertResourceHasStringAttribute(t, r, conventions.AttributeCloudAvailabilityZone, "us-east-1b")
+	})
+}
+
 func TestProcessorAddLabels(t *testing.T) {
 	m := newMultiTest(
 		t,
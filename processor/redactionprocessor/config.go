@@ -15,9 +15,34 @@
 package redactionprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
 )
 
+const (
+	// maskModeMask replaces the matching part of a value with asterisks.
+	maskModeMask = "mask"
+	// maskModeHash replaces the matching part of a value with its salted SHA-256 hash.
+	maskModeHash = "hash"
+
+	// summaryDebug lists the redacted and masked keys and their counts.
+	summaryDebug = "debug"
+	// summaryInfo only sets the counts of the redacted and masked keys.
+	summaryInfo = "info"
+	// summarySilent doesn't add any summary attribute.
+	summarySilent = "silent"
+)
+
+var (
+	errKeepLastWithHash    = errors.New("\"keep_last\" can only be used with the \"mask\" masking mode")
+	errSaltWithoutHash     = errors.New("\"salt\" can only be used with the \"hash\" masking mode")
+	errNegativeKeepLast    = errors.New("\"keep_last\" must not be negative")
+	errUnsupportedMaskMode = fmt.Errorf("masking \"mode\" must be one of %q or %q", maskModeMask, maskModeHash)
+	errUnsupportedSummary  = fmt.Errorf("\"summary\" must be one of %q, %q or %q", summaryDebug, summaryInfo, summarySilent)
+)
+
 type Config struct {
 	config.ProcessorSettings `mapstructure:",squash"`
 
@@ -28,10 +53,68 @@ type Config struct {
 
 	// AllowedKeys is a list of allowed span attribute keys. Span attributes
 	// not on the list are removed. The list fails closed if it's empty. To
-	// allow all keys, you should explicitly set AllowAllKeys
+	// allow all keys, you should explicitly set AllowAllKeys. The attributes
+	// of metric data points are never removed
 	AllowedKeys []string `mapstructure:"allowed_keys"`
 
 	// BlockedValues is a list of regular expressions for blocking values of
 	// allowed span attributes. Values that match are masked
 	BlockedValues []string `mapstructure:"blocked_values"`
+
+	// Masking configures how the parts of the values matching BlockedValues
+	// are masked. By default they are replaced with asterisks.
+	Masking MaskingConfig `mapstructure:"masking"`
+
+	// Summary controls the attributes summarizing the changes made by the
+	// processor to a span or a log record. "debug" lists the redacted and
+	// masked keys along with their counts, "info" only sets the counts and
+	// "silent" doesn't add any attribute. The default is "debug".
+	Summary string `mapstructure:"summary"`
+}
+
+// MaskingConfig configures how the blocked values are masked.
+type MaskingConfig struct {
+	// Mode is either "mask", replacing the matching part of a value with
+	// asterisks, or "hash", replacing it with the hex encoded SHA-256 hash of
+	// the salted match. Hashing keeps equal values correlated without exposing
+	// them. The default is "mask".
+	Mode string `mapstructure:"mode"`
+
+	// KeepLast is the number of trailing characters of the match left visible
+	// after the asterisks, e.g. 4 to keep the last 4 digits of a credit card
+	// number. Matches not longer than KeepLast are masked entirely. Only used
+	// by the "mask" mode.
+	KeepLast int `mapstructure:"keep_last"`
+
+	// Salt is prepended to the match before hashing it, so the hashes can't be
+	// reversed with a lookup table. Only used by the "hash" mode.
+	Salt string `mapstructure:"salt"`
+}
+
+var _ config.Processor = (*Config)(nil)
+
+// Validate checks if the processor configuration is valid
+func (cfg *Config) Validate() error {
+	switch cfg.Masking.Mode {
+	case "", maskModeMask:
+		if cfg.Masking.Salt != "" {
+			return errSaltWithoutHash
+		}
+	case maskModeHash:
+		if cfg.Masking.KeepLast != 0 {
+			return errKeepLastWithHash
+		}
+	default:
+		return errUnsupportedMaskMode
+	}
+	if cfg.Masking.KeepLast < 0 {
+		return errNegativeKeepLast
+	}
+
+	switch cfg.Summary {
+	case "", summaryDebug, summaryInfo, summarySilent:
+	default:
+		return errUnsupportedSummary
+	}
+	return nil
 }
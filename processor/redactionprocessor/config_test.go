@@ -21,6 +21,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configtest"
 )
 
@@ -33,4 +34,71 @@ func TestLoadConfig(t *testing.T) {
 	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
 	require.NoError(t, err)
 	require.NotNil(t, cfg)
+
+	p0 := cfg.Processors[config.NewComponentID(typeStr)]
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		AllowedKeys:       []string{"description", "group", "id", "name"},
+		BlockedValues:     []string{"4[0-9]{12}(?:[0-9]{3})?", "(5[1-5][0-9]{14})"},
+		Masking: MaskingConfig{
+			Mode:     maskModeMask,
+			KeepLast: 4,
+		},
+		Summary: summaryDebug,
+	}, p0)
+
+	p1 := cfg.Processors[config.NewComponentIDWithName(typeStr, "hash")]
+	assert.Equal(t, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName(typeStr, "hash")),
+		AllowAllKeys:      true,
+		BlockedValues:     []string{"[a-z0-9._%+-]+@[a-z0-9.-]+\\.[a-z]{2,}"},
+		Masking: MaskingConfig{
+			Mode: maskModeHash,
+			Salt: "s3cr3t",
+		},
+		Summary: summaryInfo,
+	}, p1)
+}
+
+func TestValidateConfig(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  *Config
+		err  error
+	}{
+		{
+			name: "default",
+			cfg:  createDefaultConfig().(*Config),
+		},
+		{
+			name: "unsupported mode",
+			cfg:  &Config{Masking: MaskingConfig{Mode: "scramble"}},
+			err:  errUnsupportedMaskMode,
+		},
+		{
+			name: "keep_last with hash",
+			cfg:  &Config{Masking: MaskingConfig{Mode: maskModeHash, KeepLast: 4}},
+			err:  errKeepLastWithHash,
+		},
+		{
+			name: "salt without hash",
+			cfg:  &Config{Masking: MaskingConfig{Mode: maskModeMask, Salt: "s3cr3t"}},
+			err:  errSaltWithoutHash,
+		},
+		{
+			name: "negative keep_last",
+			cfg:  &Config{Masking: MaskingConfig{KeepLast: -1}},
+			err:  errNegativeKeepLast,
+		},
+		{
+			name: "unsupported summary",
+			cfg:  &Config{Summary: "verbose"},
+			err:  errUnsupportedSummary,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.err, tc.cfg.Validate())
+		})
+	}
 }
@@ -16,8 +16,12 @@ package redactionprocessor // import "github.com/open-telemetry/opentelemetry-co
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"regexp"
+	"sort"
+	"strings"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -25,23 +29,19 @@ import (
 	"go.uber.org/zap"
 )
 
-var _ component.TracesProcessor = (*redaction)(nil)
-
 type redaction struct {
 	// Attribute keys allowed in a span
 	allowList map[string]string
-	// Attribute values blocked in a span
-	blockRegexList map[string]*regexp.Regexp
+	// Attribute values blocked in a span, in the order of the configuration
+	blockRegexList []*regexp.Regexp
 	// Redaction processor configuration
 	config *Config
 	// Logger
 	logger *zap.Logger
-	// Next trace consumer in line
-	next consumer.Traces
 }
 
 // newRedaction creates a new instance of the redaction processor
-func newRedaction(ctx context.Context, config *Config, logger *zap.Logger, next consumer.Traces) (*redaction, error) {
+func newRedaction(ctx context.Context, config *Config, logger *zap.Logger) (*redaction, error) {
 	allowList := makeAllowList(config)
 	blockRegexList, err := makeBlockRegexList(ctx, config)
 	if err != nil {
@@ -54,30 +54,194 @@ func newRedaction(ctx context.Context, config *Config, logger *zap.Logger, next
 		blockRegexList: blockRegexList,
 		config:         config,
 		logger:         logger,
-		next:           next,
 	}, nil
 }
 
-// processTraces implements ProcessMetricsFunc. It processes the incoming data
+// processTraces implements ProcessTracesFunc. It processes the incoming data
 // and returns the data to be sent to the next component
-func (s *redaction) processTraces(_ context.Context, batch pdata.Traces) (pdata.Traces, error) {
-	// TODO: Implementation to follow in the next PR
+func (s *redaction) processTraces(ctx context.Context, batch pdata.Traces) (pdata.Traces, error) {
+	rss := batch.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		ilss := rss.At(i).InstrumentationLibrarySpans()
+		for j := 0; j < ilss.Len(); j++ {
+			spans := ilss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				s.processAttrs(ctx, spans.At(k).Attributes())
+			}
+		}
+	}
 	return batch, nil
 }
 
-// ConsumeTraces implements the SpanProcessor interface
-func (s *redaction) ConsumeTraces(_ context.Context, _ pdata.Traces) error {
-	// TODO: Implementation to follow in the next PR
-	return nil
+// processLogs implements ProcessLogsFunc. It processes the attributes of the
+// incoming log records the same way as the span attributes
+func (s *redaction) processLogs(ctx context.Context, batch pdata.Logs) (pdata.Logs, error) {
+	rls := batch.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		ills := rls.At(i).InstrumentationLibraryLogs()
+		for j := 0; j < ills.Len(); j++ {
+			logs := ills.At(j).Logs()
+			for k := 0; k < logs.Len(); k++ {
+				s.processAttrs(ctx, logs.At(k).Attributes())
+			}
+		}
+	}
+	return batch, nil
+}
+
+// processMetrics implements ProcessMetricsFunc. It masks the values of the
+// incoming metric data point attributes matching the block list. The keys
+// aren't removed and no summary is added, as it would change the identity of
+// the time series
+func (s *redaction) processMetrics(_ context.Context, batch pdata.Metrics) (pdata.Metrics, error) {
+	rms := batch.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		ilms := rms.At(i).InstrumentationLibraryMetrics()
+		for j := 0; j < ilms.Len(); j++ {
+			metrics := ilms.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				s.processMetric(metrics.At(k))
+			}
+		}
+	}
+	return batch, nil
+}
+
+func (s *redaction) processMetric(metric pdata.Metric) {
+	switch metric.DataType() {
+	case pdata.MetricDataTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s.maskAttrs(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s.maskAttrs(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s.maskAttrs(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s.maskAttrs(dps.At(i).Attributes())
+		}
+	case pdata.MetricDataTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s.maskAttrs(dps.At(i).Attributes())
+		}
+	}
+}
+
+// processAttrs removes the attributes not on the allow list and masks the
+// values matching the block list. The changes are summarized in the
+// attributes
+func (s *redaction) processAttrs(_ context.Context, attributes pdata.AttributeMap) {
+	toDelete := make([]string, 0, attributes.Len())
+	toBlock := make([]string, 0, attributes.Len())
+	attributes.Range(func(k string, value pdata.AttributeValue) bool {
+		if !s.config.AllowAllKeys {
+			if _, allowed := s.allowList[k]; !allowed {
+				toDelete = append(toDelete, k)
+				return true
+			}
+		}
+		// The summary of a previous redaction is left as is
+		if isRedactionKey(k) {
+			return true
+		}
+
+		if s.maskValue(value) {
+			toBlock = append(toBlock, k)
+		}
+		return true
+	})
+
+	for _, k := range toDelete {
+		attributes.Delete(k)
+	}
+
+	s.addMetaAttrs(toDelete, attributes, redactedKeys, redactedKeyCount)
+	s.addMetaAttrs(toBlock, attributes, maskedValues, maskedValueCount)
+}
+
+// maskAttrs masks the values matching the block list, keeping all the keys
+func (s *redaction) maskAttrs(attributes pdata.AttributeMap) {
+	attributes.Range(func(_ string, value pdata.AttributeValue) bool {
+		s.maskValue(value)
+		return true
+	})
+}
+
+// maskValue masks the parts of the value matching the block list, returning
+// whether the value was changed
+func (s *redaction) maskValue(value pdata.AttributeValue) bool {
+	strVal := value.AsString()
+	masked := strVal
+	for _, re := range s.blockRegexList {
+		masked = re.ReplaceAllStringFunc(masked, s.mask)
+	}
+	if masked == strVal {
+		return false
+	}
+	value.SetStringVal(masked)
+	return true
+}
+
+// addMetaAttrs adds the summary of the redacted or masked keys to the
+// attributes, depending on the summary configuration
+func (s *redaction) addMetaAttrs(keys []string, attributes pdata.AttributeMap, keysAttr, countAttr string) {
+	if len(keys) == 0 || s.config.Summary == summarySilent {
+		return
+	}
+
+	if s.config.Summary != summaryInfo {
+		sort.Strings(keys)
+		attributes.UpsertString(keysAttr, strings.Join(keys, ","))
+	}
+	attributes.UpsertInt(countAttr, int64(len(keys)))
+}
+
+// mask returns the replacement of a part of a value matching a blocked regex
+func (s *redaction) mask(match string) string {
+	if s.config.Masking.Mode == maskModeHash {
+		h := sha256.New()
+		h.Write([]byte(s.config.Masking.Salt))
+		h.Write([]byte(match))
+		return hex.EncodeToString(h.Sum(nil))
+	}
+
+	keep := s.config.Masking.KeepLast
+	runes := []rune(match)
+	if keep <= 0 || keep >= len(runes) {
+		return mask
+	}
+	return mask + string(runes[len(runes)-keep:])
 }
 
 const (
+	mask = "****"
+
 	redactedKeys     = "redaction.redacted.keys"
 	redactedKeyCount = "redaction.redacted.count"
 	maskedValues     = "redaction.masked.keys"
 	maskedValueCount = "redaction.masked.count"
 )
 
+// isRedactionKey returns true for the attributes created by the processor to
+// summarize its changes
+func isRedactionKey(k string) bool {
+	switch k {
+	case redactedKeys, redactedKeyCount, maskedValues, maskedValueCount:
+		return true
+	}
+	return false
+}
+
 // makeAllowList sets up a lookup table of allowed span attribute keys
 func makeAllowList(c *Config) map[string]string {
 	// redactionKeys are additional span attributes created by the processor to
@@ -105,15 +269,15 @@ func makeAllowList(c *Config) map[string]string {
 }
 
 // makeBlockRegexList precompiles all the blocked regex patterns
-func makeBlockRegexList(_ context.Context, config *Config) (map[string]*regexp.Regexp, error) {
-	blockRegexList := make(map[string]*regexp.Regexp, len(config.BlockedValues))
+func makeBlockRegexList(_ context.Context, config *Config) ([]*regexp.Regexp, error) {
+	blockRegexList := make([]*regexp.Regexp, 0, len(config.BlockedValues))
 	for _, pattern := range config.BlockedValues {
 		re, err := regexp.Compile(pattern)
 		if err != nil {
 			// TODO: Placeholder for an error metric in the next PR
 			return nil, fmt.Errorf("error compiling regex in block list: %w", err)
 		}
-		blockRegexList[pattern] = re
+		blockRegexList = append(blockRegexList, re)
 	}
 	return blockRegexList, nil
 }
@@ -16,18 +16,20 @@ package redactionprocessor
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
-	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap/zaptest"
 )
 
 func TestCapabilities(t *testing.T) {
 	config := &Config{}
-	next := new(consumertest.TracesSink)
-	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t), next)
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
 	assert.NoError(t, err)
 
 	cap := processor.Capabilities()
@@ -36,8 +38,7 @@ func TestCapabilities(t *testing.T) {
 
 func TestStartShutdown(t *testing.T) {
 	config := &Config{}
-	next := new(consumertest.TracesSink)
-	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t), next)
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
 	assert.NoError(t, err)
 
 	ctx := context.Background()
@@ -46,3 +47,221 @@ func TestStartShutdown(t *testing.T) {
 	err = processor.Shutdown(ctx)
 	assert.Nil(t, err)
 }
+
+// TestRedactUnknownAttributes validates that the attributes not on the allow
+// list are removed and listed in the summary
+func TestRedactUnknownAttributes(t *testing.T) {
+	config := &Config{
+		AllowedKeys: []string{"group", "id"},
+	}
+	span := runTraces(t, config, map[string]pdata.AttributeValue{
+		"group":    pdata.NewAttributeValueString("temporary"),
+		"id":       pdata.NewAttributeValueInt(5),
+		"name":     pdata.NewAttributeValueString("placeholder"),
+		"password": pdata.NewAttributeValueString("xyzxyz"),
+	})
+	attrs := span.Attributes()
+
+	assertStringAttribute(t, attrs, "group", "temporary")
+	_, ok := attrs.Get("name")
+	assert.False(t, ok)
+	_, ok = attrs.Get("password")
+	assert.False(t, ok)
+	assertStringAttribute(t, attrs, redactedKeys, "name,password")
+	assertIntAttribute(t, attrs, redactedKeyCount, 2)
+	_, ok = attrs.Get(maskedValues)
+	assert.False(t, ok)
+}
+
+// TestMaskBlockedValues validates that the parts of the values matching the
+// block list are masked, including the values that are not strings
+func TestMaskBlockedValues(t *testing.T) {
+	testCases := []struct {
+		name     string
+		masking  MaskingConfig
+		expected string
+	}{
+		{
+			name:     "mask",
+			expected: "card ****, expires 12/25",
+		},
+		{
+			name:     "keep-last",
+			masking:  MaskingConfig{Mode: maskModeMask, KeepLast: 4},
+			expected: "card ****1111, expires 12/25",
+		},
+		{
+			name:     "hash",
+			masking:  MaskingConfig{Mode: maskModeHash, Salt: "pepper"},
+			expected: "card " + sha256Hex("pepper4111111111111111") + ", expires 12/25",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			config := &Config{
+				AllowAllKeys:  true,
+				BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+				Masking:       tc.masking,
+			}
+			span := runTraces(t, config, map[string]pdata.AttributeValue{
+				"notes":  pdata.NewAttributeValueString("card 4111111111111111, expires 12/25"),
+				"number": pdata.NewAttributeValueInt(4111111111111111),
+				"group":  pdata.NewAttributeValueString("temporary"),
+			})
+			attrs := span.Attributes()
+
+			assertStringAttribute(t, attrs, "notes", tc.expected)
+			number, ok := attrs.Get("number")
+			require.True(t, ok)
+			assert.Equal(t, pdata.AttributeValueTypeString, number.Type())
+			assert.NotContains(t, number.StringVal(), "4111111111")
+			assertStringAttribute(t, attrs, "group", "temporary")
+			assertStringAttribute(t, attrs, maskedValues, "notes,number")
+			assertIntAttribute(t, attrs, maskedValueCount, 2)
+		})
+	}
+}
+
+// TestMaskKeepLastShortMatch validates that matches not longer than KeepLast
+// are masked entirely
+func TestMaskKeepLastShortMatch(t *testing.T) {
+	config := &Config{
+		AllowAllKeys:  true,
+		BlockedValues: []string{"[0-9]{3}"},
+		Masking:       MaskingConfig{KeepLast: 4},
+	}
+	span := runTraces(t, config, map[string]pdata.AttributeValue{
+		"cvv": pdata.NewAttributeValueString("123"),
+	})
+	assertStringAttribute(t, span.Attributes(), "cvv", "****")
+}
+
+// TestSummary validates the summary attributes added for each summary level
+func TestSummary(t *testing.T) {
+	testCases := []struct {
+		summary    string
+		wantKeys   bool
+		wantCounts bool
+	}{
+		{summary: "", wantKeys: true, wantCounts: true},
+		{summary: summaryDebug, wantKeys: true, wantCounts: true},
+		{summary: summaryInfo, wantKeys: false, wantCounts: true},
+		{summary: summarySilent, wantKeys: false, wantCounts: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.summary, func(t *testing.T) {
+			config := &Config{
+				AllowedKeys:   []string{"notes"},
+				BlockedValues: []string{"secret"},
+				Summary:       tc.summary,
+			}
+			span := runTraces(t, config, map[string]pdata.AttributeValue{
+				"notes":    pdata.NewAttributeValueString("a secret"),
+				"password": pdata.NewAttributeValueString("xyzxyz"),
+			})
+			attrs := span.Attributes()
+
+			_, ok := attrs.Get(redactedKeys)
+			assert.Equal(t, tc.wantKeys, ok)
+			_, ok = attrs.Get(maskedValues)
+			assert.Equal(t, tc.wantKeys, ok)
+			_, ok = attrs.Get(redactedKeyCount)
+			assert.Equal(t, tc.wantCounts, ok)
+			_, ok = attrs.Get(maskedValueCount)
+			assert.Equal(t, tc.wantCounts, ok)
+		})
+	}
+}
+
+// TestProcessLogs validates that the attributes of log records are redacted
+func TestProcessLogs(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"notes"},
+		BlockedValues: []string{"4[0-9]{12}(?:[0-9]{3})?"},
+		Masking:       MaskingConfig{KeepLast: 4},
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	logs := pdata.NewLogs()
+	record := logs.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	record.Attributes().InsertString("notes", "card 4111111111111111")
+	record.Attributes().InsertString("password", "xyzxyz")
+
+	processed, err := processor.processLogs(context.Background(), logs)
+	require.NoError(t, err)
+	attrs := processed.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Attributes()
+
+	assertStringAttribute(t, attrs, "notes", "card ****1111")
+	_, ok := attrs.Get("password")
+	assert.False(t, ok)
+	assertStringAttribute(t, attrs, redactedKeys, "password")
+	assertStringAttribute(t, attrs, maskedValues, "notes")
+}
+
+// TestProcessMetrics validates that the attribute values of metric data points
+// are masked, without removing keys nor adding summary attributes
+func TestProcessMetrics(t *testing.T) {
+	config := &Config{
+		AllowedKeys:   []string{"user"},
+		BlockedValues: []string{"[a-z]+@example.com"},
+		Masking:       MaskingConfig{Mode: maskModeHash},
+	}
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	metrics := pdata.NewMetrics()
+	ms := metrics.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty().Metrics()
+	gauge := ms.AppendEmpty()
+	gauge.SetDataType(pdata.MetricDataTypeGauge)
+	dp := gauge.Gauge().DataPoints().AppendEmpty()
+	dp.Attributes().InsertString("user", "jane@example.com")
+	dp.Attributes().InsertString("session", "abc")
+	histogram := ms.AppendEmpty()
+	histogram.SetDataType(pdata.MetricDataTypeHistogram)
+	hdp := histogram.Histogram().DataPoints().AppendEmpty()
+	hdp.Attributes().InsertString("user", "john@example.com")
+
+	processed, err := processor.processMetrics(context.Background(), metrics)
+	require.NoError(t, err)
+	processedMs := processed.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+
+	attrs := processedMs.At(0).Gauge().DataPoints().At(0).Attributes()
+	assert.Equal(t, 2, attrs.Len())
+	assertStringAttribute(t, attrs, "user", sha256Hex("jane@example.com"))
+	assertStringAttribute(t, attrs, "session", "abc")
+	attrs = processedMs.At(1).Histogram().DataPoints().At(0).Attributes()
+	assert.Equal(t, 1, attrs.Len())
+	assertStringAttribute(t, attrs, "user", sha256Hex("john@example.com"))
+}
+
+// runTraces processes a span with the given attributes and returns it
+func runTraces(t *testing.T, config *Config, attrs map[string]pdata.AttributeValue) pdata.Span {
+	processor, err := newRedaction(context.Background(), config, zaptest.NewLogger(t))
+	require.NoError(t, err)
+
+	traces := pdata.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	pdata.NewAttributeMapFromMap(attrs).CopyTo(span.Attributes())
+
+	processed, err := processor.processTraces(context.Background(), traces)
+	require.NoError(t, err)
+	return processed.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+}
+
+func assertStringAttribute(t *testing.T, attrs pdata.AttributeMap, key string, expected string) {
+	value, ok := attrs.Get(key)
+	require.True(t, ok, "missing attribute %q", key)
+	assert.Equal(t, expected, value.StringVal())
+}
+
+func assertIntAttribute(t *testing.T, attrs pdata.AttributeMap, key string, expected int64) {
+	value, ok := attrs.Get(key)
+	require.True(t, ok, "missing attribute %q", key)
+	assert.Equal(t, expected, value.IntVal())
+}
+
+func sha256Hex(s string) string {
+	h := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(h[:])
+}
@@ -42,6 +42,7 @@ func TestLoadConfig(t *testing.T) {
 			{Key: "cloud.availability_zone", Value: "zone-1", Action: attraction.UPSERT},
 			{Key: "k8s.cluster.name", FromAttribute: "k8s-cluster", Action: attraction.INSERT},
 			{Key: "redundant-attribute", Action: attraction.DELETE},
+			{Key: "service.instance.id", FromAttributes: []string{"host.name", "process.pid"}, Separator: ":", Action: attraction.CONCAT},
 		},
 	})
 
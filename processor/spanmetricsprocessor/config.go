@@ -18,6 +18,12 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+const (
+	delta      = "AGGREGATION_TEMPORALITY_DELTA"
+	cumulative = "AGGREGATION_TEMPORALITY_CUMULATIVE"
 )
 
 // Dimension defines the dimension name and optional default value if the Dimension is missing from a span attribute.
@@ -45,4 +51,34 @@ type Config struct {
 	// The dimensions will be fetched from the span's attributes. Examples of some conventionally used attributes:
 	// https://github.com/open-telemetry/opentelemetry-collector/blob/main/model/semconv/opentelemetry.go.
 	Dimensions []Dimension `mapstructure:"dimensions"`
+
+	// AggregationTemporality defines the aggregation temporality of the generated metrics,
+	// either "AGGREGATION_TEMPORALITY_CUMULATIVE" or "AGGREGATION_TEMPORALITY_DELTA".
+	// With delta temporality the accumulated metrics are reset after each flush.
+	// Optional. Defaults to "AGGREGATION_TEMPORALITY_CUMULATIVE".
+	AggregationTemporality string `mapstructure:"aggregation_temporality"`
+
+	// DimensionsCacheSize is the maximum number of dimension combinations kept in memory.
+	// When the limit is reached the least recently used combination is evicted, and its
+	// metrics are emitted one last time before being dropped.
+	// Optional. See defaultDimensionsCacheSize in factory.go for the default value.
+	DimensionsCacheSize int `mapstructure:"dimensions_cache_size"`
+
+	// ResourceAttributes defines the list of resource attributes copied onto the resource of the generated metrics.
+	// The attributes are fetched from the span's resource attributes, falling back to the optional default value.
+	// The generated metrics are grouped by the values of these attributes.
+	ResourceAttributes []Dimension `mapstructure:"resource_attributes"`
+
+	// MetricsFlushInterval is the interval at which the generated metrics are built and sent to the metrics exporter.
+	// Optional. Defaults to 0, which sends the metrics each time a batch of spans is consumed.
+	MetricsFlushInterval time.Duration `mapstructure:"metrics_flush_interval"`
+}
+
+// GetAggregationTemporality converts the string value given in the config into a MetricAggregationTemporality.
+// Returns cumulative, unless delta is correctly specified.
+func (c Config) GetAggregationTemporality() pdata.MetricAggregationTemporality {
+	if c.AggregationTemporality == delta {
+		return pdata.MetricAggregationTemporalityDelta
+	}
+	return pdata.MetricAggregationTemporalityCumulative
 }
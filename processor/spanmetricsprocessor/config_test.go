@@ -35,14 +35,29 @@ import (
 
 func TestLoadConfig(t *testing.T) {
 	defaultMethod := "GET"
+	defaultEnvironment := "production"
 	testcases := []struct {
 		configFile                  string
 		wantMetricsExporter         string
 		wantLatencyHistogramBuckets []time.Duration
 		wantDimensions              []Dimension
+		wantAggregationTemporality  string
+		wantDimensionsCacheSize     int
+		wantResourceAttributes      []Dimension
+		wantMetricsFlushInterval    time.Duration
 	}{
-		{configFile: "config-2-pipelines.yaml", wantMetricsExporter: "prometheus"},
-		{configFile: "config-3-pipelines.yaml", wantMetricsExporter: "otlp/spanmetrics"},
+		{
+			configFile:                 "config-2-pipelines.yaml",
+			wantMetricsExporter:        "prometheus",
+			wantAggregationTemporality: cumulative,
+			wantDimensionsCacheSize:    defaultDimensionsCacheSize,
+		},
+		{
+			configFile:                 "config-3-pipelines.yaml",
+			wantMetricsExporter:        "otlp/spanmetrics",
+			wantAggregationTemporality: cumulative,
+			wantDimensionsCacheSize:    defaultDimensionsCacheSize,
+		},
 		{
 			configFile:          "config-full.yaml",
 			wantMetricsExporter: "otlp/spanmetrics",
@@ -59,6 +74,13 @@ func TestLoadConfig(t *testing.T) {
 				{"http.method", &defaultMethod},
 				{"http.status_code", nil},
 			},
+			wantAggregationTemporality: delta,
+			wantDimensionsCacheSize:    500,
+			wantResourceAttributes: []Dimension{
+				{"service.name", nil},
+				{"deployment.environment", &defaultEnvironment},
+			},
+			wantMetricsFlushInterval: 15 * time.Second,
 		},
 	}
 	for _, tc := range testcases {
@@ -89,6 +111,10 @@ func TestLoadConfig(t *testing.T) {
 					MetricsExporter:         tc.wantMetricsExporter,
 					LatencyHistogramBuckets: tc.wantLatencyHistogramBuckets,
 					Dimensions:              tc.wantDimensions,
+					AggregationTemporality:  tc.wantAggregationTemporality,
+					DimensionsCacheSize:     tc.wantDimensionsCacheSize,
+					ResourceAttributes:      tc.wantResourceAttributes,
+					MetricsFlushInterval:    tc.wantMetricsFlushInterval,
 				},
 				cfg.Processors[config.NewComponentID(typeStr)],
 			)
@@ -16,7 +16,9 @@ package spanmetricsprocessor // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"sync"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
@@ -26,10 +28,20 @@ import (
 const (
 	// The value of "type" key in configuration.
 	typeStr = "spanmetrics"
+
+	// defaultDimensionsCacheSize is the default maximum number of dimension combinations kept in memory.
+	defaultDimensionsCacheSize = 1000
 )
 
+var once sync.Once
+
 // NewFactory creates a factory for the spanmetrics processor.
 func NewFactory() component.ProcessorFactory {
+	once.Do(func() {
+		// TODO: as with other -contrib factories registering metrics, this is causing the error being ignored
+		_ = view.Register(MetricViews()...)
+	})
+
 	return processorhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
@@ -39,7 +51,9 @@ func NewFactory() component.ProcessorFactory {
 
 func createDefaultConfig() config.Processor {
 	return &Config{
-		ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		ProcessorSettings:      config.NewProcessorSettings(config.NewComponentID(typeStr)),
+		AggregationTemporality: cumulative,
+		DimensionsCacheSize:    defaultDimensionsCacheSize,
 	}
 }
 
@@ -24,6 +24,8 @@ import (
 	"time"
 	"unicode"
 
+	"github.com/hashicorp/golang-lru/simplelru"
+	"go.opencensus.io/stats"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
@@ -57,6 +59,15 @@ type exemplarData struct {
 
 type metricKey string
 
+type resourceKey string
+
+// metricDimensions holds the attributes of a metric and of the resource the metric belongs to.
+type metricDimensions struct {
+	resourceKey   resourceKey
+	resourceAttrs pdata.AttributeMap
+	attrs         pdata.AttributeMap
+}
+
 type processorImp struct {
 	lock   sync.RWMutex
 	logger *zap.Logger
@@ -68,9 +79,16 @@ type processorImp struct {
 	// Additional dimensions to add to metrics.
 	dimensions []Dimension
 
-	// The starting time of the data points.
+	// Resource attributes to add to the resource of the metrics.
+	resourceAttributes []Dimension
+
+	// The starting time of the data points with delta temporality: the time of the last flush.
 	startTime time.Time
 
+	// The starting time of the data points of each metric key with cumulative temporality:
+	// the time the metrics of the key were created, or created again after being dropped.
+	metricStartTimes map[metricKey]time.Time
+
 	// Call & Error counts.
 	callSum map[metricKey]int64
 
@@ -81,9 +99,18 @@ type processorImp struct {
 	latencyBounds        []float64
 	latencyExemplarsData map[metricKey][]exemplarData
 
-	// A cache of dimension key-value maps keyed by a unique identifier formed by a concatenation of its values:
+	// A cache of metricDimensions keyed by a unique identifier formed by a concatenation of its values:
 	// e.g. { "foo/barOK": { "serviceName": "foo", "operation": "/bar", "status_code": "OK" }}
-	metricKeyToDimensions map[metricKey]pdata.AttributeMap
+	// The cache holds at most DimensionsCacheSize keys, evicting the least recently used ones.
+	metricKeyToDimensions *simplelru.LRU
+
+	// The dimensions of the keys evicted from metricKeyToDimensions since the last flush.
+	// Their metrics are emitted once more, then dropped unless the key was seen again.
+	evictedDimensions map[metricKey]metricDimensions
+
+	// Closed on shutdown to stop the periodic flush of the metrics, if configured.
+	done chan struct{}
+	wg   sync.WaitGroup
 }
 
 func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer consumer.Traces) (*processorImp, error) {
@@ -104,20 +131,38 @@ func newProcessor(logger *zap.Logger, config config.Processor, nextConsumer cons
 		return nil, err
 	}
 
-	return &processorImp{
-		logger:                logger,
-		config:                *pConfig,
-		startTime:             time.Now(),
-		callSum:               make(map[metricKey]int64),
-		latencyBounds:         bounds,
-		latencySum:            make(map[metricKey]float64),
-		latencyCount:          make(map[metricKey]uint64),
-		latencyBucketCounts:   make(map[metricKey][]uint64),
-		latencyExemplarsData:  make(map[metricKey][]exemplarData),
-		nextConsumer:          nextConsumer,
-		dimensions:            pConfig.Dimensions,
-		metricKeyToDimensions: make(map[metricKey]pdata.AttributeMap),
-	}, nil
+	if err := validateAggregationTemporality(pConfig.AggregationTemporality); err != nil {
+		return nil, err
+	}
+
+	if pConfig.MetricsFlushInterval < 0 {
+		return nil, fmt.Errorf("invalid metrics_flush_interval %v, must not be negative", pConfig.MetricsFlushInterval)
+	}
+
+	p := &processorImp{
+		logger:               logger,
+		config:               *pConfig,
+		startTime:            time.Now(),
+		metricStartTimes:     make(map[metricKey]time.Time),
+		callSum:              make(map[metricKey]int64),
+		latencyBounds:        bounds,
+		latencySum:           make(map[metricKey]float64),
+		latencyCount:         make(map[metricKey]uint64),
+		latencyBucketCounts:  make(map[metricKey][]uint64),
+		latencyExemplarsData: make(map[metricKey][]exemplarData),
+		nextConsumer:         nextConsumer,
+		dimensions:           pConfig.Dimensions,
+		resourceAttributes:   pConfig.ResourceAttributes,
+		evictedDimensions:    make(map[metricKey]metricDimensions),
+	}
+
+	cache, err := simplelru.NewLRU(pConfig.DimensionsCacheSize, p.onDimensionsEvicted)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dimensions_cache_size %d: %w", pConfig.DimensionsCacheSize, err)
+	}
+	p.metricKeyToDimensions = cache
+
+	return p, nil
 }
 
 // durationToMillis converts the given duration to the number of milliseconds it represents.
@@ -163,6 +208,16 @@ func validateDimensions(dimensions []Dimension) error {
 	return nil
 }
 
+// validateAggregationTemporality checks the aggregation temporality is one of the supported values.
+func validateAggregationTemporality(temporality string) error {
+	switch temporality {
+	case "", delta, cumulative:
+		return nil
+	default:
+		return fmt.Errorf("invalid aggregation_temporality %q, must be one of %q or %q", temporality, cumulative, delta)
+	}
+}
+
 // Start implements the component.Component interface.
 func (p *processorImp) Start(ctx context.Context, host component.Host) error {
 	p.logger.Info("Starting spanmetricsprocessor")
@@ -193,14 +248,53 @@ func (p *processorImp) Start(ctx context.Context, host component.Host) error {
 		return fmt.Errorf("failed to find metrics exporter: '%s'; please configure metrics_exporter from one of: %+v",
 			p.config.MetricsExporter, availableMetricsExporters)
 	}
+	if p.config.MetricsFlushInterval > 0 {
+		p.startFlushLoop()
+	}
 	p.logger.Info("Started spanmetricsprocessor")
 	return nil
 }
 
 // Shutdown implements the component.Component interface.
+// If the metrics are flushed periodically, the metrics accumulated since the last flush are flushed.
 func (p *processorImp) Shutdown(ctx context.Context) error {
 	p.logger.Info("Shutting down spanmetricsprocessor")
-	return nil
+	if p.done == nil {
+		return nil
+	}
+	close(p.done)
+	p.wg.Wait()
+	return p.flushMetrics(ctx)
+}
+
+// startFlushLoop starts flushing the metrics every MetricsFlushInterval until shutdown.
+func (p *processorImp) startFlushLoop() {
+	p.done = make(chan struct{})
+	ticker := time.NewTicker(p.config.MetricsFlushInterval)
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := p.flushMetrics(context.Background()); err != nil {
+					p.logger.Error("Failed to flush metrics", zap.Error(err))
+				}
+			case <-p.done:
+				return
+			}
+		}
+	}()
+}
+
+// flushMetrics builds the metrics and sends them to the metrics exporter, unless there are none.
+func (p *processorImp) flushMetrics(ctx context.Context) error {
+	m := p.buildMetrics()
+	if m.MetricCount() == 0 {
+		return nil
+	}
+	return p.metricsExporter.ConsumeMetrics(ctx, *m)
 }
 
 // Capabilities implements the consumer interface.
@@ -209,16 +303,17 @@ func (p *processorImp) Capabilities() consumer.Capabilities {
 }
 
 // ConsumeTraces implements the consumer.Traces interface.
-// It aggregates the trace data to generate metrics, forwarding these metrics to the discovered metrics exporter.
+// It aggregates the trace data to generate metrics, forwarding these metrics to the discovered metrics exporter,
+// unless the metrics are flushed periodically.
 // The original input trace data will be forwarded to the next consumer, unmodified.
 func (p *processorImp) ConsumeTraces(ctx context.Context, traces pdata.Traces) error {
 	p.aggregateMetrics(traces)
 
-	m := p.buildMetrics()
-
 	// Firstly, export metrics to avoid being impacted by downstream trace processor errors/latency.
-	if err := p.metricsExporter.ConsumeMetrics(ctx, *m); err != nil {
-		return err
+	if p.config.MetricsFlushInterval <= 0 {
+		if err := p.flushMetrics(ctx); err != nil {
+			return err
+		}
 	}
 
 	// Forward trace data unmodified.
@@ -227,61 +322,136 @@ func (p *processorImp) ConsumeTraces(ctx context.Context, traces pdata.Traces) e
 
 // buildMetrics collects the computed raw metrics data, builds the metrics object and
 // writes the raw metrics data into the metrics object.
+// The metrics are grouped by resource, based on the configured resource attributes.
 func (p *processorImp) buildMetrics() *pdata.Metrics {
 	m := pdata.NewMetrics()
-	ilm := m.ResourceMetrics().AppendEmpty().InstrumentationLibraryMetrics().AppendEmpty()
-	ilm.InstrumentationLibrary().SetName("spanmetricsprocessor")
+	ilms := make(map[resourceKey]pdata.InstrumentationLibraryMetrics)
 
-	p.lock.RLock()
-	p.collectCallMetrics(ilm)
-	p.collectLatencyMetrics(ilm)
-	p.lock.RUnlock()
+	p.lock.Lock()
+	now := time.Now()
+	p.collectCallMetrics(m, ilms, now)
+	p.collectLatencyMetrics(m, ilms, now)
+	p.resetState(now)
+	p.lock.Unlock()
 
 	return &m
 }
 
+// getInstrumentationLibraryMetrics returns the instrumentation library metrics of the resource
+// of the given dimensions, appending a new resource to the metrics on first use.
+func getInstrumentationLibraryMetrics(m pdata.Metrics, ilms map[resourceKey]pdata.InstrumentationLibraryMetrics, dims metricDimensions) pdata.InstrumentationLibraryMetrics {
+	if ilm, ok := ilms[dims.resourceKey]; ok {
+		return ilm
+	}
+	rm := m.ResourceMetrics().AppendEmpty()
+	dims.resourceAttrs.CopyTo(rm.Resource().Attributes())
+	ilm := rm.InstrumentationLibraryMetrics().AppendEmpty()
+	ilm.InstrumentationLibrary().SetName("spanmetricsprocessor")
+	ilms[dims.resourceKey] = ilm
+	return ilm
+}
+
+// resetState drops the state that was flushed and is no longer needed. With delta temporality
+// all accumulated metrics are reset and the next data points start at the given flush time.
+// With cumulative temporality only the metrics of the keys evicted from the dimensions cache
+// are dropped.
+func (p *processorImp) resetState(now time.Time) {
+	if p.config.GetAggregationTemporality() == pdata.MetricAggregationTemporalityDelta {
+		p.callSum = make(map[metricKey]int64)
+		p.latencyCount = make(map[metricKey]uint64)
+		p.latencySum = make(map[metricKey]float64)
+		p.latencyBucketCounts = make(map[metricKey][]uint64)
+		p.latencyExemplarsData = make(map[metricKey][]exemplarData)
+		p.metricStartTimes = make(map[metricKey]time.Time)
+		p.startTime = now
+	} else {
+		for key := range p.evictedDimensions {
+			if p.metricKeyToDimensions.Contains(key) {
+				continue
+			}
+			delete(p.callSum, key)
+			delete(p.latencyCount, key)
+			delete(p.latencySum, key)
+			delete(p.latencyBucketCounts, key)
+			delete(p.latencyExemplarsData, key)
+			delete(p.metricStartTimes, key)
+		}
+	}
+	p.evictedDimensions = make(map[metricKey]metricDimensions)
+}
+
 // collectLatencyMetrics collects the raw latency metrics, writing the data
-// into the given instrumentation library metrics.
-func (p *processorImp) collectLatencyMetrics(ilm pdata.InstrumentationLibraryMetrics) {
+// into the instrumentation library metrics of each metric's resource.
+func (p *processorImp) collectLatencyMetrics(m pdata.Metrics, ilms map[resourceKey]pdata.InstrumentationLibraryMetrics, now time.Time) {
 	for key := range p.latencyCount {
-		mLatency := ilm.Metrics().AppendEmpty()
+		dims := p.getDimensions(key)
+		mLatency := getInstrumentationLibraryMetrics(m, ilms, dims).Metrics().AppendEmpty()
 		mLatency.SetDataType(pdata.MetricDataTypeHistogram)
 		mLatency.SetName("latency")
-		mLatency.Histogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		mLatency.Histogram().SetAggregationTemporality(p.config.GetAggregationTemporality())
 
-		timestamp := pdata.NewTimestampFromTime(time.Now())
+		timestamp := pdata.NewTimestampFromTime(now)
 
 		dpLatency := mLatency.Histogram().DataPoints().AppendEmpty()
-		dpLatency.SetStartTimestamp(pdata.NewTimestampFromTime(p.startTime))
+		dpLatency.SetStartTimestamp(p.startTimestamp(key))
 		dpLatency.SetTimestamp(timestamp)
 		dpLatency.SetExplicitBounds(p.latencyBounds)
-		dpLatency.SetBucketCounts(p.latencyBucketCounts[key])
+		// Copy the bucket counts so the emitted metric doesn't change with the next spans.
+		bucketCounts := make([]uint64, len(p.latencyBucketCounts[key]))
+		copy(bucketCounts, p.latencyBucketCounts[key])
+		dpLatency.SetBucketCounts(bucketCounts)
 		dpLatency.SetCount(p.latencyCount[key])
 		dpLatency.SetSum(p.latencySum[key])
 
 		setLatencyExemplars(p.latencyExemplarsData[key], timestamp, dpLatency.Exemplars())
 
-		p.metricKeyToDimensions[key].CopyTo(dpLatency.Attributes())
+		dims.attrs.CopyTo(dpLatency.Attributes())
 	}
 }
 
 // collectCallMetrics collects the raw call count metrics, writing the data
-// into the given instrumentation library metrics.
-func (p *processorImp) collectCallMetrics(ilm pdata.InstrumentationLibraryMetrics) {
+// into the instrumentation library metrics of each metric's resource.
+func (p *processorImp) collectCallMetrics(m pdata.Metrics, ilms map[resourceKey]pdata.InstrumentationLibraryMetrics, now time.Time) {
 	for key := range p.callSum {
-		mCalls := ilm.Metrics().AppendEmpty()
+		dims := p.getDimensions(key)
+		mCalls := getInstrumentationLibraryMetrics(m, ilms, dims).Metrics().AppendEmpty()
 		mCalls.SetDataType(pdata.MetricDataTypeSum)
 		mCalls.SetName("calls_total")
 		mCalls.Sum().SetIsMonotonic(true)
-		mCalls.Sum().SetAggregationTemporality(pdata.MetricAggregationTemporalityCumulative)
+		mCalls.Sum().SetAggregationTemporality(p.config.GetAggregationTemporality())
 
 		dpCalls := mCalls.Sum().DataPoints().AppendEmpty()
-		dpCalls.SetStartTimestamp(pdata.NewTimestampFromTime(p.startTime))
-		dpCalls.SetTimestamp(pdata.NewTimestampFromTime(time.Now()))
+		dpCalls.SetStartTimestamp(p.startTimestamp(key))
+		dpCalls.SetTimestamp(pdata.NewTimestampFromTime(now))
 		dpCalls.SetIntVal(p.callSum[key])
 
-		p.metricKeyToDimensions[key].CopyTo(dpCalls.Attributes())
+		dims.attrs.CopyTo(dpCalls.Attributes())
+	}
+}
+
+// startTimestamp returns the starting time of the data points of the metric key.
+func (p *processorImp) startTimestamp(key metricKey) pdata.Timestamp {
+	if p.config.GetAggregationTemporality() == pdata.MetricAggregationTemporalityDelta {
+		return pdata.NewTimestampFromTime(p.startTime)
 	}
+	return pdata.NewTimestampFromTime(p.metricStartTimes[key])
+}
+
+// getDimensions returns the dimensions of the metric key, from the cache or,
+// if the key was evicted since the last flush, from the evicted dimensions.
+// The lookup doesn't count as a use of the key for the cache eviction.
+func (p *processorImp) getDimensions(key metricKey) metricDimensions {
+	if dims, ok := p.metricKeyToDimensions.Peek(key); ok {
+		return dims.(metricDimensions)
+	}
+	return p.evictedDimensions[key]
+}
+
+// onDimensionsEvicted keeps the dimensions of an evicted metric key until the next flush
+// and records the eviction.
+func (p *processorImp) onDimensionsEvicted(key interface{}, value interface{}) {
+	p.evictedDimensions[key.(metricKey)] = value.(metricDimensions)
+	stats.Record(context.Background(), mDimensionsCacheEvictions.M(1))
 }
 
 // aggregateMetrics aggregates the raw metrics from the input trace data.
@@ -303,27 +473,37 @@ func (p *processorImp) aggregateMetrics(traces pdata.Traces) {
 }
 
 func (p *processorImp) aggregateMetricsForServiceSpans(rspans pdata.ResourceSpans, serviceName string) {
+	resourceAttr := rspans.Resource().Attributes()
+	resKey := buildResourceKey(p.resourceAttributes, resourceAttr)
+
 	ilsSlice := rspans.InstrumentationLibrarySpans()
 	for j := 0; j < ilsSlice.Len(); j++ {
 		ils := ilsSlice.At(j)
 		spans := ils.Spans()
 		for k := 0; k < spans.Len(); k++ {
 			span := spans.At(k)
-			p.aggregateMetricsForSpan(serviceName, span, rspans.Resource().Attributes())
+			p.aggregateMetricsForSpan(serviceName, span, resourceAttr, resKey)
 		}
 	}
 }
 
-func (p *processorImp) aggregateMetricsForSpan(serviceName string, span pdata.Span, resourceAttr pdata.AttributeMap) {
+func (p *processorImp) aggregateMetricsForSpan(serviceName string, span pdata.Span, resourceAttr pdata.AttributeMap, resKey resourceKey) {
 	latencyInMilliseconds := float64(span.EndTimestamp()-span.StartTimestamp()) / float64(time.Millisecond.Nanoseconds())
 
 	// Binary search to find the latencyInMilliseconds bucket index.
 	index := sort.SearchFloat64s(p.latencyBounds, latencyInMilliseconds)
 
 	key := buildKey(serviceName, span, p.dimensions, resourceAttr)
+	if resKey != "" {
+		// Metrics with the same dimensions but of different resources are distinct.
+		key = metricKey(string(resKey) + metricKeySeparator + string(key))
+	}
 
 	p.lock.Lock()
-	p.cache(serviceName, span, key, resourceAttr)
+	p.cache(serviceName, span, key, resourceAttr, resKey)
+	if _, ok := p.metricStartTimes[key]; !ok {
+		p.metricStartTimes[key] = time.Now()
+	}
 	p.updateCallMetrics(key)
 	p.updateLatencyMetrics(key, latencyInMilliseconds, index)
 	p.updateLatencyExemplars(key, latencyInMilliseconds, index, span.TraceID())
@@ -401,6 +581,45 @@ func buildKey(serviceName string, span pdata.Span, optionalDims []Dimension, res
 	return k
 }
 
+// buildResourceKey builds the resource key from the configured resource attributes found in
+// the resource attributes, or their default values.
+//
+// The resource key is a concatenation of the attribute names and values, delimited by a null character.
+// The names are included since a missing attribute without default is omitted.
+func buildResourceKey(resourceAttrDims []Dimension, resourceAttrs pdata.AttributeMap) resourceKey {
+	var resourceKeyBuilder strings.Builder
+	for _, d := range resourceAttrDims {
+		if v, ok := getResourceAttributeValue(d, resourceAttrs); ok {
+			concatDimensionValue(&resourceKeyBuilder, d.Name, resourceKeyBuilder.Len() > 0)
+			concatDimensionValue(&resourceKeyBuilder, v.AsString(), true)
+		}
+	}
+	return resourceKey(resourceKeyBuilder.String())
+}
+
+// buildResourceAttrs builds the attributes of the resource of the metrics from the configured resource attributes.
+func buildResourceAttrs(resourceAttrDims []Dimension, resourceAttrs pdata.AttributeMap) pdata.AttributeMap {
+	attrs := pdata.NewAttributeMap()
+	for _, d := range resourceAttrDims {
+		if v, ok := getResourceAttributeValue(d, resourceAttrs); ok {
+			attrs.Upsert(d.Name, v)
+		}
+	}
+	return attrs
+}
+
+// getResourceAttributeValue gets the value of the configured resource attribute from the resource attributes,
+// falling back to the configured default value if provided.
+func getResourceAttributeValue(d Dimension, resourceAttr pdata.AttributeMap) (v pdata.AttributeValue, ok bool) {
+	if attr, exists := resourceAttr.Get(d.Name); exists {
+		return attr, true
+	}
+	if d.Default != nil {
+		return pdata.NewAttributeValueString(*d.Default), true
+	}
+	return v, ok
+}
+
 // getDimensionValue gets the dimension value for the given configured dimension.
 // It searches through the span's attributes first, being the more specific;
 // falling back to searching in resource attributes if it can't be found in the span.
@@ -423,12 +642,17 @@ func getDimensionValue(d Dimension, spanAttr pdata.AttributeMap, resourceAttr pd
 	return v, ok
 }
 
-// cache the dimension key-value map for the metricKey if there is a cache miss.
+// cache the dimensions for the metricKey if there is a cache miss.
+// A cache hit marks the metricKey as recently used.
 // This enables a lookup of the dimension key-value map when constructing the metric like so:
 //   LabelsMap().InitFromMap(p.metricKeyToDimensions[key])
-func (p *processorImp) cache(serviceName string, span pdata.Span, k metricKey, resourceAttrs pdata.AttributeMap) {
-	if _, ok := p.metricKeyToDimensions[k]; !ok {
-		p.metricKeyToDimensions[k] = p.buildDimensionKVs(serviceName, span, p.dimensions, resourceAttrs)
+func (p *processorImp) cache(serviceName string, span pdata.Span, k metricKey, resourceAttrs pdata.AttributeMap, resKey resourceKey) {
+	if _, ok := p.metricKeyToDimensions.Get(k); !ok {
+		p.metricKeyToDimensions.Add(k, metricDimensions{
+			resourceKey:   resKey,
+			resourceAttrs: buildResourceAttrs(p.resourceAttributes, resourceAttrs),
+			attrs:         p.buildDimensionKVs(serviceName, span, p.dimensions, resourceAttrs),
+		})
 	}
 }
 
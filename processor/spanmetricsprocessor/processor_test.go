@@ -20,6 +20,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/hashicorp/golang-lru/simplelru"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -202,7 +203,7 @@ func TestProcessorConsumeTracesErrors(t *testing.T) {
 			tcon := &mocks.TracesConsumer{}
 			tcon.On("ConsumeTraces", mock.Anything, mock.Anything).Return(tc.consumeTracesErr)
 
-			p := newProcessorImp(mexp, tcon, nil)
+			p := newProcessorImp(mexp, tcon, nil, cumulative)
 
 			traces := buildSampleTrace()
 
@@ -217,17 +218,66 @@ func TestProcessorConsumeTracesErrors(t *testing.T) {
 }
 
 func TestProcessorConsumeTraces(t *testing.T) {
+	for _, tc := range []struct {
+		name                   string
+		aggregationTemporality string
+		wantTemporality        pdata.MetricAggregationTemporality
+		wantSecondCallCount    int64
+	}{
+		{
+			name:                   "cumulative temporality keeps accumulating",
+			aggregationTemporality: cumulative,
+			wantTemporality:        pdata.MetricAggregationTemporalityCumulative,
+			wantSecondCallCount:    2,
+		},
+		{
+			name:                   "delta temporality resets after each flush",
+			aggregationTemporality: delta,
+			wantTemporality:        pdata.MetricAggregationTemporalityDelta,
+			wantSecondCallCount:    1,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			mexp := &mocks.MetricsExporter{}
+			tcon := &mocks.TracesConsumer{}
+
+			var consumed []pdata.Metrics
+			mexp.On("ConsumeMetrics", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				consumed = append(consumed, args.Get(1).(pdata.Metrics))
+			})
+			tcon.On("ConsumeTraces", mock.Anything, mock.Anything).Return(nil)
+
+			defaultNullValue := "defaultNullValue"
+			p := newProcessorImp(mexp, tcon, &defaultNullValue, tc.aggregationTemporality)
+
+			traces := buildSampleTrace()
+
+			// Test
+			ctx := metadata.NewIncomingContext(context.Background(), nil)
+			err := p.ConsumeTraces(ctx, traces)
+			require.NoError(t, err)
+			err = p.ConsumeTraces(ctx, traces)
+
+			// Verify
+			assert.NoError(t, err)
+			require.Len(t, consumed, 2)
+			verifyConsumeMetricsInput(consumed[0], t, tc.wantTemporality, 1)
+			verifyConsumeMetricsInput(consumed[1], t, tc.wantTemporality, tc.wantSecondCallCount)
+		})
+	}
+}
+
+func TestMetricKeyCache(t *testing.T) {
 	// Prepare
 	mexp := &mocks.MetricsExporter{}
 	tcon := &mocks.TracesConsumer{}
 
-	mexp.On("ConsumeMetrics", mock.Anything, mock.MatchedBy(func(input pdata.Metrics) bool {
-		return verifyConsumeMetricsInput(input, t)
-	})).Return(nil)
+	mexp.On("ConsumeMetrics", mock.Anything, mock.Anything).Return(nil)
 	tcon.On("ConsumeTraces", mock.Anything, mock.Anything).Return(nil)
 
 	defaultNullValue := "defaultNullValue"
-	p := newProcessorImp(mexp, tcon, &defaultNullValue)
+	p := newProcessorImp(mexp, tcon, &defaultNullValue, cumulative)
 
 	traces := buildSampleTrace()
 
@@ -235,37 +285,220 @@ func TestProcessorConsumeTraces(t *testing.T) {
 	ctx := metadata.NewIncomingContext(context.Background(), nil)
 	err := p.ConsumeTraces(ctx, traces)
 
-	// Verify
-	assert.NoError(t, err)
+	// Validate
+	require.NoError(t, err)
+
+	origKeyCache := make(map[interface{}]interface{})
+	for _, k := range p.metricKeyToDimensions.Keys() {
+		origKeyCache[k], _ = p.metricKeyToDimensions.Peek(k)
+	}
+	err = p.ConsumeTraces(ctx, traces)
+	require.NoError(t, err)
+
+	keyCache := make(map[interface{}]interface{})
+	for _, k := range p.metricKeyToDimensions.Keys() {
+		keyCache[k], _ = p.metricKeyToDimensions.Peek(k)
+	}
+	assert.Equal(t, origKeyCache, keyCache)
 }
 
-func TestMetricKeyCache(t *testing.T) {
+func TestMetricKeyCacheEviction(t *testing.T) {
 	// Prepare
 	mexp := &mocks.MetricsExporter{}
 	tcon := &mocks.TracesConsumer{}
 
-	mexp.On("ConsumeMetrics", mock.Anything, mock.Anything).Return(nil)
 	tcon.On("ConsumeTraces", mock.Anything, mock.Anything).Return(nil)
 
 	defaultNullValue := "defaultNullValue"
-	p := newProcessorImp(mexp, tcon, &defaultNullValue)
+	p := newProcessorImp(mexp, tcon, &defaultNullValue, cumulative)
+	cache, err := simplelru.NewLRU(2, p.onDimensionsEvicted)
+	require.NoError(t, err)
+	p.metricKeyToDimensions = cache
 
-	traces := buildSampleTrace()
+	// The sample trace has 3 distinct metric keys, so the least recently used one is evicted
+	// but its metrics are still emitted, with their dimensions, by the flush of the same call.
+	mexp.On("ConsumeMetrics", mock.Anything, mock.MatchedBy(func(input pdata.Metrics) bool {
+		return verifyConsumeMetricsInput(input, t, pdata.MetricAggregationTemporalityCumulative, 1)
+	})).Return(nil).Once()
+
+	ctx := metadata.NewIncomingContext(context.Background(), nil)
+	err = p.ConsumeTraces(ctx, buildSampleTrace())
+	require.NoError(t, err)
+
+	// Verify
+	assert.Equal(t, 2, p.metricKeyToDimensions.Len())
+	assert.Len(t, p.callSum, 2, "metrics of the evicted key should be dropped after the flush")
+	assert.Len(t, p.latencyCount, 2, "metrics of the evicted key should be dropped after the flush")
+	assert.Empty(t, p.evictedDimensions)
+	mexp.AssertExpectations(t)
+}
+
+func TestMetricKeyStartTimeAfterEviction(t *testing.T) {
+	// Prepare
+	defaultNullValue := "defaultNullValue"
+	p := newProcessorImp(&mocks.MetricsExporter{}, &mocks.TracesConsumer{}, &defaultNullValue, cumulative)
+	cache, err := simplelru.NewLRU(1, p.onDimensionsEvicted)
+	require.NoError(t, err)
+	p.metricKeyToDimensions = cache
+
+	callStartTimes := func(m *pdata.Metrics) map[int64][]pdata.Timestamp {
+		startTimes := make(map[int64][]pdata.Timestamp)
+		metrics := m.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			if metrics.At(i).Name() == "calls_total" {
+				dp := metrics.At(i).Sum().DataPoints().At(0)
+				startTimes[dp.IntVal()] = append(startTimes[dp.IntVal()], dp.StartTimestamp())
+			}
+		}
+		return startTimes
+	}
+
+	// Test
+	// Only the last of the 3 metric keys of the sample trace is kept after the flush.
+	p.aggregateMetrics(buildSampleTrace())
+	first := p.buildMetrics()
+	firstStartTimes := callStartTimes(first)
+	require.Len(t, firstStartTimes[1], 3)
+	flushTime := first.ResourceMetrics().At(0).InstrumentationLibraryMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).Timestamp()
+
+	p.aggregateMetrics(buildSampleTrace())
+	secondStartTimes := callStartTimes(p.buildMetrics())
+
+	// Verify
+	// The dropped keys restart from 0, starting after the previous flush.
+	require.Len(t, secondStartTimes[1], 2)
+	for _, startTime := range secondStartTimes[1] {
+		assert.GreaterOrEqual(t, uint64(startTime), uint64(flushTime))
+	}
+	// The kept key keeps accumulating since its first start time.
+	require.Len(t, secondStartTimes[2], 1)
+	assert.Contains(t, firstStartTimes[1], secondStartTimes[2][0])
+	assert.Less(t, uint64(secondStartTimes[2][0]), uint64(flushTime))
+}
+
+func TestProcessorResourceAttributes(t *testing.T) {
+	// Prepare
+	mexp := &mocks.MetricsExporter{}
+	tcon := &mocks.TracesConsumer{}
+
+	var consumed []pdata.Metrics
+	mexp.On("ConsumeMetrics", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		consumed = append(consumed, args.Get(1).(pdata.Metrics))
+	})
+	tcon.On("ConsumeTraces", mock.Anything, mock.Anything).Return(nil)
+
+	defaultNullValue := "defaultNullValue"
+	defaultCluster := "cluster-1"
+	p := newProcessorImp(mexp, tcon, &defaultNullValue, cumulative)
+	p.resourceAttributes = []Dimension{
+		{conventions.AttributeServiceName, nil},
+		{regionResourceAttrName, nil},
+		// Add a default value for a resource attribute that doesn't exist in the resource.
+		{"cluster", &defaultCluster},
+		// Leave the default value unset to test that this attribute should not be added to the resource.
+		{notInSpanAttrName1, nil},
+	}
 
 	// Test
 	ctx := metadata.NewIncomingContext(context.Background(), nil)
-	err := p.ConsumeTraces(ctx, traces)
+	err := p.ConsumeTraces(ctx, buildSampleTrace())
 
-	// Validate
+	// Verify
 	require.NoError(t, err)
+	require.Len(t, consumed, 1)
+
+	rms := consumed[0].ResourceMetrics()
+	require.Equal(t, 2, rms.Len(), "Should be one resource for each of service-a and service-b")
 
-	origKeyCache := make(map[metricKey]pdata.AttributeMap)
-	for k, v := range p.metricKeyToDimensions {
-		origKeyCache[k] = v
+	wantMetricCounts := map[string]int{
+		// 2 call count and 2 latency metrics for the server and client spans.
+		"service-a": 4,
+		"service-b": 2,
 	}
-	err = p.ConsumeTraces(ctx, traces)
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		attrs := rm.Resource().Attributes()
+		assert.Equal(t, 3, attrs.Len())
+
+		serviceName, ok := attrs.Get(conventions.AttributeServiceName)
+		require.True(t, ok)
+		region, ok := attrs.Get(regionResourceAttrName)
+		require.True(t, ok)
+		assert.Equal(t, sampleRegion, region.StringVal())
+		cluster, ok := attrs.Get("cluster")
+		require.True(t, ok)
+		assert.Equal(t, defaultCluster, cluster.StringVal())
+
+		require.Equal(t, 1, rm.InstrumentationLibraryMetrics().Len())
+		ilm := rm.InstrumentationLibraryMetrics().At(0)
+		assert.Equal(t, "spanmetricsprocessor", ilm.InstrumentationLibrary().Name())
+		assert.Equal(t, wantMetricCounts[serviceName.StringVal()], ilm.Metrics().Len())
+		delete(wantMetricCounts, serviceName.StringVal())
+	}
+	assert.Empty(t, wantMetricCounts)
+}
+
+func TestProcessorMetricsFlushInterval(t *testing.T) {
+	// Prepare
+	mexp := &mocks.MetricsExporter{}
+	tcon := &mocks.TracesConsumer{}
+
+	flushed := make(chan pdata.Metrics, 10)
+	mexp.On("ConsumeMetrics", mock.Anything, mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+		flushed <- args.Get(1).(pdata.Metrics)
+	})
+	tcon.On("ConsumeTraces", mock.Anything, mock.Anything).Return(nil)
+
+	defaultNullValue := "defaultNullValue"
+	p := newProcessorImp(mexp, tcon, &defaultNullValue, delta)
+	p.config.MetricsFlushInterval = time.Millisecond
+
+	// Test
+	ctx := metadata.NewIncomingContext(context.Background(), nil)
+	err := p.ConsumeTraces(ctx, buildSampleTrace())
+	require.NoError(t, err)
+
+	// Verify the metrics are only sent to the exporter by the flush loop.
+	mexp.AssertNotCalled(t, "ConsumeMetrics", mock.Anything, mock.Anything)
+	tcon.AssertCalled(t, "ConsumeTraces", mock.Anything, mock.Anything)
+
+	p.startFlushLoop()
+	select {
+	case m := <-flushed:
+		verifyConsumeMetricsInput(m, t, pdata.MetricAggregationTemporalityDelta, 1)
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "metrics were not flushed")
+	}
+
+	// The delta metrics were reset by the flush, so nothing is left to flush on shutdown.
+	require.NoError(t, p.Shutdown(context.Background()))
+	assert.Empty(t, flushed)
+}
+
+func TestProcessorShutdownFlushesMetrics(t *testing.T) {
+	// Prepare
+	mexp := &mocks.MetricsExporter{}
+	tcon := &mocks.TracesConsumer{}
+
+	mexp.On("ConsumeMetrics", mock.Anything, mock.Anything).Return(nil)
+	tcon.On("ConsumeTraces", mock.Anything, mock.Anything).Return(nil)
+
+	defaultNullValue := "defaultNullValue"
+	p := newProcessorImp(mexp, tcon, &defaultNullValue, cumulative)
+	p.config.MetricsFlushInterval = time.Hour
+	p.startFlushLoop()
+
+	ctx := metadata.NewIncomingContext(context.Background(), nil)
+	err := p.ConsumeTraces(ctx, buildSampleTrace())
 	require.NoError(t, err)
-	assert.Equal(t, origKeyCache, p.metricKeyToDimensions)
+	mexp.AssertNotCalled(t, "ConsumeMetrics", mock.Anything, mock.Anything)
+
+	// Test
+	err = p.Shutdown(context.Background())
+
+	// Verify
+	require.NoError(t, err)
+	mexp.AssertNumberOfCalls(t, "ConsumeMetrics", 1)
 }
 
 func BenchmarkProcessorConsumeTraces(b *testing.B) {
@@ -277,7 +510,7 @@ func BenchmarkProcessorConsumeTraces(b *testing.B) {
 	tcon.On("ConsumeTraces", mock.Anything, mock.Anything).Return(nil)
 
 	defaultNullValue := "defaultNullValue"
-	p := newProcessorImp(mexp, tcon, &defaultNullValue)
+	p := newProcessorImp(mexp, tcon, &defaultNullValue, cumulative)
 
 	traces := buildSampleTrace()
 
@@ -288,14 +521,16 @@ func BenchmarkProcessorConsumeTraces(b *testing.B) {
 	}
 }
 
-func newProcessorImp(mexp *mocks.MetricsExporter, tcon *mocks.TracesConsumer, defaultNullValue *string) *processorImp {
+func newProcessorImp(mexp *mocks.MetricsExporter, tcon *mocks.TracesConsumer, defaultNullValue *string, temporality string) *processorImp {
 	defaultNotInSpanAttrVal := "defaultNotInSpanAttrVal"
-	return &processorImp{
+	p := &processorImp{
 		logger:          zap.NewNop(),
+		config:          Config{AggregationTemporality: temporality},
 		metricsExporter: mexp,
 		nextConsumer:    tcon,
 
 		startTime:            time.Now(),
+		metricStartTimes:     make(map[metricKey]time.Time),
 		callSum:              make(map[metricKey]int64),
 		latencySum:           make(map[metricKey]float64),
 		latencyCount:         make(map[metricKey]uint64),
@@ -318,13 +553,16 @@ func newProcessorImp(mexp *mocks.MetricsExporter, tcon *mocks.TracesConsumer, de
 			// Add a resource attribute to test "process" attributes like IP, host, region, cluster, etc.
 			{regionResourceAttrName, nil},
 		},
-		metricKeyToDimensions: make(map[metricKey]pdata.AttributeMap),
+		evictedDimensions: make(map[metricKey]metricDimensions),
 	}
+	// Ignore the error as the size is valid.
+	p.metricKeyToDimensions, _ = simplelru.NewLRU(defaultDimensionsCacheSize, p.onDimensionsEvicted)
+	return p
 }
 
 // verifyConsumeMetricsInput verifies the input of the ConsumeMetrics call from this processor.
 // This is the best point to verify the computed metrics from spans are as expected.
-func verifyConsumeMetricsInput(input pdata.Metrics, t *testing.T) bool {
+func verifyConsumeMetricsInput(input pdata.Metrics, t *testing.T, wantTemporality pdata.MetricAggregationTemporality, wantCallCount int64) bool {
 	require.Equal(t, 6, input.MetricCount(),
 		"Should be 3 for each of call count and latency. Each group of 3 metrics is made of: "+
 			"service-a (server kind) -> service-a (client kind) -> service-b (service kind)",
@@ -347,14 +585,14 @@ func verifyConsumeMetricsInput(input pdata.Metrics, t *testing.T) bool {
 		assert.Equal(t, "calls_total", m.At(mi).Name())
 
 		data := m.At(mi).Sum()
-		assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, data.AggregationTemporality())
+		assert.Equal(t, wantTemporality, data.AggregationTemporality())
 		assert.True(t, data.IsMonotonic())
 
 		dps := data.DataPoints()
 		require.Equal(t, 1, dps.Len())
 
 		dp := dps.At(0)
-		assert.Equal(t, int64(1), dp.IntVal(), "There should only be one metric per Service/operation/kind combination")
+		assert.Equal(t, wantCallCount, dp.IntVal(), "There should only be one metric per Service/operation/kind combination")
 		assert.NotZero(t, dp.StartTimestamp(), "StartTimestamp should be set")
 		assert.NotZero(t, dp.Timestamp(), "Timestamp should be set")
 
@@ -367,13 +605,13 @@ func verifyConsumeMetricsInput(input pdata.Metrics, t *testing.T) bool {
 		assert.Equal(t, "latency", m.At(mi).Name())
 
 		data := m.At(mi).Histogram()
-		assert.Equal(t, pdata.MetricAggregationTemporalityCumulative, data.AggregationTemporality())
+		assert.Equal(t, wantTemporality, data.AggregationTemporality())
 
 		dps := data.DataPoints()
 		require.Equal(t, 1, dps.Len())
 
 		dp := dps.At(0)
-		assert.Equal(t, sampleLatency, dp.Sum(), "Should be a single 11ms latency measurement")
+		assert.Equal(t, sampleLatency*float64(wantCallCount), dp.Sum(), "Should be a single 11ms latency measurement per call")
 		assert.NotZero(t, dp.Timestamp(), "Timestamp should be set")
 
 		// Verify bucket counts. Firstly, find the bucket index where the 11ms latency should belong in.
@@ -389,7 +627,7 @@ func verifyConsumeMetricsInput(input pdata.Metrics, t *testing.T) bool {
 		for bi := 0; bi < len(dp.BucketCounts()); bi++ {
 			wantBucketCount = 0
 			if bi == foundLatencyIndex {
-				wantBucketCount = 1
+				wantBucketCount = uint64(wantCallCount)
 			}
 			assert.Equal(t, wantBucketCount, dp.BucketCounts()[bi])
 		}
@@ -437,9 +675,10 @@ func verifyMetricLabels(dp metricDataPoint, t *testing.T, seenMetricIDs map[metr
 }
 
 // buildSampleTrace builds the following trace:
-//   service-a/ping (server) ->
-//     service-a/ping (client) ->
-//       service-b/ping (server)
+//
+//	service-a/ping (server) ->
+//	  service-a/ping (client) ->
+//	    service-b/ping (server)
 func buildSampleTrace() pdata.Traces {
 	traces := pdata.NewTraces()
 
@@ -608,6 +847,48 @@ func TestBuildKeyWithDimensions(t *testing.T) {
 	}
 }
 
+func TestBuildResourceKey(t *testing.T) {
+	defaultCluster := "cluster-1"
+	resourceAttrDims := []Dimension{
+		{"region", nil},
+		{"zone", nil},
+		{"cluster", &defaultCluster},
+	}
+	for _, tc := range []struct {
+		name            string
+		resourceAttrMap map[string]pdata.AttributeValue
+		wantKey         string
+	}{
+		{
+			name:    "only default values",
+			wantKey: "cluster\u0000cluster-1",
+		},
+		{
+			name: "attributes found in resource",
+			resourceAttrMap: map[string]pdata.AttributeValue{
+				"region":  pdata.NewAttributeValueString("us-east-1"),
+				"zone":    pdata.NewAttributeValueString("a"),
+				"cluster": pdata.NewAttributeValueString("cluster-2"),
+			},
+			wantKey: "region\u0000us-east-1\u0000zone\u0000a\u0000cluster\u0000cluster-2",
+		},
+		{
+			name: "same value of different attributes",
+			resourceAttrMap: map[string]pdata.AttributeValue{
+				"zone": pdata.NewAttributeValueString("us-east-1"),
+			},
+			wantKey: "zone\u0000us-east-1\u0000cluster\u0000cluster-1",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			k := buildResourceKey(resourceAttrDims, pdata.NewAttributeMapFromMap(tc.resourceAttrMap))
+			assert.Equal(t, resourceKey(tc.wantKey), k)
+		})
+	}
+
+	assert.Equal(t, resourceKey(""), buildResourceKey(nil, pdata.NewAttributeMap()))
+}
+
 func TestProcessorDuplicateDimensions(t *testing.T) {
 	// Prepare
 	factory := NewFactory()
@@ -624,6 +905,51 @@ func TestProcessorDuplicateDimensions(t *testing.T) {
 	assert.Nil(t, p)
 }
 
+func TestProcessorInvalidConfig(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		configure   func(cfg *Config)
+		expectedErr string
+	}{
+		{
+			name: "invalid aggregation temporality",
+			configure: func(cfg *Config) {
+				cfg.AggregationTemporality = "AGGREGATION_TEMPORALITY_UNKNOWN"
+			},
+			expectedErr: `invalid aggregation_temporality "AGGREGATION_TEMPORALITY_UNKNOWN", must be one of "AGGREGATION_TEMPORALITY_CUMULATIVE" or "AGGREGATION_TEMPORALITY_DELTA"`,
+		},
+		{
+			name: "invalid dimensions cache size",
+			configure: func(cfg *Config) {
+				cfg.DimensionsCacheSize = 0
+			},
+			expectedErr: "invalid dimensions_cache_size 0: Must provide a positive size",
+		},
+		{
+			name: "negative metrics flush interval",
+			configure: func(cfg *Config) {
+				cfg.MetricsFlushInterval = -time.Second
+			},
+			expectedErr: "invalid metrics_flush_interval -1s, must not be negative",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig().(*Config)
+			tc.configure(cfg)
+
+			// Test
+			next := new(consumertest.TracesSink)
+			p, err := newProcessor(zap.NewNop(), cfg, next)
+
+			// Verify
+			assert.EqualError(t, err, tc.expectedErr)
+			assert.Nil(t, p)
+		})
+	}
+}
+
 func TestValidateDimensions(t *testing.T) {
 	for _, tc := range []struct {
 		name        string
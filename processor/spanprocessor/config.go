@@ -15,7 +15,11 @@
 package spanprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/spanprocessor"
 
 import (
+	"errors"
+	"fmt"
+
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/model/pdata"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/processor/filterconfig"
 )
@@ -35,6 +39,12 @@ type Config struct {
 	// Note: The field name is `Rename` to avoid collision with the Name() method
 	// from config.NamedEntity
 	Rename Name `mapstructure:"name"`
+
+	// SetStatus specifies the status to set on the spans.
+	SetStatus *Status `mapstructure:"status"`
+
+	// SetKind specifies the kind to set on the spans.
+	SetKind *Kind `mapstructure:"kind"`
 }
 
 // Name specifies the attributes to use to re-name a span.
@@ -80,9 +90,82 @@ type ToAttributes struct {
 	BreakAfterMatch bool `mapstructure:"break_after_match"`
 }
 
+// Status specifies the status to set on the spans matching its include/exclude properties.
+type Status struct {
+	// MatchConfig selects the spans whose status is set, out of the spans selected by
+	// the include/exclude properties of the processor. The status of all these spans
+	// is set if neither include nor exclude is specified.
+	filterconfig.MatchConfig `mapstructure:",squash"`
+
+	// Code is the status code to set. One of "Unset", "Ok" or "Error".
+	Code string `mapstructure:"code"`
+
+	// Description is the status message to set. It can only be used with the "Error" code.
+	Description string `mapstructure:"description"`
+}
+
+// Kind specifies the kind to set on the spans matching its include/exclude properties.
+type Kind struct {
+	// MatchConfig selects the spans whose kind is set, out of the spans selected by
+	// the include/exclude properties of the processor. The kind of all these spans
+	// is set if neither include nor exclude is specified.
+	filterconfig.MatchConfig `mapstructure:",squash"`
+
+	// Value is the kind to set. One of "Unspecified", "Internal", "Server", "Client",
+	// "Producer" or "Consumer".
+	Value string `mapstructure:"value"`
+}
+
+const (
+	statusCodeUnset = "Unset"
+	statusCodeOk    = "Ok"
+	statusCodeError = "Error"
+)
+
+var statusCodes = map[string]pdata.StatusCode{
+	statusCodeUnset: pdata.StatusCodeUnset,
+	statusCodeOk:    pdata.StatusCodeOk,
+	statusCodeError: pdata.StatusCodeError,
+}
+
+const (
+	spanKindUnspecified = "Unspecified"
+	spanKindInternal    = "Internal"
+	spanKindServer      = "Server"
+	spanKindClient      = "Client"
+	spanKindProducer    = "Producer"
+	spanKindConsumer    = "Consumer"
+)
+
+var spanKinds = map[string]pdata.SpanKind{
+	spanKindUnspecified: pdata.SpanKindUnspecified,
+	spanKindInternal:    pdata.SpanKindInternal,
+	spanKindServer:      pdata.SpanKindServer,
+	spanKindClient:      pdata.SpanKindClient,
+	spanKindProducer:    pdata.SpanKindProducer,
+	spanKindConsumer:    pdata.SpanKindConsumer,
+}
+
+var errStatusDescriptionWithoutError = errors.New("\"description\" in \"status:\" can only be set with the \"Error\" code")
+
 var _ config.Processor = (*Config)(nil)
 
 // Validate checks if the processor configuration is valid
 func (cfg *Config) Validate() error {
+	if cfg.SetStatus != nil {
+		if _, ok := statusCodes[cfg.SetStatus.Code]; !ok {
+			return fmt.Errorf("invalid \"code\" %q in \"status:\", must be one of %q, %q or %q",
+				cfg.SetStatus.Code, statusCodeUnset, statusCodeOk, statusCodeError)
+		}
+		if cfg.SetStatus.Description != "" && cfg.SetStatus.Code != statusCodeError {
+			return errStatusDescriptionWithoutError
+		}
+	}
+	if cfg.SetKind != nil {
+		if _, ok := spanKinds[cfg.SetKind.Value]; !ok {
+			return fmt.Errorf("invalid \"value\" %q in \"kind:\", must be one of %q, %q, %q, %q, %q or %q",
+				cfg.SetKind.Value, spanKindUnspecified, spanKindInternal, spanKindServer, spanKindClient, spanKindProducer, spanKindConsumer)
+		}
+	}
 	return nil
 }
@@ -87,6 +87,38 @@ func TestLoadConfig(t *testing.T) {
 			},
 		},
 	})
+
+	p4 := cfg.Processors[config.NewComponentIDWithName("span", "set_status")]
+	assert.Equal(t, p4, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName("span", "set_status")),
+		SetStatus: &Status{
+			MatchConfig: filterconfig.MatchConfig{
+				Include: &filterconfig.MatchProperties{
+					Config: *createMatchConfig(filterset.Regexp),
+					Attributes: []filterconfig.Attribute{
+						{Key: "http.status_code", Value: "^5[0-9]{2}$"},
+					},
+				},
+			},
+			Code:        "Error",
+			Description: "server error",
+		},
+	})
+
+	p5 := cfg.Processors[config.NewComponentIDWithName("span", "set_kind")]
+	assert.Equal(t, p5, &Config{
+		ProcessorSettings: config.NewProcessorSettings(config.NewComponentIDWithName("span", "set_kind")),
+		SetKind: &Kind{
+			MatchConfig: filterconfig.MatchConfig{
+				Include: &filterconfig.MatchProperties{
+					Config:     *createMatchConfig(filterset.Strict),
+					Services:   []string{"frontend"},
+					Attributes: []filterconfig.Attribute{{Key: "http.method"}},
+				},
+			},
+			Value: "Server",
+		},
+	})
 }
 
 func createMatchConfig(matchType filterset.MatchType) *filterset.Config {
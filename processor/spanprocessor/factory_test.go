@@ -94,6 +94,45 @@ func TestFactory_CreateTracesProcessor_InvalidConfig(t *testing.T) {
 	}
 }
 
+// TestFactory_InvalidConfig_Validate ensures the invalid status and kind are
+// rejected when validating the configuration.
+func TestFactory_InvalidConfig_Validate(t *testing.T) {
+	factory := NewFactory()
+
+	testcases := []struct {
+		name   string
+		status *Status
+		kind   *Kind
+		err    error
+	}{
+		{
+			name:   "invalid_status_code",
+			status: &Status{Code: "Failed"},
+			err:    fmt.Errorf(`invalid "code" "Failed" in "status:", must be one of "Unset", "Ok" or "Error"`),
+		},
+		{
+			name:   "status_description_without_error",
+			status: &Status{Code: statusCodeOk, Description: "all good"},
+			err:    errStatusDescriptionWithoutError,
+		},
+		{
+			name: "invalid_kind",
+			kind: &Kind{Value: "Browser"},
+			err:  fmt.Errorf(`invalid "value" "Browser" in "kind:", must be one of "Unspecified", "Internal", "Server", "Client", "Producer" or "Consumer"`),
+		},
+	}
+
+	for _, test := range testcases {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := factory.CreateDefaultConfig().(*Config)
+			cfg.SetStatus = test.status
+			cfg.SetKind = test.kind
+
+			assert.EqualValues(t, test.err, cfg.Validate())
+		})
+	}
+}
+
 func TestFactory_CreateMetricProcessor(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
@@ -31,6 +31,10 @@ type spanProcessor struct {
 	toAttributeRules []toAttributeRule
 	include          filterspan.Matcher
 	exclude          filterspan.Matcher
+	statusInclude    filterspan.Matcher
+	statusExclude    filterspan.Matcher
+	kindInclude      filterspan.Matcher
+	kindExclude      filterspan.Matcher
 }
 
 // toAttributeRule is the compiled equivalent of config.ToAttributes field.
@@ -59,6 +63,24 @@ func newSpanProcessor(config Config) (*spanProcessor, error) {
 		exclude: exclude,
 	}
 
+	if config.SetStatus != nil {
+		if sp.statusInclude, err = filterspan.NewMatcher(config.SetStatus.Include); err != nil {
+			return nil, err
+		}
+		if sp.statusExclude, err = filterspan.NewMatcher(config.SetStatus.Exclude); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.SetKind != nil {
+		if sp.kindInclude, err = filterspan.NewMatcher(config.SetKind.Include); err != nil {
+			return nil, err
+		}
+		if sp.kindExclude, err = filterspan.NewMatcher(config.SetKind.Exclude); err != nil {
+			return nil, err
+		}
+	}
+
 	// Compile ToAttributes regexp and extract attributes names.
 	if config.Rename.ToAttributes != nil {
 		for _, pattern := range config.Rename.ToAttributes.Rules {
@@ -97,6 +119,8 @@ func (sp *spanProcessor) processTraces(_ context.Context, td pdata.Traces) (pdat
 				}
 				sp.processFromAttributes(s)
 				sp.processToAttributes(s)
+				sp.processSetStatus(s, resource, library)
+				sp.processSetKind(s, resource, library)
 			}
 		}
 	}
@@ -220,3 +244,32 @@ func (sp *spanProcessor) processToAttributes(span pdata.Span) {
 		}
 	}
 }
+
+func (sp *spanProcessor) processSetStatus(span pdata.Span, resource pdata.Resource, library pdata.InstrumentationLibrary) {
+	cfg := sp.config.SetStatus
+	if cfg == nil {
+		return
+	}
+
+	// The status is only set on the spans matching the properties of the status section.
+	if filterspan.SkipSpan(sp.statusInclude, sp.statusExclude, span, resource, library) {
+		return
+	}
+
+	span.Status().SetCode(statusCodes[cfg.Code])
+	span.Status().SetMessage(cfg.Description)
+}
+
+func (sp *spanProcessor) processSetKind(span pdata.Span, resource pdata.Resource, library pdata.InstrumentationLibrary) {
+	cfg := sp.config.SetKind
+	if cfg == nil {
+		return
+	}
+
+	// The kind is only set on the spans matching the properties of the kind section.
+	if filterspan.SkipSpan(sp.kindInclude, sp.kindExclude, span, resource, library) {
+		return
+	}
+
+	span.SetKind(spanKinds[cfg.Value])
+}
@@ -594,3 +594,145 @@ func TestSpanProcessor_skipSpan(t *testing.T) {
 		runIndividualTestCase(t, tc, tp)
 	}
 }
+
+func TestSpanProcessor_setStatus(t *testing.T) {
+	testCases := []struct {
+		name            string
+		serviceName     string
+		statusCode      int64
+		wantCode        pdata.StatusCode
+		wantDescription string
+	}{
+		{
+			name:            "server-error",
+			serviceName:     "banks",
+			statusCode:      503,
+			wantCode:        pdata.StatusCodeError,
+			wantDescription: "server error",
+		},
+		{
+			name:        "success",
+			serviceName: "banks",
+			statusCode:  200,
+			wantCode:    pdata.StatusCodeUnset,
+		},
+		{
+			name:        "excluded-service",
+			serviceName: "health",
+			statusCode:  500,
+			wantCode:    pdata.StatusCodeUnset,
+		},
+	}
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Exclude = &filterconfig.MatchProperties{
+		Config:   *createMatchConfig(filterset.Strict),
+		Services: []string{"health"},
+	}
+	oCfg.SetStatus = &Status{
+		MatchConfig: filterconfig.MatchConfig{
+			Include: &filterconfig.MatchProperties{
+				Config: *createMatchConfig(filterset.Regexp),
+				Attributes: []filterconfig.Attribute{
+					{Key: "http.status_code", Value: "^5[0-9]{2}$"},
+				},
+			},
+		},
+		Code:        statusCodeError,
+		Description: "server error",
+	}
+	tp, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), oCfg, consumertest.NewNop())
+	require.Nil(t, err)
+	require.NotNil(t, tp)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			td := generateTraceData(tc.serviceName, "span", map[string]pdata.AttributeValue{
+				"http.status_code": pdata.NewAttributeValueInt(tc.statusCode),
+			})
+			assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+			status := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0).Status()
+			assert.Equal(t, tc.wantCode, status.Code())
+			assert.Equal(t, tc.wantDescription, status.Message())
+		})
+	}
+}
+
+func TestSpanProcessor_setStatusOk(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.SetStatus = &Status{Code: statusCodeOk}
+	tp, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), oCfg, consumertest.NewNop())
+	require.Nil(t, err)
+	require.NotNil(t, tp)
+
+	td := generateTraceData("", "span", nil)
+	span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+	span.Status().SetCode(pdata.StatusCodeError)
+	span.Status().SetMessage("failed")
+	assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	assert.Equal(t, pdata.StatusCodeOk, span.Status().Code())
+	assert.Equal(t, "", span.Status().Message())
+}
+
+func TestSpanProcessor_setKind(t *testing.T) {
+	testCases := []struct {
+		name        string
+		serviceName string
+		attrs       map[string]pdata.AttributeValue
+		wantKind    pdata.SpanKind
+	}{
+		{
+			name:        "http-request",
+			serviceName: "frontend",
+			attrs:       map[string]pdata.AttributeValue{"http.method": pdata.NewAttributeValueString("GET")},
+			wantKind:    pdata.SpanKindServer,
+		},
+		{
+			name:        "no-http-method",
+			serviceName: "frontend",
+			wantKind:    pdata.SpanKindUnspecified,
+		},
+		{
+			name:        "excluded-service",
+			serviceName: "health",
+			attrs:       map[string]pdata.AttributeValue{"http.method": pdata.NewAttributeValueString("GET")},
+			wantKind:    pdata.SpanKindUnspecified,
+		},
+	}
+
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	oCfg := cfg.(*Config)
+	oCfg.Exclude = &filterconfig.MatchProperties{
+		Config:   *createMatchConfig(filterset.Strict),
+		Services: []string{"health"},
+	}
+	oCfg.SetKind = &Kind{
+		MatchConfig: filterconfig.MatchConfig{
+			Include: &filterconfig.MatchProperties{
+				Config:     *createMatchConfig(filterset.Strict),
+				Attributes: []filterconfig.Attribute{{Key: "http.method"}},
+			},
+		},
+		Value: spanKindServer,
+	}
+	tp, err := factory.CreateTracesProcessor(context.Background(), componenttest.NewNopProcessorCreateSettings(), oCfg, consumertest.NewNop())
+	require.Nil(t, err)
+	require.NotNil(t, tp)
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			td := generateTraceData(tc.serviceName, "span", tc.attrs)
+			assert.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+			span := td.ResourceSpans().At(0).InstrumentationLibrarySpans().At(0).Spans().At(0)
+			assert.Equal(t, tc.wantKind, span.Kind())
+		})
+	}
+}
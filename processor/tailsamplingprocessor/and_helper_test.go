@@ -0,0 +1,53 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAndHelper(t *testing.T) {
+	cfg := AndCfg{
+		SubPolicyCfg: []SubPolicyCfg{
+			{
+				Name:                "test-and-policy-1",
+				Type:                NumericAttribute,
+				NumericAttributeCfg: NumericAttributeCfg{Key: "key1", MinValue: 50, MaxValue: 100},
+			},
+			{
+				Name:          "test-and-policy-2",
+				Type:          TraceState,
+				TraceStateCfg: TraceStateCfg{Key: "key2", Values: []string{"value1", "value2"}},
+			},
+		},
+	}
+
+	and, err := getNewAndPolicy(zap.NewNop(), cfg)
+	require.NoError(t, err)
+	require.NotNil(t, and)
+}
+
+func TestAndHelperErrors(t *testing.T) {
+	_, err := getNewAndPolicy(zap.NewNop(), AndCfg{})
+	require.EqualError(t, err, "and policy requires at least one sub-policy")
+
+	_, err = getNewAndPolicy(zap.NewNop(), AndCfg{
+		SubPolicyCfg: []SubPolicyCfg{{Name: "test-and-policy-1", Type: "unknown"}},
+	})
+	require.EqualError(t, err, `failed to create and sub-policy "test-and-policy-1": unknown sampling policy type unknown`)
+}
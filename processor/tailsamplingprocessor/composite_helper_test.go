@@ -35,7 +35,7 @@ func TestCompositeHelper(t *testing.T) {
 				Type: Composite,
 				CompositeCfg: CompositeCfg{
 					MaxTotalSpansPerSecond: 1000,
-					PolicyOrder:            []string{"test-composite-policy-1", "test-composite-policy-2", "test-composite-policy-3", "test-composite-policy-4", "test-composite-policy-5"},
+					PolicyOrder:            []string{"test-composite-policy-1", "test-composite-policy-2", "test-composite-policy-3", "test-composite-policy-4", "test-composite-policy-5", "test-composite-policy-6", "test-composite-policy-7", "test-composite-policy-8"},
 					SubPolicyCfg: []SubPolicyCfg{
 						{
 							Name:                "test-composite-policy-1",
@@ -59,6 +59,21 @@ func TestCompositeHelper(t *testing.T) {
 						{
 							Name: "test-composite-policy-5",
 						},
+						{
+							Name:         "test-composite-policy-6",
+							Type:         SpanCount,
+							SpanCountCfg: SpanCountCfg{MinSpans: 2, MaxSpans: 20},
+						},
+						{
+							Name:       "test-composite-policy-7",
+							Type:       Latency,
+							LatencyCfg: LatencyCfg{ThresholdMs: 5000, UpperThresholdMs: 10000},
+						},
+						{
+							Name:                "test-composite-policy-8",
+							Type:                BooleanAttribute,
+							BooleanAttributeCfg: BooleanAttributeCfg{Key: "key4", Value: true},
+						},
 					},
 					RateAllocation: []RateAllocationCfg{
 						{
@@ -84,3 +99,26 @@ func TestCompositeHelper(t *testing.T) {
 	require.NoError(t, e)
 	// TBD add more assertions
 }
+
+func TestGetSubPolicyEvaluator(t *testing.T) {
+	for _, cfg := range []SubPolicyCfg{
+		{Type: AlwaysSample},
+		{Type: NumericAttribute, NumericAttributeCfg: NumericAttributeCfg{Key: "key1", MinValue: 50, MaxValue: 100}},
+		{Type: StringAttribute, StringAttributeCfg: StringAttributeCfg{Key: "key2", Values: []string{"value1"}}},
+		{Type: RateLimiting, RateLimitingCfg: RateLimitingCfg{SpansPerSecond: 10}},
+		{Type: Latency, LatencyCfg: LatencyCfg{ThresholdMs: 5000, UpperThresholdMs: 10000}},
+		{Type: StatusCode, StatusCodeCfg: StatusCodeCfg{StatusCodes: []string{"ERROR"}}},
+		{Type: SpanCount, SpanCountCfg: SpanCountCfg{MinSpans: 2}},
+		{Type: BooleanAttribute, BooleanAttributeCfg: BooleanAttributeCfg{Key: "key4", Value: true}},
+	} {
+		t.Run(string(cfg.Type), func(t *testing.T) {
+			policyCfg := cfg
+			evaluator, err := getSubPolicyEvaluator(zap.NewNop(), &policyCfg)
+			require.NoError(t, err)
+			require.NotNil(t, evaluator)
+		})
+	}
+
+	_, err := getSubPolicyEvaluator(zap.NewNop(), &SubPolicyCfg{Type: "unknown"})
+	require.Error(t, err)
+}
@@ -15,6 +15,7 @@
 package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
 
 import (
+	"errors"
 	"time"
 
 	"go.opentelemetry.io/collector/config"
@@ -26,7 +27,8 @@ type PolicyType string
 const (
 	// AlwaysSample samples all traces, typically used for debugging.
 	AlwaysSample PolicyType = "always_sample"
-	// Latency sample traces that are longer than a given threshold.
+	// Latency sample traces that are longer than a given threshold, and optionally
+	// shorter than an upper threshold.
 	Latency PolicyType = "latency"
 	// NumericAttribute sample traces that have a given numeric attribute in a specified
 	// range, e.g.: attribute "http.status_code" >= 399 and <= 999.
@@ -42,6 +44,17 @@ const (
 	RateLimiting PolicyType = "rate_limiting"
 	// Composite allows defining a composite policy, combining the other policies in one
 	Composite PolicyType = "composite"
+	// SpanCount sample traces that have a number of spans in a specified range.
+	SpanCount PolicyType = "span_count"
+	// BooleanAttribute sample traces that have a given boolean attribute set to
+	// the specified value.
+	BooleanAttribute PolicyType = "boolean_attribute"
+	// TraceState sample traces with specified values by the given key in the
+	// W3C tracestate of a span.
+	TraceState PolicyType = "trace_state"
+	// And allows defining an and policy, sampling traces sampled by all the
+	// policies it combines.
+	And PolicyType = "and"
 )
 
 // SubPolicyCfg holds the common configuration to all policies under composite policy.
@@ -60,6 +73,12 @@ type SubPolicyCfg struct {
 	LatencyCfg LatencyCfg `mapstructure:"latency"`
 	// Configs for status code filter sampling policy evaluator.
 	StatusCodeCfg StatusCodeCfg `mapstructure:"status_code"`
+	// Configs for span count filter sampling policy evaluator.
+	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
+	// Configs for boolean attribute filter sampling policy evaluator.
+	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
+	// Configs for trace state filter sampling policy evaluator.
+	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
 }
 
 // CompositeCfg holds the configurable settings to create a composite
@@ -71,6 +90,12 @@ type CompositeCfg struct {
 	RateAllocation         []RateAllocationCfg `mapstructure:"rate_allocation"`
 }
 
+// AndCfg holds the configurable settings to create an and sampling policy
+// evaluator.
+type AndCfg struct {
+	SubPolicyCfg []SubPolicyCfg `mapstructure:"and_sub_policy"`
+}
+
 // RateAllocationCfg  used within composite policy
 type RateAllocationCfg struct {
 	Policy  string `mapstructure:"policy"`
@@ -97,6 +122,14 @@ type PolicyCfg struct {
 	RateLimitingCfg RateLimitingCfg `mapstructure:"rate_limiting"`
 	// Configs for defining composite policy
 	CompositeCfg CompositeCfg `mapstructure:"composite"`
+	// Configs for span count filter sampling policy evaluator.
+	SpanCountCfg SpanCountCfg `mapstructure:"span_count"`
+	// Configs for boolean attribute filter sampling policy evaluator.
+	BooleanAttributeCfg BooleanAttributeCfg `mapstructure:"boolean_attribute"`
+	// Configs for trace state filter sampling policy evaluator.
+	TraceStateCfg TraceStateCfg `mapstructure:"trace_state"`
+	// Configs for defining and policy
+	AndCfg AndCfg `mapstructure:"and"`
 }
 
 // LatencyCfg holds the configurable settings to create a latency filter sampling policy
@@ -104,6 +137,9 @@ type PolicyCfg struct {
 type LatencyCfg struct {
 	// ThresholdMs in milliseconds.
 	ThresholdMs int64 `mapstructure:"threshold_ms"`
+	// UpperThresholdMs in milliseconds, traces longer than it are not sampled.
+	// Zero means no upper threshold.
+	UpperThresholdMs int64 `mapstructure:"upper_threshold_ms"`
 }
 
 // NumericAttributeCfg holds the configurable settings to create a numeric attribute filter
@@ -117,6 +153,35 @@ type NumericAttributeCfg struct {
 	MaxValue int64 `mapstructure:"max_value"`
 }
 
+// SpanCountCfg holds the configurable settings to create a span count filter sampling
+// policy evaluator.
+type SpanCountCfg struct {
+	// MinSpans is the minimum number of spans of the trace to be considered a match.
+	MinSpans int64 `mapstructure:"min_spans"`
+	// MaxSpans is the maximum number of spans of the trace to be considered a match.
+	// Zero means no maximum.
+	MaxSpans int64 `mapstructure:"max_spans"`
+}
+
+// BooleanAttributeCfg holds the configurable settings to create a boolean attribute filter
+// sampling policy evaluator.
+type BooleanAttributeCfg struct {
+	// Tag that the filter is going to be matching against.
+	Key string `mapstructure:"key"`
+	// Value indicate the bool value, either true or false to use when matching against attribute values.
+	Value bool `mapstructure:"value"`
+}
+
+// TraceStateCfg holds the configurable settings to create a trace state filter sampling
+// policy evaluator.
+type TraceStateCfg struct {
+	// Key of the trace state list-member that the filter is going to be matching against.
+	Key string `mapstructure:"key"`
+	// Values indicate the set of values to use when matching against the trace state value.
+	// The values must not be empty nor longer than 256 characters.
+	Values []string `mapstructure:"values"`
+}
+
 // ProbabilisticCfg holds the configurable settings to create a probabilistic
 // sampling policy evaluator.
 type ProbabilisticCfg struct {
@@ -177,4 +242,54 @@ type Config struct {
 	// PolicyCfgs sets the tail-based sampling policy which makes a sampling decision
 	// for a given trace when requested.
 	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+	// DecisionCache keeps the sampling decisions of traces that were already removed
+	// from memory, so spans arriving later for them inherit the original decision.
+	DecisionCache DecisionCacheConfig `mapstructure:"decision_cache"`
+	// PolicySource loads the policies from a file or an HTTP endpoint, replacing PolicyCfgs,
+	// and reloads them while the processor is running.
+	PolicySource *PolicySourceConfig `mapstructure:"policy_source"`
+}
+
+// PolicySourceConfig holds the configurable settings of the source the policies are loaded from.
+// Exactly one of File or Endpoint must be set.
+type PolicySourceConfig struct {
+	// File is the path of a YAML file holding the policies.
+	File string `mapstructure:"file"`
+	// Endpoint is an HTTP URL serving the policies as YAML.
+	Endpoint string `mapstructure:"endpoint"`
+	// ReloadInterval is how often the policies are read from the source. Defaults to 30s.
+	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+}
+
+var (
+	errPolicySourceNotSet        = errors.New("policy_source requires either a file or an endpoint")
+	errPolicySourceBothSet       = errors.New("policy_source cannot have both a file and an endpoint")
+	errNegativeReloadInterval    = errors.New("policy_source reload_interval must be a non-negative duration")
+	errPolicySourceHasNoPolicies = errors.New("policy source has no policies")
+)
+
+// Validate checks if the processor configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.PolicySource == nil {
+		return nil
+	}
+	switch {
+	case cfg.PolicySource.File == "" && cfg.PolicySource.Endpoint == "":
+		return errPolicySourceNotSet
+	case cfg.PolicySource.File != "" && cfg.PolicySource.Endpoint != "":
+		return errPolicySourceBothSet
+	case cfg.PolicySource.ReloadInterval < 0:
+		return errNegativeReloadInterval
+	}
+	return nil
+}
+
+// DecisionCacheConfig holds the configurable settings of the decision cache.
+type DecisionCacheConfig struct {
+	// SampledCacheSize is the maximum number of sampled trace IDs kept on the cache.
+	// Zero disables the cache for sampled traces.
+	SampledCacheSize int `mapstructure:"sampled_cache_size"`
+	// NonSampledCacheSize is the maximum number of not sampled trace IDs kept on the cache.
+	// Zero disables the cache for not sampled traces.
+	NonSampledCacheSize int `mapstructure:"non_sampled_cache_size"`
 }
@@ -43,6 +43,7 @@ func TestLoadConfig(t *testing.T) {
 			DecisionWait:            10 * time.Second,
 			NumTraces:               100,
 			ExpectedNewTracesPerSec: 10,
+			DecisionCache:           DecisionCacheConfig{SampledCacheSize: 1000, NonSampledCacheSize: 10000},
 			PolicyCfgs: []PolicyCfg{
 				{
 					Name: "test-policy-1",
@@ -51,7 +52,7 @@ func TestLoadConfig(t *testing.T) {
 				{
 					Name:       "test-policy-2",
 					Type:       Latency,
-					LatencyCfg: LatencyCfg{ThresholdMs: 5000},
+					LatencyCfg: LatencyCfg{ThresholdMs: 5000, UpperThresholdMs: 10000},
 				},
 				{
 					Name:                "test-policy-3",
@@ -78,6 +79,39 @@ func TestLoadConfig(t *testing.T) {
 					Type:            RateLimiting,
 					RateLimitingCfg: RateLimitingCfg{SpansPerSecond: 35},
 				},
+				{
+					Name:         "test-policy-8",
+					Type:         SpanCount,
+					SpanCountCfg: SpanCountCfg{MinSpans: 2, MaxSpans: 20},
+				},
+				{
+					Name:                "test-policy-9",
+					Type:                BooleanAttribute,
+					BooleanAttributeCfg: BooleanAttributeCfg{Key: "key4", Value: true},
+				},
+				{
+					Name:          "test-policy-10",
+					Type:          TraceState,
+					TraceStateCfg: TraceStateCfg{Key: "key3", Values: []string{"value1", "value2"}},
+				},
+				{
+					Name: "and-policy-1",
+					Type: And,
+					AndCfg: AndCfg{
+						SubPolicyCfg: []SubPolicyCfg{
+							{
+								Name:                "test-and-policy-1",
+								Type:                NumericAttribute,
+								NumericAttributeCfg: NumericAttributeCfg{Key: "key1", MinValue: 50, MaxValue: 100},
+							},
+							{
+								Name:               "test-and-policy-2",
+								Type:               StringAttribute,
+								StringAttributeCfg: StringAttributeCfg{Key: "key2", Values: []string{"value1", "value2"}},
+							},
+						},
+					},
+				},
 				{
 					Name: "composite-policy-1",
 					Type: Composite,
@@ -115,3 +149,64 @@ func TestLoadConfig(t *testing.T) {
 			},
 		})
 }
+
+func TestLoadPolicySourceConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Processors[factory.Type()] = factory
+
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "tail_sampling_policy_source_config.yaml"), factories)
+	require.Nil(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Equal(t, cfg.Processors[config.NewComponentID(typeStr)],
+		&Config{
+			ProcessorSettings: config.NewProcessorSettings(config.NewComponentID(typeStr)),
+			DecisionWait:      10 * time.Second,
+			NumTraces:         100,
+			PolicySource: &PolicySourceConfig{
+				Endpoint:       "http://localhost:8080/policies.yaml",
+				ReloadInterval: time.Minute,
+			},
+		})
+}
+
+func TestValidatePolicySource(t *testing.T) {
+	tests := []struct {
+		name   string
+		source *PolicySourceConfig
+		err    error
+	}{
+		{
+			name: "no policy source",
+		},
+		{
+			name:   "file",
+			source: &PolicySourceConfig{File: "policies.yaml"},
+		},
+		{
+			name:   "neither file nor endpoint",
+			source: &PolicySourceConfig{ReloadInterval: time.Minute},
+			err:    errPolicySourceNotSet,
+		},
+		{
+			name:   "file and endpoint",
+			source: &PolicySourceConfig{File: "policies.yaml", Endpoint: "http://localhost:8080"},
+			err:    errPolicySourceBothSet,
+		},
+		{
+			name:   "negative reload interval",
+			source: &PolicySourceConfig{File: "policies.yaml", ReloadInterval: -time.Second},
+			err:    errNegativeReloadInterval,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.PolicySource = tt.source
+			assert.Equal(t, tt.err, cfg.Validate())
+		})
+	}
+}
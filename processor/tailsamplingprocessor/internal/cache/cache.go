@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cache defines bounded caches of trace IDs used to remember the
+// sampling decisions taken for traces that are no longer held in memory.
+package cache // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
+
+import (
+	"sync"
+
+	"github.com/golang/groupcache/lru"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// Cache holds a bounded set of trace IDs. Implementations must be safe for
+// concurrent use.
+type Cache interface {
+	// Get returns true if the given id is on the cache.
+	Get(id pdata.TraceID) bool
+	// Put adds the given id to the cache, evicting the least recently used id
+	// if the cache is full.
+	Put(id pdata.TraceID)
+	// Len returns the number of ids currently on the cache.
+	Len() int
+}
+
+// New creates a Cache holding up to size trace IDs, evicting the least recently
+// used ones first. A cache with a size of zero or less holds nothing.
+func New(size int) Cache {
+	if size <= 0 {
+		return nopCache{}
+	}
+	return &lruCache{lru: lru.New(size)}
+}
+
+var _ Cache = (*lruCache)(nil)
+
+type lruCache struct {
+	// mu protects lru, which is not safe for concurrent use. Get also needs the
+	// write lock as it updates the recency of the id.
+	mu  sync.Mutex
+	lru *lru.Cache
+}
+
+func (c *lruCache) Get(id pdata.TraceID) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.lru.Get(id)
+	return ok
+}
+
+func (c *lruCache) Put(id pdata.TraceID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.lru.Add(id, nil)
+}
+
+func (c *lruCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.lru.Len()
+}
+
+var _ Cache = nopCache{}
+
+type nopCache struct{}
+
+func (nopCache) Get(pdata.TraceID) bool { return false }
+
+func (nopCache) Put(pdata.TraceID) {}
+
+func (nopCache) Len() int { return 0 }
@@ -0,0 +1,72 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := New(2)
+
+	id1 := pdata.NewTraceID([16]byte{1})
+	id2 := pdata.NewTraceID([16]byte{2})
+	id3 := pdata.NewTraceID([16]byte{3})
+
+	c.Put(id1)
+	c.Put(id2)
+	assert.True(t, c.Get(id1))
+
+	// id2 is now the least recently used id.
+	c.Put(id3)
+	assert.Equal(t, 2, c.Len())
+	assert.True(t, c.Get(id1))
+	assert.False(t, c.Get(id2))
+	assert.True(t, c.Get(id3))
+}
+
+func TestCacheDisabled(t *testing.T) {
+	for _, size := range []int{0, -1} {
+		c := New(size)
+		id := pdata.NewTraceID([16]byte{1})
+		c.Put(id)
+		assert.False(t, c.Get(id))
+		assert.Equal(t, 0, c.Len())
+	}
+}
+
+func TestCacheConcurrentAccess(t *testing.T) {
+	c := New(100)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i byte) {
+			defer wg.Done()
+			for j := byte(0); j < 50; j++ {
+				id := pdata.NewTraceID([16]byte{i, j})
+				c.Put(id)
+				c.Get(id)
+			}
+		}(byte(i))
+	}
+	wg.Wait()
+
+	assert.Equal(t, 100, c.Len())
+}
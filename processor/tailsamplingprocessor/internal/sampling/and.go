@@ -0,0 +1,74 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// And evaluator and its internal data
+type And struct {
+	// the subpolicy evaluators
+	subpolicies []PolicyEvaluator
+	logger      *zap.Logger
+}
+
+var _ PolicyEvaluator = (*And)(nil)
+
+// NewAnd creates a policy evaluator that samples traces sampled by all its subpolicies.
+func NewAnd(
+	logger *zap.Logger,
+	subpolicies []PolicyEvaluator,
+) PolicyEvaluator {
+
+	return &And{
+		subpolicies: subpolicies,
+		logger:      logger,
+	}
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (c *And) OnLateArrivingSpans(earlyDecision Decision, spans []*pdata.Span) error {
+	c.logger.Debug("Triggering action for late arriving spans in and filter")
+	for _, sub := range c.subpolicies {
+		if err := sub.OnLateArrivingSpans(earlyDecision, spans); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (c *And) Evaluate(traceID pdata.TraceID, trace *TraceData) (Decision, error) {
+	// The policy iterates over all sub-policies and returns Sampled if all sub-policies
+	// sampled the trace, stopping at the first sub-policy which did not.
+	for _, sub := range c.subpolicies {
+		decision, err := sub.Evaluate(traceID, trace)
+		if err != nil {
+			return Unspecified, err
+		}
+
+		switch decision {
+		case Sampled, InvertSampled:
+		default:
+			return NotSampled, nil
+		}
+	}
+	return Sampled, nil
+}
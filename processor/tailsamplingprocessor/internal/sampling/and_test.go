@@ -0,0 +1,94 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+func TestAndEvaluatorSampled(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"}, false, 0, false)
+	n2 := NewStringAttributeFilter(zap.NewNop(), "attribute_name", []string{"attribute_value"}, false, 0, false)
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{n1, n2})
+
+	trace := newTraceStringAttrs(map[string]pdata.AttributeValue{"name": pdata.NewAttributeValueString("value")}, "attribute_name", "attribute_value")
+	decision, err := and.Evaluate(pdata.NewTraceID([16]byte{1}), trace)
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestAndEvaluatorNotSampled(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"}, false, 0, false)
+	n2 := NewStringAttributeFilter(zap.NewNop(), "attribute_name", []string{"attribute_value"}, false, 0, false)
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{n1, n2})
+
+	trace := newTraceStringAttrs(map[string]pdata.AttributeValue{"name": pdata.NewAttributeValueString("value")}, "attribute_name", "other_value")
+	decision, err := and.Evaluate(pdata.NewTraceID([16]byte{1}), trace)
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestAndEvaluatorInvertSampled(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"}, false, 0, false)
+	n2 := NewStringAttributeFilter(zap.NewNop(), "attribute_name", []string{"other_value"}, false, 0, true)
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{n1, n2})
+
+	trace := newTraceStringAttrs(map[string]pdata.AttributeValue{"name": pdata.NewAttributeValueString("value")}, "attribute_name", "attribute_value")
+	decision, err := and.Evaluate(pdata.NewTraceID([16]byte{1}), trace)
+	assert.NoError(t, err)
+	assert.Equal(t, Sampled, decision)
+}
+
+func TestAndEvaluatorInvertNotSampled(t *testing.T) {
+	n1 := NewStringAttributeFilter(zap.NewNop(), "name", []string{"value"}, false, 0, false)
+	n2 := NewStringAttributeFilter(zap.NewNop(), "attribute_name", []string{"attribute_value"}, false, 0, true)
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{n1, n2})
+
+	trace := newTraceStringAttrs(map[string]pdata.AttributeValue{"name": pdata.NewAttributeValueString("value")}, "attribute_name", "attribute_value")
+	decision, err := and.Evaluate(pdata.NewTraceID([16]byte{1}), trace)
+	assert.NoError(t, err)
+	assert.Equal(t, NotSampled, decision)
+}
+
+func TestAndEvaluatorError(t *testing.T) {
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{NewAlwaysSample(zap.NewNop()), &failingEvaluator{}})
+
+	decision, err := and.Evaluate(pdata.NewTraceID([16]byte{1}), &TraceData{})
+	assert.Error(t, err)
+	assert.Equal(t, Unspecified, decision)
+}
+
+func TestOnLateArrivingSpans_And(t *testing.T) {
+	and := NewAnd(zap.NewNop(), []PolicyEvaluator{NewAlwaysSample(zap.NewNop()), &failingEvaluator{}})
+	err := and.OnLateArrivingSpans(Sampled, nil)
+	assert.Error(t, err)
+}
+
+type failingEvaluator struct{}
+
+var _ PolicyEvaluator = (*failingEvaluator)(nil)
+
+func (f *failingEvaluator) OnLateArrivingSpans(Decision, []*pdata.Span) error {
+	return errors.New("late spans failure")
+}
+
+func (f *failingEvaluator) Evaluate(pdata.TraceID, *TraceData) (Decision, error) {
+	return Unspecified, errors.New("evaluation failure")
+}
@@ -0,0 +1,69 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+type booleanAttributeFilter struct {
+	key    string
+	value  bool
+	logger *zap.Logger
+}
+
+var _ PolicyEvaluator = (*booleanAttributeFilter)(nil)
+
+// NewBooleanAttributeFilter creates a policy evaluator that samples all traces with
+// the given boolean attribute set to the given value.
+func NewBooleanAttributeFilter(logger *zap.Logger, key string, value bool) PolicyEvaluator {
+	return &booleanAttributeFilter{
+		key:    key,
+		value:  value,
+		logger: logger,
+	}
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (baf *booleanAttributeFilter) OnLateArrivingSpans(Decision, []*pdata.Span) error {
+	baf.logger.Debug("Triggering action for late arriving spans in boolean-attribute filter")
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (baf *booleanAttributeFilter) Evaluate(_ pdata.TraceID, trace *TraceData) (Decision, error) {
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	return hasResourceOrSpanWithCondition(
+		batches,
+		func(resource pdata.Resource) bool {
+			return baf.matches(resource.Attributes())
+		},
+		func(span pdata.Span) bool {
+			return baf.matches(span.Attributes())
+		},
+	), nil
+}
+
+func (baf *booleanAttributeFilter) matches(attrs pdata.AttributeMap) bool {
+	v, ok := attrs.Get(baf.key)
+	return ok && v.Type() == pdata.AttributeValueTypeBool && v.BoolVal() == baf.value
+}
@@ -20,17 +20,20 @@ import (
 )
 
 type latency struct {
-	logger      *zap.Logger
-	thresholdMs int64
+	logger           *zap.Logger
+	thresholdMs      int64
+	upperThresholdMs int64
 }
 
 var _ PolicyEvaluator = (*latency)(nil)
 
-// NewLatency creates a policy evaluator sampling traces with a duration higher than a configured threshold
-func NewLatency(logger *zap.Logger, thresholdMs int64) PolicyEvaluator {
+// NewLatency creates a policy evaluator sampling traces with a duration higher than a configured threshold,
+// and, if the upper threshold is not zero, lower than or equal to the upper threshold.
+func NewLatency(logger *zap.Logger, thresholdMs int64, upperThresholdMs int64) PolicyEvaluator {
 	return &latency{
-		logger:      logger,
-		thresholdMs: thresholdMs,
+		logger:           logger,
+		thresholdMs:      thresholdMs,
+		upperThresholdMs: upperThresholdMs,
 	}
 }
 
@@ -54,7 +57,7 @@ func (l *latency) Evaluate(_ pdata.TraceID, traceData *TraceData) (Decision, err
 	var minTime pdata.Timestamp
 	var maxTime pdata.Timestamp
 
-	return hasSpanWithCondition(batches, func(span pdata.Span) bool {
+	decision := hasSpanWithCondition(batches, func(span pdata.Span) bool {
 		if minTime == 0 || span.StartTimestamp() < minTime {
 			minTime = span.StartTimestamp()
 		}
@@ -62,7 +65,21 @@ func (l *latency) Evaluate(_ pdata.TraceID, traceData *TraceData) (Decision, err
 			maxTime = span.EndTimestamp()
 		}
 
+		// With an upper threshold, the whole trace must be looked at to know its duration.
+		if l.upperThresholdMs != 0 {
+			return false
+		}
+
 		duration := maxTime.AsTime().Sub(minTime.AsTime())
 		return duration.Milliseconds() >= l.thresholdMs
-	}), nil
+	})
+	if l.upperThresholdMs == 0 || minTime == 0 {
+		return decision, nil
+	}
+
+	duration := maxTime.AsTime().Sub(minTime.AsTime()).Milliseconds()
+	if duration >= l.thresholdMs && duration <= l.upperThresholdMs {
+		return Sampled, nil
+	}
+	return NotSampled, nil
 }
@@ -24,7 +24,7 @@ import (
 )
 
 func TestEvaluate_Latency(t *testing.T) {
-	filter := NewLatency(zap.NewNop(), 5000)
+	filter := NewLatency(zap.NewNop(), 5000, 0)
 
 	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
 	now := time.Now()
@@ -80,8 +80,100 @@ func TestEvaluate_Latency(t *testing.T) {
 	}
 }
 
+func TestEvaluate_LatencyWithUpperThreshold(t *testing.T) {
+	filter := NewLatency(zap.NewNop(), 5000, 10000)
+
+	traceID := pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	now := time.Now()
+
+	cases := []struct {
+		Desc     string
+		Spans    []spanWithTimeAndDuration
+		Decision Decision
+	}{
+		{
+			"trace duration shorter than lower threshold",
+			[]spanWithTimeAndDuration{
+				{
+					StartTime: now,
+					Duration:  4500 * time.Millisecond,
+				},
+			},
+			NotSampled,
+		},
+		{
+			"trace duration is equal to lower threshold",
+			[]spanWithTimeAndDuration{
+				{
+					StartTime: now,
+					Duration:  5000 * time.Millisecond,
+				},
+			},
+			Sampled,
+		},
+		{
+			"trace duration is within lower and upper threshold",
+			[]spanWithTimeAndDuration{
+				{
+					StartTime: now,
+					Duration:  5001 * time.Millisecond,
+				},
+			},
+			Sampled,
+		},
+		{
+			"trace duration is equal to upper threshold",
+			[]spanWithTimeAndDuration{
+				{
+					StartTime: now,
+					Duration:  10000 * time.Millisecond,
+				},
+			},
+			Sampled,
+		},
+		{
+			"trace duration is above upper threshold",
+			[]spanWithTimeAndDuration{
+				{
+					StartTime: now,
+					Duration:  10001 * time.Millisecond,
+				},
+			},
+			NotSampled,
+		},
+		{
+			"first span is within thresholds but total trace duration is above upper threshold",
+			[]spanWithTimeAndDuration{
+				{
+					StartTime: now,
+					Duration:  6000 * time.Millisecond,
+				},
+				{
+					StartTime: now.Add(5000 * time.Millisecond),
+					Duration:  6000 * time.Millisecond,
+				},
+			},
+			NotSampled,
+		},
+		{
+			"no spans",
+			nil,
+			NotSampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			decision, err := filter.Evaluate(traceID, newTraceWithSpans(c.Spans))
+
+			assert.NoError(t, err)
+			assert.Equal(t, decision, c.Decision)
+		})
+	}
+}
+
 func TestOnLateArrivingSpans_Latency(t *testing.T) {
-	filter := NewLatency(zap.NewNop(), 5000)
+	filter := NewLatency(zap.NewNop(), 5000, 0)
 	err := filter.OnLateArrivingSpans(NotSampled, nil)
 	assert.Nil(t, err)
 }
@@ -0,0 +1,96 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
+
+import (
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+// maxTraceStateMemberLength is the maximum length of a tracestate list-member value,
+// see https://www.w3.org/TR/trace-context/#value
+const maxTraceStateMemberLength = 256
+
+type traceStateFilter struct {
+	key     string
+	logger  *zap.Logger
+	matcher func(string) bool
+}
+
+var _ PolicyEvaluator = (*traceStateFilter)(nil)
+
+// NewTraceStateFilter creates a policy evaluator that samples all traces with
+// the given key set to one of the given values in the tracestate of a span.
+// Values that can't appear in a tracestate, empty or too long, are rejected.
+func NewTraceStateFilter(logger *zap.Logger, key string, values []string) (PolicyEvaluator, error) {
+	valuesMap := make(map[string]struct{})
+	for _, value := range values {
+		if value == "" {
+			return nil, fmt.Errorf("empty value for the trace state key %q", key)
+		}
+		if len(value) > maxTraceStateMemberLength {
+			return nil, fmt.Errorf("value %q for the trace state key %q is longer than %d characters", value, key, maxTraceStateMemberLength)
+		}
+		valuesMap[value] = struct{}{}
+	}
+	return &traceStateFilter{
+		key:    key,
+		logger: logger,
+		matcher: func(toMatch string) bool {
+			_, matched := valuesMap[toMatch]
+			return matched
+		},
+	}, nil
+}
+
+// OnLateArrivingSpans notifies the evaluator that the given list of spans arrived
+// after the sampling decision was already taken for the trace.
+// This gives the evaluator a chance to log any message/metrics and/or update any
+// related internal state.
+func (tsf *traceStateFilter) OnLateArrivingSpans(Decision, []*pdata.Span) error {
+	tsf.logger.Debug("Triggering action for late arriving spans in trace state filter")
+	return nil
+}
+
+// Evaluate looks at the trace data and returns a corresponding SamplingDecision.
+func (tsf *traceStateFilter) Evaluate(_ pdata.TraceID, trace *TraceData) (Decision, error) {
+	trace.Lock()
+	batches := trace.ReceivedBatches
+	trace.Unlock()
+
+	return hasSpanWithCondition(batches, func(span pdata.Span) bool {
+		value, ok := traceStateValue(string(span.TraceState()), tsf.key)
+		return ok && tsf.matcher(value)
+	}), nil
+}
+
+// traceStateValue returns the value of the key in the comma-separated key=value
+// list-members of a W3C tracestate.
+func traceStateValue(traceState string, key string) (string, bool) {
+	for _, member := range strings.Split(traceState, ",") {
+		member = strings.TrimSpace(member)
+		separatorIndex := strings.IndexByte(member, '=')
+		if separatorIndex <= 0 {
+			continue
+		}
+		if member[:separatorIndex] == key {
+			return member[separatorIndex+1:], true
+		}
+	}
+	return "", false
+}
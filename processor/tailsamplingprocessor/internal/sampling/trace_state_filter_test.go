@@ -0,0 +1,109 @@
+// Copyright  The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sampling
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+)
+
+func TestTraceStateFilter(t *testing.T) {
+	filter, err := NewTraceStateFilter(zap.NewNop(), "example", []string{"value", "other"})
+	require.NoError(t, err)
+
+	cases := []struct {
+		Desc       string
+		TraceState string
+		Decision   Decision
+	}{
+		{
+			Desc:       "empty trace state",
+			TraceState: "",
+			Decision:   NotSampled,
+		},
+		{
+			Desc:       "matching key and value",
+			TraceState: "example=value",
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "matching key and value among other list-members",
+			TraceState: "vendor=abc, example=other ,key=value",
+			Decision:   Sampled,
+		},
+		{
+			Desc:       "matching key with non matching value",
+			TraceState: "example=another",
+			Decision:   NotSampled,
+		},
+		{
+			Desc:       "matching value with non matching key",
+			TraceState: "key=value",
+			Decision:   NotSampled,
+		},
+		{
+			Desc:       "key prefix",
+			TraceState: "example2=value",
+			Decision:   NotSampled,
+		},
+		{
+			Desc:       "malformed list-member",
+			TraceState: "example",
+			Decision:   NotSampled,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.Desc, func(t *testing.T) {
+			decision, err := filter.Evaluate(pdata.NewTraceID([16]byte{1}), newTraceWithTraceState(c.TraceState))
+			assert.NoError(t, err)
+			assert.Equal(t, c.Decision, decision)
+		})
+	}
+}
+
+func TestNewTraceStateFilter_errorHandling(t *testing.T) {
+	_, err := NewTraceStateFilter(zap.NewNop(), "example", []string{"value", ""})
+	assert.EqualError(t, err, `empty value for the trace state key "example"`)
+
+	_, err = NewTraceStateFilter(zap.NewNop(), "example", []string{strings.Repeat("v", 257)})
+	assert.Error(t, err)
+
+	_, err = NewTraceStateFilter(zap.NewNop(), "example", []string{strings.Repeat("v", 256)})
+	assert.NoError(t, err)
+}
+
+func TestOnLateArrivingSpans_TraceStateFilter(t *testing.T) {
+	filter, err := NewTraceStateFilter(zap.NewNop(), "example", []string{"value"})
+	require.NoError(t, err)
+	err = filter.OnLateArrivingSpans(NotSampled, nil)
+	assert.Nil(t, err)
+}
+
+func newTraceWithTraceState(traceState string) *TraceData {
+	traces := pdata.NewTraces()
+	span := traces.ResourceSpans().AppendEmpty().InstrumentationLibrarySpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(pdata.NewTraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}))
+	span.SetSpanID(pdata.NewSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8}))
+	span.SetTraceState(pdata.TraceState(traceState))
+	return &TraceData{
+		ReceivedBatches: []pdata.Traces{traces},
+	}
+}
@@ -27,6 +27,8 @@ var (
 	tagPolicyKey, _    = tag.NewKey("policy")
 	tagSampledKey, _   = tag.NewKey("sampled")
 	tagSourceFormat, _ = tag.NewKey("source_format")
+	tagDecisionKey, _  = tag.NewKey("decision")
+	tagSuccessKey, _   = tag.NewKey("success")
 
 	statDecisionLatencyMicroSec  = stats.Int64("sampling_decision_latency", "Latency (in microseconds) of a given sampling policy", "µs")
 	statOverallDecisionLatencyUs = stats.Int64("sampling_decision_timer_latency", "Latency (in microseconds) of each run of the sampling decision timer", "µs")
@@ -36,7 +38,13 @@ var (
 
 	statPolicyEvaluationErrorCount = stats.Int64("sampling_policy_evaluation_error", "Count of sampling policy evaluation errors", stats.UnitDimensionless)
 
-	statCountTracesSampled = stats.Int64("count_traces_sampled", "Count of traces that were sampled or not", stats.UnitDimensionless)
+	statCountTracesSampled   = stats.Int64("count_traces_sampled", "Count of traces that were sampled or not", stats.UnitDimensionless)
+	statCountPolicyDecisions = stats.Int64("count_policy_decisions", "Count of traces by the decision of each sampling policy", stats.UnitDimensionless)
+
+	statDecisionCacheHitCount  = stats.Int64("sampling_decision_cache_hit", "Count of span batches of traces no longer in memory whose decision was found on the decision cache", stats.UnitDimensionless)
+	statDecisionCacheMissCount = stats.Int64("sampling_decision_cache_miss", "Count of span batches of traces not in memory whose decision was not found on the decision cache", stats.UnitDimensionless)
+
+	statPolicyReloadCount = stats.Int64("sampling_policy_reload", "Count of attempts to load the sampling policies from the policy source", stats.UnitDimensionless)
 
 	statDroppedTooEarlyCount    = stats.Int64("sampling_trace_dropped_too_early", "Count of traces that needed to be dropped the configured wait time", stats.UnitDimensionless)
 	statNewTraceIDReceivedCount = stats.Int64("new_trace_id_received", "Counts the arrival of new traces", stats.UnitDimensionless)
@@ -97,6 +105,37 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		Aggregation: view.Sum(),
 	}
 
+	decisionTagKeys := []tag.Key{tagPolicyKey, tagDecisionKey}
+	countPolicyDecisionsView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statCountPolicyDecisions.Name()),
+		Measure:     statCountPolicyDecisions,
+		Description: statCountPolicyDecisions.Description(),
+		TagKeys:     decisionTagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	decisionCacheHitView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionCacheHitCount.Name()),
+		Measure:     statDecisionCacheHitCount,
+		Description: statDecisionCacheHitCount.Description(),
+		TagKeys:     []tag.Key{tagDecisionKey},
+		Aggregation: view.Sum(),
+	}
+	decisionCacheMissView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDecisionCacheMissCount.Name()),
+		Measure:     statDecisionCacheMissCount,
+		Description: statDecisionCacheMissCount.Description(),
+		Aggregation: view.Sum(),
+	}
+
+	policyReloadView := &view.View{
+		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statPolicyReloadCount.Name()),
+		Measure:     statPolicyReloadCount,
+		Description: statPolicyReloadCount.Description(),
+		TagKeys:     []tag.Key{tagSuccessKey},
+		Aggregation: view.Sum(),
+	}
+
 	countTraceDroppedTooEarlyView := &view.View{
 		Name:        obsreport.BuildProcessorCustomMetricName(typeStr, statDroppedTooEarlyCount.Name()),
 		Measure:     statDroppedTooEarlyCount,
@@ -126,6 +165,12 @@ func SamplingProcessorMetricViews(level configtelemetry.Level) []*view.View {
 		countPolicyEvaluationErrorView,
 
 		countTracesSampledView,
+		countPolicyDecisionsView,
+
+		decisionCacheHitView,
+		decisionCacheMissView,
+
+		policyReloadView,
 
 		countTraceDroppedTooEarlyView,
 		countTraceIDArrivalView,
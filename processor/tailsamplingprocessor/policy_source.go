@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	defaultPolicyReloadInterval = 30 * time.Second
+	policySourceRequestTimeout  = 10 * time.Second
+)
+
+// policySourceContent is the content of the document served by a policy source.
+type policySourceContent struct {
+	// Version identifies the policies, it is reported when they become active. When
+	// not set, a hash of the document is used instead.
+	Version    string      `mapstructure:"version"`
+	PolicyCfgs []PolicyCfg `mapstructure:"policies"`
+}
+
+// policySource reads the policies from the file or endpoint configured.
+type policySource struct {
+	cfg    PolicySourceConfig
+	client *http.Client
+}
+
+func newPolicySource(cfg PolicySourceConfig) *policySource {
+	return &policySource{
+		cfg:    cfg,
+		client: &http.Client{Timeout: policySourceRequestTimeout},
+	}
+}
+
+func (ps *policySource) reloadInterval() time.Duration {
+	if ps.cfg.ReloadInterval == 0 {
+		return defaultPolicyReloadInterval
+	}
+	return ps.cfg.ReloadInterval
+}
+
+// load reads and parses the policies of the source.
+func (ps *policySource) load(ctx context.Context) (*policySourceContent, error) {
+	data, err := ps.read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return parsePolicySource(data)
+}
+
+func (ps *policySource) read(ctx context.Context) ([]byte, error) {
+	if ps.cfg.File != "" {
+		return ioutil.ReadFile(ps.cfg.File)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ps.cfg.Endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := ps.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("policy source %s returned status %d", ps.cfg.Endpoint, resp.StatusCode)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+func parsePolicySource(data []byte) (*policySourceContent, error) {
+	cm, err := config.NewMapFromBuffer(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse policy source: %w", err)
+	}
+	content := &policySourceContent{}
+	if err = cm.UnmarshalExact(content); err != nil {
+		return nil, fmt.Errorf("failed to parse policy source: %w", err)
+	}
+	if len(content.PolicyCfgs) == 0 {
+		return nil, errPolicySourceHasNoPolicies
+	}
+	if content.Version == "" {
+		sum := sha256.Sum256(data)
+		content.Version = hex.EncodeToString(sum[:8])
+	}
+	return content, nil
+}
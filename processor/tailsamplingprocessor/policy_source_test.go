@@ -0,0 +1,235 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tailsamplingprocessor
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.uber.org/zap"
+)
+
+const (
+	alwaysSamplePolicies = `
+version: v1
+policies:
+  - name: always
+    type: always_sample
+`
+	latencyPolicies = `
+version: v2
+policies:
+  - name: slow
+    type: latency
+    latency: {threshold_ms: 5000}
+  - name: errors
+    type: status_code
+    status_code: {status_codes: [ERROR]}
+`
+	slowPolicies = `
+version: v4
+policies:
+  - name: slow
+    type: latency
+    latency: {threshold_ms: 5000}
+`
+	invalidPolicies = `
+version: v3
+policies:
+  - name: invalid
+    type: status_code
+    status_code: {status_codes: [WRONG]}
+`
+)
+
+func TestParsePolicySource(t *testing.T) {
+	content, err := parsePolicySource([]byte(latencyPolicies))
+	require.NoError(t, err)
+	assert.Equal(t, &policySourceContent{
+		Version: "v2",
+		PolicyCfgs: []PolicyCfg{
+			{Name: "slow", Type: Latency, LatencyCfg: LatencyCfg{ThresholdMs: 5000}},
+			{Name: "errors", Type: StatusCode, StatusCodeCfg: StatusCodeCfg{StatusCodes: []string{"ERROR"}}},
+		},
+	}, content)
+}
+
+func TestParsePolicySourceVersionFromContent(t *testing.T) {
+	unversioned := "policies: [{name: always, type: always_sample}]"
+	content, err := parsePolicySource([]byte(unversioned))
+	require.NoError(t, err)
+	assert.Len(t, content.Version, 16)
+
+	again, err := parsePolicySource([]byte(unversioned))
+	require.NoError(t, err)
+	assert.Equal(t, content.Version, again.Version)
+
+	other, err := parsePolicySource([]byte("policies: [{name: other, type: always_sample}]"))
+	require.NoError(t, err)
+	assert.NotEqual(t, content.Version, other.Version)
+}
+
+func TestParsePolicySourceErrors(t *testing.T) {
+	_, err := parsePolicySource([]byte("version: v1"))
+	assert.ErrorIs(t, err, errPolicySourceHasNoPolicies)
+
+	_, err = parsePolicySource([]byte("unknown: field\npolicies: [{name: always, type: always_sample}]"))
+	assert.Error(t, err)
+
+	_, err = parsePolicySource([]byte("policies: ["))
+	assert.Error(t, err)
+}
+
+func TestPolicyReloadFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(alwaysSamplePolicies), 0600))
+
+	tsp := newPolicySourceTestProcessor(t, &PolicySourceConfig{File: path, ReloadInterval: time.Hour})
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	assertActivePolicies(t, tsp, "v1", "always")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte(latencyPolicies), 0600))
+	require.NoError(t, tsp.reloadPolicies(context.Background()))
+	assertActivePolicies(t, tsp, "v2", "slow", "errors")
+
+	// Invalid policies are rejected, keeping the active ones.
+	require.NoError(t, ioutil.WriteFile(path, []byte(invalidPolicies), 0600))
+	require.Error(t, tsp.reloadPolicies(context.Background()))
+	assertActivePolicies(t, tsp, "v2", "slow", "errors")
+
+	require.NoError(t, ioutil.WriteFile(path, []byte("policies: []"), 0600))
+	require.ErrorIs(t, tsp.reloadPolicies(context.Background()), errPolicySourceHasNoPolicies)
+	assertActivePolicies(t, tsp, "v2", "slow", "errors")
+}
+
+func TestPolicyReloadFromEndpoint(t *testing.T) {
+	var served atomic.Value
+	served.Store(alwaysSamplePolicies)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content := served.Load().(string)
+		if content == "" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	tsp := newPolicySourceTestProcessor(t, &PolicySourceConfig{Endpoint: server.URL, ReloadInterval: 10 * time.Millisecond})
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+	assertActivePolicies(t, tsp, "v1", "always")
+
+	served.Store(latencyPolicies)
+	assert.Eventually(t, func() bool {
+		return tsp.activePolicyVersion() == "v2"
+	}, 5*time.Second, 10*time.Millisecond)
+	assertActivePolicies(t, tsp, "v2", "slow", "errors")
+
+	served.Store("")
+	require.Error(t, tsp.reloadPolicies(context.Background()))
+	assertActivePolicies(t, tsp, "v2", "slow", "errors")
+}
+
+func TestPolicySourceUnavailableOnStart(t *testing.T) {
+	tsp := newPolicySourceTestProcessor(t, &PolicySourceConfig{File: filepath.Join(t.TempDir(), "missing.yaml")})
+	require.Error(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+}
+
+func TestPolicyReloadKeepsTracesInMemory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, ioutil.WriteFile(path, []byte(alwaysSamplePolicies), 0600))
+
+	msp := new(consumertest.TracesSink)
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    100,
+		PolicySource: &PolicySourceConfig{File: path, ReloadInterval: time.Hour},
+	}
+	sp, err := newTracesProcessor(zap.NewNop(), msp, cfg)
+	require.NoError(t, err)
+	tsp := sp.(*tailSamplingSpanProcessor)
+	tsp.decisionBatcher = newSyncIDBatcher(1)
+	tsp.policyTicker = &manualTTicker{}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	// The first two traces arrive while the policy of v1 is active.
+	traceIds, batches := generateIdsAndBatches(3)
+	for _, batch := range batches[:3] {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+
+	// v4 has as many policies as v1, but samples none of the traces.
+	require.NoError(t, ioutil.WriteFile(path, []byte(slowPolicies), 0600))
+	require.NoError(t, tsp.reloadPolicies(context.Background()))
+	assertActivePolicies(t, tsp, "v4", "slow")
+
+	// The third trace arrives after the reload.
+	for _, batch := range batches[3:] {
+		require.NoError(t, tsp.ConsumeTraces(context.Background(), batch))
+	}
+
+	// The buffered traces are evaluated by the policies active when they arrived.
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Len(t, msp.AllTraces(), 2)
+	require.Equal(t, 3, msp.SpanCount())
+
+	// Late spans are replayed against the same policies, and aren't dropped.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+	require.Equal(t, 4, msp.SpanCount())
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[3]))
+	require.Equal(t, 4, msp.SpanCount())
+	_, ok := tsp.idToTrace.Load(traceIds[2])
+	require.True(t, ok)
+}
+
+func newPolicySourceTestProcessor(t *testing.T, source *PolicySourceConfig) *tailSamplingSpanProcessor {
+	cfg := Config{
+		DecisionWait: defaultTestDecisionWait,
+		NumTraces:    100,
+		PolicySource: source,
+	}
+	sp, err := newTracesProcessor(zap.NewNop(), consumertest.NewNop(), cfg)
+	require.NoError(t, err)
+	return sp.(*tailSamplingSpanProcessor)
+}
+
+func assertActivePolicies(t *testing.T, tsp *tailSamplingSpanProcessor, version string, names ...string) {
+	var active []string
+	for _, p := range tsp.getPolicies() {
+		active = append(active, p.name)
+	}
+	assert.Equal(t, names, active)
+	assert.Equal(t, version, tsp.activePolicyVersion())
+}
@@ -30,6 +30,7 @@ import (
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -45,13 +46,27 @@ type policy struct {
 	ctx context.Context
 }
 
+// traceWithPolicies is a trace held in memory along with the policies that were active when the
+// trace arrived. The trace is evaluated, and its late spans replayed, against these policies,
+// even if the policies are reloaded in between.
+type traceWithPolicies struct {
+	*sampling.TraceData
+	policies []*policy
+}
+
 // tailSamplingSpanProcessor handles the incoming trace data and uses the given sampling
 // policy to sample traces.
 type tailSamplingSpanProcessor struct {
-	ctx             context.Context
-	nextConsumer    consumer.Traces
-	maxNumTraces    uint64
+	ctx          context.Context
+	nextConsumer consumer.Traces
+	maxNumTraces uint64
+	// policiesMu protects policies and policyVersion, which are replaced when the
+	// policies are reloaded from the policySource.
+	policiesMu      sync.RWMutex
 	policies        []*policy
+	policyVersion   string
+	policySource    *policySource
+	reloadTicker    tTicker
 	logger          *zap.Logger
 	idToTrace       sync.Map
 	policyTicker    tTicker
@@ -59,6 +74,11 @@ type tailSamplingSpanProcessor struct {
 	decisionBatcher idbatcher.Batcher
 	deleteChan      chan pdata.TraceID
 	numTracesOnMap  uint64
+	// sampledIDCache and nonSampledIDCache remember the decisions taken for traces
+	// after they are removed from idToTrace.
+	sampledIDCache    cache.Cache
+	nonSampledIDCache cache.Cache
+	useDecisionCache  bool
 }
 
 const (
@@ -79,23 +99,9 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 	}
 
 	ctx := context.Background()
-	var policies []*policy
-	for i := range cfg.PolicyCfgs {
-		policyCfg := &cfg.PolicyCfgs[i]
-		policyCtx, err := tag.New(ctx, tag.Upsert(tagPolicyKey, policyCfg.Name), tag.Upsert(tagSourceFormat, sourceFormat))
-		if err != nil {
-			return nil, err
-		}
-		eval, err := getPolicyEvaluator(logger, policyCfg)
-		if err != nil {
-			return nil, err
-		}
-		p := &policy{
-			name:      policyCfg.Name,
-			evaluator: eval,
-			ctx:       policyCtx,
-		}
-		policies = append(policies, p)
+	policies, err := newPolicies(ctx, logger, cfg.PolicyCfgs)
+	if err != nil {
+		return nil, err
 	}
 
 	tsp := &tailSamplingSpanProcessor{
@@ -106,21 +112,53 @@ func newTracesProcessor(logger *zap.Logger, nextConsumer consumer.Traces, cfg Co
 		decisionBatcher: inBatcher,
 		policies:        policies,
 		tickerFrequency: time.Second,
+
+		sampledIDCache:    cache.New(cfg.DecisionCache.SampledCacheSize),
+		nonSampledIDCache: cache.New(cfg.DecisionCache.NonSampledCacheSize),
+		useDecisionCache:  cfg.DecisionCache.SampledCacheSize > 0 || cfg.DecisionCache.NonSampledCacheSize > 0,
 	}
 
 	tsp.policyTicker = &policyTicker{onTickFunc: tsp.samplingPolicyOnTick}
 	tsp.deleteChan = make(chan pdata.TraceID, cfg.NumTraces)
 
+	if cfg.PolicySource != nil {
+		tsp.policySource = newPolicySource(*cfg.PolicySource)
+		tsp.reloadTicker = &policyTicker{onTickFunc: tsp.reloadPoliciesOnTick}
+	}
+
 	return tsp, nil
 }
 
+// newPolicies creates the policies for the given configuration, failing if any of them is invalid.
+func newPolicies(ctx context.Context, logger *zap.Logger, cfgs []PolicyCfg) ([]*policy, error) {
+	var policies []*policy
+	for i := range cfgs {
+		policyCfg := &cfgs[i]
+		policyCtx, err := tag.New(ctx, tag.Upsert(tagPolicyKey, policyCfg.Name), tag.Upsert(tagSourceFormat, sourceFormat))
+		if err != nil {
+			return nil, err
+		}
+		eval, err := getPolicyEvaluator(logger, policyCfg)
+		if err != nil {
+			return nil, err
+		}
+		p := &policy{
+			name:      policyCfg.Name,
+			evaluator: eval,
+			ctx:       policyCtx,
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
 func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEvaluator, error) {
 	switch cfg.Type {
 	case AlwaysSample:
 		return sampling.NewAlwaysSample(logger), nil
 	case Latency:
 		lfCfg := cfg.LatencyCfg
-		return sampling.NewLatency(logger, lfCfg.ThresholdMs), nil
+		return sampling.NewLatency(logger, lfCfg.ThresholdMs, lfCfg.UpperThresholdMs), nil
 	case NumericAttribute:
 		nafCfg := cfg.NumericAttributeCfg
 		return sampling.NewNumericAttributeFilter(logger, nafCfg.Key, nafCfg.MinValue, nafCfg.MaxValue), nil
@@ -139,6 +177,17 @@ func getPolicyEvaluator(logger *zap.Logger, cfg *PolicyCfg) (sampling.PolicyEval
 	case Composite:
 		rlfCfg := cfg.CompositeCfg
 		return getNewCompositePolicy(logger, rlfCfg)
+	case SpanCount:
+		scCfg := cfg.SpanCountCfg
+		return sampling.NewSpanCount(logger, scCfg.MinSpans, scCfg.MaxSpans), nil
+	case BooleanAttribute:
+		bafCfg := cfg.BooleanAttributeCfg
+		return sampling.NewBooleanAttributeFilter(logger, bafCfg.Key, bafCfg.Value), nil
+	case TraceState:
+		tsfCfg := cfg.TraceStateCfg
+		return sampling.NewTraceStateFilter(logger, tsfCfg.Key, tsfCfg.Values)
+	case And:
+		return getNewAndPolicy(logger, cfg.AndCfg)
 	default:
 		return nil, fmt.Errorf("unknown sampling policy type %s", cfg.Type)
 	}
@@ -161,10 +210,11 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 			metrics.idNotFoundOnMapCount++
 			continue
 		}
-		trace := d.(*sampling.TraceData)
+		trace := d.(*traceWithPolicies)
 		trace.DecisionTime = time.Now()
 
 		decision, policy := tsp.makeDecision(id, trace, &metrics)
+		tsp.cacheDecision(id, decision)
 
 		// Sampled or not, remove the batches
 		trace.Lock()
@@ -201,7 +251,7 @@ func (tsp *tailSamplingSpanProcessor) samplingPolicyOnTick() {
 	)
 }
 
-func (tsp *tailSamplingSpanProcessor) makeDecision(id pdata.TraceID, trace *sampling.TraceData, metrics *policyMetrics) (sampling.Decision, *policy) {
+func (tsp *tailSamplingSpanProcessor) makeDecision(id pdata.TraceID, trace *traceWithPolicies, metrics *policyMetrics) (sampling.Decision, *policy) {
 	finalDecision := sampling.NotSampled
 	var matchingPolicy *policy
 	samplingDecision := map[sampling.Decision]bool{
@@ -212,40 +262,51 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pdata.TraceID, trace *samp
 		sampling.InvertNotSampled: false,
 	}
 
+	policies := trace.policies
+	// The decisions are only published once all policies are evaluated, as the evaluators lock the trace
+	// themselves and the late spans must see either pending or final decisions.
+	decisions := make([]sampling.Decision, len(policies))
+
 	// Check all policies before making a final decision
-	for i, p := range tsp.policies {
+	for i, p := range policies {
 		policyEvaluateStartTime := time.Now()
-		decision, err := p.evaluator.Evaluate(id, trace)
+		decision, err := p.evaluator.Evaluate(id, trace.TraceData)
 		stats.Record(
 			p.ctx,
 			statDecisionLatencyMicroSec.M(int64(time.Since(policyEvaluateStartTime)/time.Microsecond)))
 
+		recordPolicyDecision(p, decision, err)
+
 		if err != nil {
 			samplingDecision[sampling.Error] = true
-			trace.Decisions[i] = sampling.NotSampled
+			decisions[i] = sampling.NotSampled
 			metrics.evaluateErrorCount++
 			tsp.logger.Debug("Sampling policy error", zap.Error(err))
 		} else {
 			switch decision {
 			case sampling.Sampled:
 				samplingDecision[sampling.Sampled] = true
-				trace.Decisions[i] = decision
+				decisions[i] = decision
 
 			case sampling.NotSampled:
 				samplingDecision[sampling.NotSampled] = true
-				trace.Decisions[i] = decision
+				decisions[i] = decision
 
 			case sampling.InvertSampled:
 				samplingDecision[sampling.InvertSampled] = true
-				trace.Decisions[i] = sampling.Sampled
+				decisions[i] = sampling.Sampled
 
 			case sampling.InvertNotSampled:
 				samplingDecision[sampling.InvertNotSampled] = true
-				trace.Decisions[i] = sampling.NotSampled
+				decisions[i] = sampling.NotSampled
 			}
 		}
 	}
 
+	trace.Lock()
+	trace.Decisions = decisions
+	trace.Unlock()
+
 	// InvertNotSampled takes precedence over any other decision
 	if samplingDecision[sampling.InvertNotSampled] {
 		finalDecision = sampling.NotSampled
@@ -255,7 +316,7 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pdata.TraceID, trace *samp
 		finalDecision = sampling.Sampled
 	}
 
-	for _, p := range tsp.policies {
+	for _, p := range policies {
 		switch finalDecision {
 		case sampling.Sampled:
 			// any single policy that decides to sample will cause the decision to be sampled
@@ -284,6 +345,26 @@ func (tsp *tailSamplingSpanProcessor) makeDecision(id pdata.TraceID, trace *samp
 	return finalDecision, matchingPolicy
 }
 
+// recordPolicyDecision records the decision of the policy itself, regardless of
+// the final decision for the trace.
+func recordPolicyDecision(p *policy, decision sampling.Decision, err error) {
+	var decisionTag string
+	switch {
+	case err != nil:
+		decisionTag = "error"
+	case decision == sampling.Sampled, decision == sampling.InvertSampled:
+		decisionTag = "sampled"
+	default:
+		decisionTag = "not_sampled"
+	}
+
+	_ = stats.RecordWithTags(
+		p.ctx,
+		[]tag.Mutator{tag.Insert(tagDecisionKey, decisionTag)},
+		statCountPolicyDecisions.M(int64(1)),
+	)
+}
+
 // ConsumeTraceData is required by the SpanProcessor interface.
 func (tsp *tailSamplingSpanProcessor) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
 	resourceSpans := td.ResourceSpans()
@@ -311,22 +392,30 @@ func (tsp *tailSamplingSpanProcessor) groupSpansByTraceKey(resourceSpans pdata.R
 func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans pdata.ResourceSpans) {
 	// Group spans per their traceId to minimize contention on idToTrace
 	idToSpans := tsp.groupSpansByTraceKey(resourceSpans)
+	policies := tsp.getPolicies()
 	var newTraceIDs int64
 	for id, spans := range idToSpans {
+		if tsp.processCachedDecision(id, resourceSpans, spans) {
+			continue
+		}
+
 		lenSpans := int64(len(spans))
-		lenPolicies := len(tsp.policies)
+		lenPolicies := len(policies)
 		initialDecisions := make([]sampling.Decision, lenPolicies)
 		for i := 0; i < lenPolicies; i++ {
 			initialDecisions[i] = sampling.Pending
 		}
-		initialTraceData := &sampling.TraceData{
-			Decisions:   initialDecisions,
-			ArrivalTime: time.Now(),
-			SpanCount:   lenSpans,
+		initialTraceData := &traceWithPolicies{
+			TraceData: &sampling.TraceData{
+				Decisions:   initialDecisions,
+				ArrivalTime: time.Now(),
+				SpanCount:   lenSpans,
+			},
+			policies: policies,
 		}
 		d, loaded := tsp.idToTrace.LoadOrStore(id, initialTraceData)
 
-		actualData := d.(*sampling.TraceData)
+		actualData := d.(*traceWithPolicies)
 		if loaded {
 			atomic.AddInt64(&actualData.SpanCount, lenSpans)
 		} else {
@@ -346,7 +435,8 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans pdata.Resource
 			}
 		}
 
-		for i, p := range tsp.policies {
+		// The late spans are replayed against the policies the trace was evaluated with.
+		for i, p := range actualData.policies {
 			var traceTd pdata.Traces
 			actualData.Lock()
 			actualDecision := actualData.Decisions[i]
@@ -393,12 +483,70 @@ func (tsp *tailSamplingSpanProcessor) processTraces(resourceSpans pdata.Resource
 	stats.Record(tsp.ctx, statNewTraceIDReceivedCount.M(newTraceIDs))
 }
 
+// cacheDecision remembers the decision taken for the trace, so it outlives the trace in memory.
+func (tsp *tailSamplingSpanProcessor) cacheDecision(id pdata.TraceID, decision sampling.Decision) {
+	if !tsp.useDecisionCache {
+		return
+	}
+	if decision == sampling.Sampled {
+		tsp.sampledIDCache.Put(id)
+	} else {
+		tsp.nonSampledIDCache.Put(id)
+	}
+}
+
+// processCachedDecision applies the cached decision to spans of a trace that was already
+// removed from memory. It returns false if the trace is still in memory or its decision
+// is unknown, in which case the spans must be processed as usual.
+func (tsp *tailSamplingSpanProcessor) processCachedDecision(id pdata.TraceID, resourceSpans pdata.ResourceSpans, spans []*pdata.Span) bool {
+	if !tsp.useDecisionCache {
+		return false
+	}
+	if _, ok := tsp.idToTrace.Load(id); ok {
+		return false
+	}
+
+	switch {
+	case tsp.sampledIDCache.Get(id):
+		recordDecisionCacheHit(tsp.ctx, sampling.Sampled)
+		traceTd := prepareTraceBatch(resourceSpans, spans)
+		if err := tsp.nextConsumer.ConsumeTraces(tsp.ctx, traceTd); err != nil {
+			tsp.logger.Warn("Error sending late arrived spans of a cached trace to destination", zap.Error(err))
+		}
+		return true
+	case tsp.nonSampledIDCache.Get(id):
+		recordDecisionCacheHit(tsp.ctx, sampling.NotSampled)
+		return true
+	default:
+		stats.Record(tsp.ctx, statDecisionCacheMissCount.M(int64(1)))
+		return false
+	}
+}
+
+func recordDecisionCacheHit(ctx context.Context, decision sampling.Decision) {
+	decisionTag := "not_sampled"
+	if decision == sampling.Sampled {
+		decisionTag = "sampled"
+	}
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Insert(tagDecisionKey, decisionTag)},
+		statDecisionCacheHitCount.M(int64(1)),
+	)
+}
+
 func (tsp *tailSamplingSpanProcessor) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: false}
 }
 
 // Start is invoked during service startup.
-func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) error {
+func (tsp *tailSamplingSpanProcessor) Start(ctx context.Context, _ component.Host) error {
+	if tsp.policySource != nil {
+		if err := tsp.reloadPolicies(ctx); err != nil {
+			return fmt.Errorf("failed to load sampling policies: %w", err)
+		}
+		tsp.reloadTicker.start(tsp.policySource.reloadInterval())
+	}
 	tsp.policyTicker.start(tsp.tickerFrequency)
 	return nil
 }
@@ -407,13 +555,81 @@ func (tsp *tailSamplingSpanProcessor) Start(context.Context, component.Host) err
 func (tsp *tailSamplingSpanProcessor) Shutdown(context.Context) error {
 	tsp.decisionBatcher.Stop()
 	tsp.policyTicker.stop()
+	if tsp.reloadTicker != nil {
+		tsp.reloadTicker.stop()
+	}
 	return nil
 }
 
+func (tsp *tailSamplingSpanProcessor) getPolicies() []*policy {
+	tsp.policiesMu.RLock()
+	defer tsp.policiesMu.RUnlock()
+	return tsp.policies
+}
+
+func (tsp *tailSamplingSpanProcessor) reloadPoliciesOnTick() {
+	if err := tsp.reloadPolicies(tsp.ctx); err != nil {
+		tsp.logger.Warn("Failed to reload sampling policies, keeping the active ones",
+			zap.String("version", tsp.activePolicyVersion()),
+			zap.Error(err))
+	}
+}
+
+// reloadPolicies reads the policies from the policy source and, if their version changed,
+// replaces the active ones. Traces already in memory are kept, and are evaluated by the
+// policies that were active when they arrived.
+func (tsp *tailSamplingSpanProcessor) reloadPolicies(ctx context.Context) error {
+	content, err := tsp.policySource.load(ctx)
+	if err != nil {
+		recordPolicyReload(tsp.ctx, false)
+		return err
+	}
+	if content.Version == tsp.activePolicyVersion() {
+		return nil
+	}
+
+	policies, err := newPolicies(tsp.ctx, tsp.logger, content.PolicyCfgs)
+	if err != nil {
+		recordPolicyReload(tsp.ctx, false)
+		return fmt.Errorf("invalid sampling policies of version %q: %w", content.Version, err)
+	}
+
+	tsp.policiesMu.Lock()
+	tsp.policies = policies
+	tsp.policyVersion = content.Version
+	tsp.policiesMu.Unlock()
+
+	recordPolicyReload(tsp.ctx, true)
+	tsp.logger.Info("Sampling policies loaded",
+		zap.String("version", content.Version),
+		zap.Int("policies", len(policies)))
+	return nil
+}
+
+func (tsp *tailSamplingSpanProcessor) activePolicyVersion() string {
+	tsp.policiesMu.RLock()
+	defer tsp.policiesMu.RUnlock()
+	return tsp.policyVersion
+}
+
+// recordPolicyReload counts an attempt to load the policies. The version isn't used as a tag as it can take
+// arbitrary values, it's logged instead.
+func recordPolicyReload(ctx context.Context, success bool) {
+	successTag := "false"
+	if success {
+		successTag = "true"
+	}
+	_ = stats.RecordWithTags(
+		ctx,
+		[]tag.Mutator{tag.Insert(tagSuccessKey, successTag)},
+		statPolicyReloadCount.M(int64(1)),
+	)
+}
+
 func (tsp *tailSamplingSpanProcessor) dropTrace(traceID pdata.TraceID, deletionTime time.Time) {
-	var trace *sampling.TraceData
+	var trace *traceWithPolicies
 	if d, ok := tsp.idToTrace.Load(traceID); ok {
-		trace = d.(*sampling.TraceData)
+		trace = d.(*traceWithPolicies)
 		tsp.idToTrace.Delete(traceID)
 		// Subtract one from numTracesOnMap per https://godoc.org/sync/atomic#AddUint64
 		atomic.AddUint64(&tsp.numTracesOnMap, ^uint64(0))
@@ -19,16 +19,21 @@ import (
 	"encoding/binary"
 	"errors"
 	"sort"
+	"strings"
 	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
 	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configtelemetry"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.uber.org/zap"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/cache"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/idbatcher"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor/internal/sampling"
 )
@@ -62,7 +67,7 @@ func TestSequentialTraceArrival(t *testing.T) {
 	for i := range traceIds {
 		d, ok := tsp.idToTrace.Load(traceIds[i])
 		require.True(t, ok, "Missing expected traceId")
-		v := d.(*sampling.TraceData)
+		v := d.(*traceWithPolicies)
 		require.Equal(t, int64(i+1), v.SpanCount, "Incorrect number of spans for entry %d", i)
 	}
 }
@@ -103,7 +108,7 @@ func TestConcurrentTraceArrival(t *testing.T) {
 	for i := range traceIds {
 		d, ok := tsp.idToTrace.Load(traceIds[i])
 		require.True(t, ok, "Missing expected traceId")
-		v := d.(*sampling.TraceData)
+		v := d.(*traceWithPolicies)
 		require.Equal(t, int64(i+1)*2, v.SpanCount, "Incorrect number of spans for entry %d", i)
 	}
 }
@@ -235,6 +240,64 @@ func TestSamplingPolicyTypicalPath(t *testing.T) {
 	require.Equal(t, 1, mpe.LateArrivingSpanCount, "policy was not notified of the late span")
 }
 
+func TestLateSpansInheritCachedDecision(t *testing.T) {
+	// Only one trace is kept in memory, so every new trace removes the previous one.
+	const maxSize = 1
+	msp := new(consumertest.TracesSink)
+	mpe := &mockPolicyEvaluator{}
+	tsp := &tailSamplingSpanProcessor{
+		ctx:               context.Background(),
+		nextConsumer:      msp,
+		maxNumTraces:      maxSize,
+		logger:            zap.NewNop(),
+		decisionBatcher:   newSyncIDBatcher(1),
+		policies:          []*policy{{name: "mock-policy", evaluator: mpe, ctx: context.TODO()}},
+		deleteChan:        make(chan pdata.TraceID, maxSize),
+		policyTicker:      &manualTTicker{},
+		tickerFrequency:   100 * time.Millisecond,
+		sampledIDCache:    cache.New(10),
+		nonSampledIDCache: cache.New(10),
+		useDecisionCache:  true,
+	}
+	require.NoError(t, tsp.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		require.NoError(t, tsp.Shutdown(context.Background()))
+	}()
+
+	// batches[0] belongs to the first trace, batches[1:3] to the second and batches[3:6] to the third one.
+	traceIds, batches := generateIdsAndBatches(3)
+
+	mpe.NextDecision = sampling.Sampled
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 1, msp.SpanCount())
+
+	// The second trace removes the sampled first trace from memory.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[1]))
+	_, ok := tsp.idToTrace.Load(traceIds[0])
+	require.False(t, ok)
+
+	// A late span of the first trace is sent down the pipeline without a new evaluation.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[0]))
+	require.Equal(t, 2, msp.SpanCount())
+	_, ok = tsp.idToTrace.Load(traceIds[0])
+	require.False(t, ok)
+
+	mpe.NextDecision = sampling.NotSampled
+	tsp.samplingPolicyOnTick()
+	tsp.samplingPolicyOnTick()
+	require.Equal(t, 2, mpe.EvaluationCount)
+
+	// The third trace removes the not sampled second trace from memory, whose late span is dropped.
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[3]))
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), batches[2]))
+	require.Equal(t, 2, msp.SpanCount())
+	_, ok = tsp.idToTrace.Load(traceIds[1])
+	require.False(t, ok)
+	require.Equal(t, 2, mpe.EvaluationCount)
+}
+
 func TestSamplingPolicyInvertSampled(t *testing.T) {
 	const maxSize = 100
 	const decisionWaitSeconds = 5
@@ -367,6 +430,61 @@ func TestSamplingMultiplePolicies(t *testing.T) {
 	require.Equal(t, 0, mpe2.LateArrivingSpanCount, "2nd policy should not have been notified of the late span")
 }
 
+func TestPolicyDecisionMetrics(t *testing.T) {
+	var decisionsView *view.View
+	for _, v := range SamplingProcessorMetricViews(configtelemetry.LevelNormal) {
+		if v.Measure == statCountPolicyDecisions {
+			decisionsView = v
+		}
+	}
+	require.NotNil(t, decisionsView)
+	require.NoError(t, view.Register(decisionsView))
+	defer view.Unregister(decisionsView)
+
+	policyCtx := func(name string) context.Context {
+		ctx, err := tag.New(context.Background(), tag.Upsert(tagPolicyKey, name))
+		require.NoError(t, err)
+		return ctx
+	}
+	tsp := &tailSamplingSpanProcessor{
+		logger: zap.NewNop(),
+		policies: []*policy{
+			{name: "metrics-sampling", evaluator: &mockPolicyEvaluator{NextDecision: sampling.Sampled}, ctx: policyCtx("metrics-sampling")},
+			{name: "metrics-not-sampling", evaluator: &mockPolicyEvaluator{NextDecision: sampling.NotSampled}, ctx: policyCtx("metrics-not-sampling")},
+			{name: "metrics-failing", evaluator: &mockPolicyEvaluator{NextError: errors.New("failed")}, ctx: policyCtx("metrics-failing")},
+		},
+	}
+
+	trace := &traceWithPolicies{TraceData: &sampling.TraceData{Decisions: make([]sampling.Decision, len(tsp.policies))}, policies: tsp.policies}
+	decision, _ := tsp.makeDecision(pdata.NewTraceID([16]byte{1}), trace, &policyMetrics{})
+	require.Equal(t, sampling.Sampled, decision)
+
+	rows, err := view.RetrieveData(decisionsView.Name)
+	require.NoError(t, err)
+	decisions := map[string]string{}
+	for _, row := range rows {
+		var policyName, decisionName string
+		for _, tg := range row.Tags {
+			switch tg.Key {
+			case tagPolicyKey:
+				policyName = tg.Value
+			case tagDecisionKey:
+				decisionName = tg.Value
+			}
+		}
+		if !strings.HasPrefix(policyName, "metrics-") {
+			continue
+		}
+		require.Equal(t, float64(1), row.Data.(*view.SumData).Value)
+		decisions[policyName] = decisionName
+	}
+	require.Equal(t, map[string]string{
+		"metrics-sampling":     "sampled",
+		"metrics-not-sampling": "not_sampled",
+		"metrics-failing":      "error",
+	}, decisions)
+}
+
 func TestSamplingPolicyDecisionNotSampled(t *testing.T) {
 	const maxSize = 100
 	const decisionWaitSeconds = 5
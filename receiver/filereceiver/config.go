@@ -0,0 +1,71 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filereceiver"
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/collector/config"
+)
+
+const (
+	formatTypeJSON  = "json"
+	formatTypeProto = "proto"
+)
+
+// Config defines configuration for the file receiver.
+type Config struct {
+	config.ReceiverSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct
+
+	// Path of the files to read, as written by the file exporter. Glob patterns are supported, so that
+	// the files rotated by the exporter can be replayed as well. The files are read in lexical order.
+	Path string `mapstructure:"path"`
+
+	// FormatType is the format of the files: "json" (default) or "proto", matching the format of the file exporter.
+	FormatType string `mapstructure:"format"`
+
+	// Tail keeps reading the last file as data is appended to it, instead of stopping at its end.
+	// When the file is rotated, the new file created in its place is tailed.
+	Tail bool `mapstructure:"tail"`
+
+	// PollInterval is how often the last file is checked for new data when tailing.
+	PollInterval time.Duration `mapstructure:"poll_interval"`
+}
+
+var _ config.Receiver = (*Config)(nil)
+
+// Validate checks if the receiver configuration is valid
+func (cfg *Config) Validate() error {
+	if cfg.Path == "" {
+		return errors.New("path must be non-empty")
+	}
+
+	if _, err := filepath.Match(cfg.Path, ""); err != nil {
+		return fmt.Errorf("path %q is not a valid pattern: %w", cfg.Path, err)
+	}
+
+	if cfg.FormatType != formatTypeJSON && cfg.FormatType != formatTypeProto {
+		return fmt.Errorf("format %q is not supported, use either %q or %q", cfg.FormatType, formatTypeJSON, formatTypeProto)
+	}
+
+	if cfg.Tail && cfg.PollInterval <= 0 {
+		return errors.New("poll_interval must be positive when tailing")
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver
+
+import (
+	"path"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/config/configtest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	factories, err := componenttest.NopFactories()
+	assert.NoError(t, err)
+
+	factory := NewFactory()
+	factories.Receivers[typeStr] = factory
+	cfg, err := configtest.LoadConfigAndValidate(path.Join(".", "testdata", "config.yaml"), factories)
+	require.NoError(t, err)
+	require.NotNil(t, cfg)
+
+	assert.Len(t, cfg.Receivers, 2)
+
+	r0 := cfg.Receivers[config.NewComponentID(typeStr)]
+	assert.Equal(t, r0,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+			Path:             "./telemetry.json",
+			FormatType:       "json",
+			PollInterval:     200 * time.Millisecond,
+		})
+
+	r1 := cfg.Receivers[config.NewComponentIDWithName(typeStr, "2")]
+	assert.Equal(t, r1,
+		&Config{
+			ReceiverSettings: config.NewReceiverSettings(config.NewComponentIDWithName(typeStr, "2")),
+			Path:             "./archive*.pb*",
+			FormatType:       "proto",
+			Tail:             true,
+			PollInterval:     time.Second,
+		})
+}
+
+func TestValidateConfig(t *testing.T) {
+	for _, tt := range []struct {
+		desc string
+		cfg  *Config
+		err  string
+	}{
+		{
+			desc: "valid",
+			cfg:  &Config{Path: "telemetry*.json", FormatType: "json"},
+		},
+		{
+			desc: "missing path",
+			cfg:  &Config{FormatType: "json"},
+			err:  "path must be non-empty",
+		},
+		{
+			desc: "invalid pattern",
+			cfg:  &Config{Path: "telemetry[.json", FormatType: "json"},
+			err:  `path "telemetry[.json" is not a valid pattern: syntax error in pattern`,
+		},
+		{
+			desc: "unsupported format",
+			cfg:  &Config{Path: "telemetry.json", FormatType: "xml"},
+			err:  `format "xml" is not supported, use either "json" or "proto"`,
+		},
+		{
+			desc: "tail without poll interval",
+			cfg:  &Config{Path: "telemetry.json", FormatType: "json", Tail: true},
+			err:  "poll_interval must be positive when tailing",
+		},
+	} {
+		t.Run(tt.desc, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.err)
+			}
+		})
+	}
+}
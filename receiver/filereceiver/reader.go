@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filereceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+)
+
+// sizePrefixLength is the length of the size preceding each message in the proto format
+const sizePrefixLength = 4
+
+var errTruncatedMessage = errors.New("the file ends with a truncated message")
+
+// messageReader splits the contents of a file into the messages written by the file exporter.
+// When the end of the file is reached in the middle of a message, the data read so far is kept,
+// so that the reading can be resumed once more data is appended to the file.
+type messageReader struct {
+	format  string
+	reader  *bufio.Reader
+	pending []byte
+}
+
+func newMessageReader(format string, r io.Reader) *messageReader {
+	return &messageReader{
+		format: format,
+		reader: bufio.NewReader(r),
+	}
+}
+
+// next returns the next complete message, or io.EOF if there's none available yet
+func (r *messageReader) next() ([]byte, error) {
+	if r.format == formatTypeProto {
+		return r.nextProto()
+	}
+	return r.nextLine()
+}
+
+// nextLine returns the next non-empty line, without its line break
+func (r *messageReader) nextLine() ([]byte, error) {
+	for {
+		line, err := r.reader.ReadBytes('\n')
+		r.pending = append(r.pending, line...)
+		if err != nil {
+			return nil, err
+		}
+
+		msg := bytes.TrimSpace(r.pending)
+		r.pending = nil
+		if len(msg) > 0 {
+			return msg, nil
+		}
+	}
+}
+
+// nextProto returns the next message, as preceded by its size as a 4-byte big-endian unsigned integer
+func (r *messageReader) nextProto() ([]byte, error) {
+	if err := r.fill(sizePrefixLength); err != nil {
+		return nil, err
+	}
+
+	size := int(binary.BigEndian.Uint32(r.pending[:sizePrefixLength]))
+	if err := r.fill(sizePrefixLength + size); err != nil {
+		return nil, err
+	}
+
+	msg := r.pending[sizePrefixLength:]
+	r.pending = nil
+	return msg, nil
+}
+
+// fill reads until there are n bytes pending
+func (r *messageReader) fill(n int) error {
+	for len(r.pending) < n {
+		buf := make([]byte, n-len(r.pending))
+		read, err := r.reader.Read(buf)
+		r.pending = append(r.pending, buf[:read]...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// last returns the message left at the end of a file that won't grow anymore, if any.
+// In the json format, the last line might lack its line break, but a message is never
+// incomplete in the proto format.
+func (r *messageReader) last() ([]byte, error) {
+	pending := r.pending
+	r.pending = nil
+
+	if r.format == formatTypeProto {
+		if len(pending) > 0 {
+			return nil, errTruncatedMessage
+		}
+		return nil, nil
+	}
+
+	return bytes.TrimSpace(pending), nil
+}
@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadJSONLines(t *testing.T) {
+	// prepare
+	data := &bytes.Buffer{}
+	reader := newMessageReader(formatTypeJSON, data)
+	data.WriteString("{\"first\":1}\n\n{\"sec")
+
+	// test and verify
+	msg, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, `{"first":1}`, string(msg))
+
+	_, err = reader.next()
+	assert.Equal(t, io.EOF, err)
+
+	// the rest of the line is appended later on
+	data.WriteString("ond\":2}\n{\"third\":3}")
+	msg, err = reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, `{"second":2}`, string(msg))
+
+	_, err = reader.next()
+	assert.Equal(t, io.EOF, err)
+
+	msg, err = reader.last()
+	require.NoError(t, err)
+	assert.Equal(t, `{"third":3}`, string(msg))
+}
+
+func TestReadProtoMessages(t *testing.T) {
+	// prepare
+	data := &bytes.Buffer{}
+	reader := newMessageReader(formatTypeProto, data)
+	first, second := sizePrefixed([]byte("first")), sizePrefixed([]byte("second"))
+	data.Write(first)
+	data.Write(second[:6])
+
+	// test and verify
+	msg, err := reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, "first", string(msg))
+
+	_, err = reader.next()
+	assert.Equal(t, io.EOF, err)
+
+	// the rest of the message is appended later on
+	data.Write(second[6:])
+	msg, err = reader.next()
+	require.NoError(t, err)
+	assert.Equal(t, "second", string(msg))
+
+	_, err = reader.next()
+	assert.Equal(t, io.EOF, err)
+
+	msg, err = reader.last()
+	require.NoError(t, err)
+	assert.Empty(t, msg)
+}
+
+func TestReadTruncatedProtoMessage(t *testing.T) {
+	// prepare
+	reader := newMessageReader(formatTypeProto, bytes.NewReader(sizePrefixed([]byte("first"))[:6]))
+
+	// test
+	_, err := reader.next()
+	require.Equal(t, io.EOF, err)
+	_, err = reader.last()
+
+	// verify
+	assert.Equal(t, errTruncatedMessage, err)
+}
+
+func sizePrefixed(msg []byte) []byte {
+	buf := make([]byte, sizePrefixLength, sizePrefixLength+len(msg))
+	binary.BigEndian.PutUint32(buf, uint32(len(msg)))
+	return append(buf, msg...)
+}
@@ -0,0 +1,354 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filereceiver"
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+	"go.opentelemetry.io/collector/obsreport"
+	"go.uber.org/zap"
+)
+
+const transport = "file"
+
+// Unmarshalers for each of the supported formats.
+var tracesUnmarshalers = map[string]pdata.TracesUnmarshaler{
+	formatTypeJSON:  otlp.NewJSONTracesUnmarshaler(),
+	formatTypeProto: otlp.NewProtobufTracesUnmarshaler(),
+}
+var metricsUnmarshalers = map[string]pdata.MetricsUnmarshaler{
+	formatTypeJSON:  otlp.NewJSONMetricsUnmarshaler(),
+	formatTypeProto: otlp.NewProtobufMetricsUnmarshaler(),
+}
+var logsUnmarshalers = map[string]pdata.LogsUnmarshaler{
+	formatTypeJSON:  otlp.NewJSONLogsUnmarshaler(),
+	formatTypeProto: otlp.NewProtobufLogsUnmarshaler(),
+}
+
+// messageConsumer decodes a message read from the files and passes it to the next consumer
+type messageConsumer func(ctx context.Context, msg []byte) error
+
+// jsonMessage holds the top-level fields of the messages written in the json format, telling their signal apart
+type jsonMessage struct {
+	ResourceSpans   json.RawMessage `json:"resourceSpans"`
+	ResourceMetrics json.RawMessage `json:"resourceMetrics"`
+	ResourceLogs    json.RawMessage `json:"resourceLogs"`
+}
+
+// fileReceiver reads the files written by the file exporter, passing the messages of its signal to the next consumer
+type fileReceiver struct {
+	logger  *zap.Logger
+	cfg     *Config
+	consume messageConsumer
+
+	cancel context.CancelFunc
+	doneWg sync.WaitGroup
+}
+
+var _ component.Receiver = (*fileReceiver)(nil)
+
+func newTracesReceiver(set component.ReceiverCreateSettings, cfg *Config, next consumer.Traces) *fileReceiver {
+	obsrecv := newObsReceiver(set, cfg.ID())
+	unmarshaler := tracesUnmarshalers[cfg.FormatType]
+
+	return newFileReceiver(set.Logger, cfg, func(ctx context.Context, msg []byte) error {
+		if cfg.FormatType == formatTypeJSON {
+			fields, err := parseJSONMessage(msg)
+			if err != nil || fields.ResourceSpans == nil {
+				return err
+			}
+		}
+
+		td, err := unmarshaler.UnmarshalTraces(msg)
+		if err != nil {
+			return err
+		}
+
+		ctx = obsrecv.StartTracesOp(ctx)
+		err = next.ConsumeTraces(ctx, td)
+		obsrecv.EndTracesOp(ctx, cfg.FormatType, td.SpanCount(), err)
+		return err
+	})
+}
+
+func newMetricsReceiver(set component.ReceiverCreateSettings, cfg *Config, next consumer.Metrics) *fileReceiver {
+	obsrecv := newObsReceiver(set, cfg.ID())
+	unmarshaler := metricsUnmarshalers[cfg.FormatType]
+
+	return newFileReceiver(set.Logger, cfg, func(ctx context.Context, msg []byte) error {
+		if cfg.FormatType == formatTypeJSON {
+			fields, err := parseJSONMessage(msg)
+			if err != nil || fields.ResourceMetrics == nil {
+				return err
+			}
+		}
+
+		md, err := unmarshaler.UnmarshalMetrics(msg)
+		if err != nil {
+			return err
+		}
+
+		ctx = obsrecv.StartMetricsOp(ctx)
+		err = next.ConsumeMetrics(ctx, md)
+		obsrecv.EndMetricsOp(ctx, cfg.FormatType, md.DataPointCount(), err)
+		return err
+	})
+}
+
+func newLogsReceiver(set component.ReceiverCreateSettings, cfg *Config, next consumer.Logs) *fileReceiver {
+	obsrecv := newObsReceiver(set, cfg.ID())
+	unmarshaler := logsUnmarshalers[cfg.FormatType]
+
+	return newFileReceiver(set.Logger, cfg, func(ctx context.Context, msg []byte) error {
+		if cfg.FormatType == formatTypeJSON {
+			fields, err := parseJSONMessage(msg)
+			if err != nil || fields.ResourceLogs == nil {
+				return err
+			}
+		}
+
+		ld, err := unmarshaler.UnmarshalLogs(msg)
+		if err != nil {
+			return err
+		}
+
+		ctx = obsrecv.StartLogsOp(ctx)
+		err = next.ConsumeLogs(ctx, ld)
+		obsrecv.EndLogsOp(ctx, cfg.FormatType, ld.LogRecordCount(), err)
+		return err
+	})
+}
+
+func newObsReceiver(set component.ReceiverCreateSettings, id config.ComponentID) *obsreport.Receiver {
+	return obsreport.NewReceiver(obsreport.ReceiverSettings{
+		ReceiverID:             id,
+		Transport:              transport,
+		ReceiverCreateSettings: set,
+	})
+}
+
+func newFileReceiver(logger *zap.Logger, cfg *Config, consume messageConsumer) *fileReceiver {
+	return &fileReceiver{
+		logger:  logger,
+		cfg:     cfg,
+		consume: consume,
+	}
+}
+
+func parseJSONMessage(msg []byte) (jsonMessage, error) {
+	var fields jsonMessage
+	err := json.Unmarshal(msg, &fields)
+	return fields, err
+}
+
+func (r *fileReceiver) Start(context.Context, component.Host) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	r.cancel = cancel
+
+	r.doneWg.Add(1)
+	go r.replay(ctx)
+
+	return nil
+}
+
+// Shutdown stops reading the files, waiting for the message being processed
+func (r *fileReceiver) Shutdown(context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.doneWg.Wait()
+	return nil
+}
+
+func (r *fileReceiver) replay(ctx context.Context) {
+	defer r.doneWg.Done()
+
+	files, err := r.findFiles(ctx)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			r.logger.Error("failed to find the files to replay", zap.String("path", r.cfg.Path), zap.Error(err))
+		}
+		return
+	}
+	if len(files) == 0 {
+		r.logger.Warn("no files found to replay", zap.String("path", r.cfg.Path))
+		return
+	}
+
+	for i, file := range files {
+		tail := r.cfg.Tail && i == len(files)-1
+		if err := r.replayFile(ctx, file, tail); err != nil {
+			r.logger.Error("failed to replay the file", zap.String("path", file), zap.Error(err))
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+	}
+
+	r.logger.Info("finished replaying the files", zap.String("path", r.cfg.Path), zap.Int("files", len(files)))
+}
+
+// findFiles returns the files matching the configured path, in lexical order.
+// When tailing, it waits for a file to show up.
+func (r *fileReceiver) findFiles(ctx context.Context) ([]string, error) {
+	for {
+		files, err := filepath.Glob(r.cfg.Path)
+		if err != nil || len(files) > 0 || !r.cfg.Tail {
+			sort.Strings(files)
+			return files, err
+		}
+
+		if err := r.wait(ctx); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// replayFile reads the messages of the given file. Files compressed upon rotation by the
+// file exporter are decompressed and never tailed, as they won't get any more data.
+// A tailed file is followed across rotations: once it's moved to a backup, its remaining
+// messages are read and the new file created in its place is tailed.
+func (r *fileReceiver) replayFile(ctx context.Context, path string, tail bool) error {
+	for {
+		rotated, err := r.readFile(ctx, path, tail)
+		if err != nil || !rotated {
+			return err
+		}
+		r.logger.Debug("the tailed file was rotated, reopening it", zap.String("path", path))
+	}
+}
+
+// readFile reads the messages of the given file, returning whether it stopped tailing it because it was rotated.
+func (r *fileReceiver) readFile(ctx context.Context, path string, tail bool) (bool, error) {
+	f, err := os.Open(path)
+	// Right after a rotation, the new file may not have been created yet.
+	for tail && errors.Is(err, os.ErrNotExist) {
+		if err := r.wait(ctx); err != nil {
+			return false, nil
+		}
+		f, err = os.Open(path)
+	}
+	if err != nil {
+		return false, err
+	}
+	defer f.Close()
+
+	var src io.Reader = f
+	switch filepath.Ext(path) {
+	case ".gz":
+		gz, err := gzip.NewReader(f)
+		if err != nil {
+			return false, err
+		}
+		defer gz.Close()
+		src, tail = gz, false
+	case ".zst":
+		zr, err := zstd.NewReader(f)
+		if err != nil {
+			return false, err
+		}
+		defer zr.Close()
+		src, tail = zr, false
+	}
+
+	rotated := false
+	reader := newMessageReader(r.cfg.FormatType, src)
+	for ctx.Err() == nil {
+		msg, err := reader.next()
+		switch {
+		case err == nil:
+			r.consumeMessage(ctx, path, msg)
+		case errors.Is(err, io.EOF) && tail:
+			if rotated, err = isRotated(f, path); err != nil {
+				return false, err
+			}
+			if rotated {
+				// Read the messages written before the rotation, up to the end of the file.
+				tail = false
+				continue
+			}
+			if err := r.wait(ctx); err != nil {
+				return false, nil
+			}
+		case errors.Is(err, io.EOF):
+			msg, err := reader.last()
+			if err != nil {
+				return false, err
+			}
+			if len(msg) > 0 {
+				r.consumeMessage(ctx, path, msg)
+			}
+			return rotated, nil
+		default:
+			return false, err
+		}
+	}
+
+	return false, nil
+}
+
+// isRotated tells whether the opened file is no longer the one found at the given path
+func isRotated(f *os.File, path string) (bool, error) {
+	current, err := os.Stat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	opened, err := f.Stat()
+	if err != nil {
+		return false, err
+	}
+	return !os.SameFile(opened, current), nil
+}
+
+// consumeMessage passes the message to the next consumer. Failures are logged, so that
+// a single bad message doesn't prevent the rest of the file from being replayed.
+func (r *fileReceiver) consumeMessage(ctx context.Context, path string, msg []byte) {
+	if err := r.consume(ctx, msg); err != nil {
+		r.logger.Warn("failed to consume a message from the file", zap.String("path", path), zap.Error(err))
+	}
+}
+
+// wait sleeps for the poll interval, returning early with an error if the receiver is shut down
+func (r *fileReceiver) wait(ctx context.Context) error {
+	timer := time.NewTimer(r.cfg.PollInterval)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package filereceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/model/otlp"
+	"go.opentelemetry.io/collector/model/pdata"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testdata"
+)
+
+func TestReplayJSONFile(t *testing.T) {
+	// prepare
+	td := testdata.GenerateTracesTwoSpansSameResource()
+	md := testdata.GenerateMetricsTwoMetrics()
+	ld := testdata.GenerateLogsTwoLogRecordsSameResource()
+
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	data := &bytes.Buffer{}
+	writeJSONLine(t, data, td)
+	data.WriteString("not json\n")
+	writeJSONLine(t, data, md)
+	writeJSONLine(t, data, ld)
+	require.NoError(t, ioutil.WriteFile(path, data.Bytes(), 0600))
+
+	cfg := testConfig(path, formatTypeJSON)
+	tracesSink := new(consumertest.TracesSink)
+	metricsSink := new(consumertest.MetricsSink)
+	logsSink := new(consumertest.LogsSink)
+	set := componenttest.NewNopReceiverCreateSettings()
+	receivers := []*fileReceiver{
+		newTracesReceiver(set, cfg, tracesSink),
+		newMetricsReceiver(set, cfg, metricsSink),
+		newLogsReceiver(set, cfg, logsSink),
+	}
+
+	// test
+	for _, r := range receivers {
+		require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	}
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return len(tracesSink.AllTraces()) == 1 && len(metricsSink.AllMetrics()) == 1 && len(logsSink.AllLogs()) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, td, tracesSink.AllTraces()[0])
+	assert.Equal(t, md, metricsSink.AllMetrics()[0])
+	assert.Equal(t, ld, logsSink.AllLogs()[0])
+
+	for _, r := range receivers {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	}
+}
+
+func TestReplayRotatedProtoFiles(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	marshaler := otlp.NewProtobufMetricsMarshaler()
+	var expected []pdata.Metrics
+	for i, name := range []string{
+		"archive-2021-11-22T10-00-00.000.pb.gz",
+		"archive-2021-11-22T11-00-00.000.pb.zst",
+		"archive.pb",
+	} {
+		md := testdata.GenerateMetricsOneMetric()
+		md.ResourceMetrics().At(0).Resource().Attributes().InsertInt("file", int64(i))
+		expected = append(expected, md)
+
+		buf, err := marshaler.MarshalMetrics(md)
+		require.NoError(t, err)
+		writeCompressed(t, filepath.Join(dir, name), sizePrefixed(buf))
+	}
+
+	sink := new(consumertest.MetricsSink)
+	r := newMetricsReceiver(componenttest.NewNopReceiverCreateSettings(), testConfig(filepath.Join(dir, "archive*.pb*"), formatTypeProto), sink)
+
+	// test
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return len(sink.AllMetrics()) == len(expected)
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, expected, sink.AllMetrics())
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
+func TestTailFile(t *testing.T) {
+	// prepare
+	path := filepath.Join(t.TempDir(), "telemetry.json")
+	cfg := testConfig(path, formatTypeJSON)
+	cfg.Tail = true
+
+	sink := new(consumertest.TracesSink)
+	r := newTracesReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer r.Shutdown(context.Background())
+
+	// test
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	defer f.Close()
+
+	data := &bytes.Buffer{}
+	writeJSONLine(t, data, testdata.GenerateTracesOneSpan())
+	_, err = f.Write(data.Bytes()[:10])
+	require.NoError(t, err)
+
+	// verify
+	time.Sleep(5 * cfg.PollInterval)
+	assert.Empty(t, sink.AllTraces(), "the partial line shouldn't be consumed")
+
+	_, err = f.Write(data.Bytes()[10:])
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	_, err = f.Write(data.Bytes())
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestTailRotatedFile(t *testing.T) {
+	// prepare
+	dir := t.TempDir()
+	path := filepath.Join(dir, "telemetry.json")
+	cfg := testConfig(path, formatTypeJSON)
+	cfg.Tail = true
+
+	data := &bytes.Buffer{}
+	writeJSONLine(t, data, testdata.GenerateTracesOneSpan())
+	require.NoError(t, ioutil.WriteFile(path, data.Bytes(), 0600))
+
+	sink := new(consumertest.TracesSink)
+	r := newTracesReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, sink)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	defer r.Shutdown(context.Background())
+
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// test
+	// The file exporter writes a last message before moving the file to a backup and starting a new one.
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	_, err = f.Write(data.Bytes())
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+	require.NoError(t, os.Rename(path, filepath.Join(dir, "telemetry-2021-11-22T10-00-00.000.json")))
+
+	time.Sleep(5 * cfg.PollInterval)
+	require.NoError(t, ioutil.WriteFile(path, data.Bytes(), 0600))
+
+	// verify
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	f, err = os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0600)
+	require.NoError(t, err)
+	defer f.Close()
+	_, err = f.Write(data.Bytes())
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return sink.SpanCount() == 4
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestShutdownWithoutFiles(t *testing.T) {
+	cfg := testConfig(filepath.Join(t.TempDir(), "*.json"), formatTypeJSON)
+	cfg.Tail = true
+
+	r := newLogsReceiver(componenttest.NewNopReceiverCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, r.Shutdown(context.Background()))
+}
+
+func testConfig(path string, format string) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Path = path
+	cfg.FormatType = format
+	cfg.PollInterval = 10 * time.Millisecond
+	return cfg
+}
+
+func writeJSONLine(t *testing.T, buf *bytes.Buffer, data interface{}) {
+	var msg []byte
+	var err error
+	switch d := data.(type) {
+	case pdata.Traces:
+		msg, err = otlp.NewJSONTracesMarshaler().MarshalTraces(d)
+	case pdata.Metrics:
+		msg, err = otlp.NewJSONMetricsMarshaler().MarshalMetrics(d)
+	case pdata.Logs:
+		msg, err = otlp.NewJSONLogsMarshaler().MarshalLogs(d)
+	}
+	require.NoError(t, err)
+	buf.Write(msg)
+	buf.WriteByte('\n')
+}
+
+// writeCompressed writes the data to the given file, compressed according to its extension
+func writeCompressed(t *testing.T, path string, data []byte) {
+	buf := &bytes.Buffer{}
+	switch filepath.Ext(path) {
+	case ".gz":
+		w := gzip.NewWriter(buf)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	case ".zst":
+		w, err := zstd.NewWriter(buf)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	default:
+		buf.Write(data)
+	}
+	require.NoError(t, ioutil.WriteFile(path, buf.Bytes(), 0600))
+}
@@ -0,0 +1,49 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/kafkareceiver"
+
+import (
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+// rawLogsUnmarshaler turns each message into a log record whose body holds the message payload:
+// a string body if the payload is valid UTF-8, as the raw marshaler of the kafka exporter sends
+// string bodies, and a bytes body otherwise.
+type rawLogsUnmarshaler struct{}
+
+var _ LogsUnmarshaler = rawLogsUnmarshaler{}
+
+func newRawLogsUnmarshaler() LogsUnmarshaler {
+	return rawLogsUnmarshaler{}
+}
+
+func (r rawLogsUnmarshaler) Unmarshal(buf []byte) (pdata.Logs, error) {
+	ld := pdata.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	if utf8.Valid(buf) {
+		lr.Body().SetStringVal(string(buf))
+		return ld, nil
+	}
+	body := make([]byte, len(buf))
+	copy(body, buf)
+	lr.Body().SetBytesVal(body)
+	return ld, nil
+}
+
+func (r rawLogsUnmarshaler) Encoding() string {
+	return "raw"
+}
@@ -0,0 +1,54 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package kafkareceiver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func TestRawLogsUnmarshaler(t *testing.T) {
+	um := newRawLogsUnmarshaler()
+	assert.Equal(t, "raw", um.Encoding())
+
+	buf := []byte("plain text log")
+	ld, err := um.Unmarshal(buf)
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+
+	body := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Body()
+	assert.Equal(t, pdata.AttributeValueTypeString, body.Type())
+	assert.Equal(t, "plain text log", body.StringVal())
+}
+
+func TestRawLogsUnmarshalerBinary(t *testing.T) {
+	um := newRawLogsUnmarshaler()
+
+	buf := []byte{0xff, 0xfe, 0x00, 0x01}
+	ld, err := um.Unmarshal(buf)
+	require.NoError(t, err)
+	require.Equal(t, 1, ld.LogRecordCount())
+
+	body := ld.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs().At(0).Body()
+	assert.Equal(t, pdata.AttributeValueTypeBytes, body.Type())
+	assert.Equal(t, buf, body.BytesVal())
+
+	// The body does not share the message buffer.
+	buf[0] = 0x00
+	assert.Equal(t, []byte{0xff, 0xfe, 0x00, 0x01}, body.BytesVal())
+}
@@ -24,6 +24,7 @@ import (
 func TestDefaultTracesUnMarshaler(t *testing.T) {
 	expectedEncodings := []string{
 		"otlp_proto",
+		"otlp_json",
 		"jaeger_proto",
 		"jaeger_json",
 		"zipkin_proto",
@@ -44,6 +45,7 @@ func TestDefaultTracesUnMarshaler(t *testing.T) {
 func TestDefaultMetricsUnMarshaler(t *testing.T) {
 	expectedEncodings := []string{
 		"otlp_proto",
+		"otlp_json",
 	}
 	marshalers := defaultMetricsUnmarshalers()
 	assert.Equal(t, len(expectedEncodings), len(marshalers))
@@ -59,6 +61,8 @@ func TestDefaultMetricsUnMarshaler(t *testing.T) {
 func TestDefaultLogsUnMarshaler(t *testing.T) {
 	expectedEncodings := []string{
 		"otlp_proto",
+		"otlp_json",
+		"raw",
 	}
 	marshalers := defaultLogsUnmarshalers()
 	assert.Equal(t, len(expectedEncodings), len(marshalers))
@@ -18,12 +18,14 @@ import (
 	"context"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 )
 
 // This file implements factory for receiver_creator. A receiver_creator can create other receivers at runtime.
@@ -37,7 +39,9 @@ func NewFactory() component.ReceiverFactory {
 	return receiverhelper.NewFactory(
 		typeStr,
 		createDefaultConfig,
-		receiverhelper.WithMetrics(createMetricsReceiver))
+		receiverhelper.WithLogs(createLogsReceiver),
+		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithTraces(createTracesReceiver))
 }
 
 func createDefaultConfig() config.Receiver {
@@ -59,11 +63,60 @@ func createDefaultConfig() config.Receiver {
 	}
 }
 
+func createLogsReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	if consumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newReceiverCreator(params, cfg.(*Config))
+	})
+	r.Unwrap().(*receiverCreator).nextConsumers.logs = consumer
+	return r, nil
+}
+
 func createMetricsReceiver(
 	ctx context.Context,
 	params component.ReceiverCreateSettings,
 	cfg config.Receiver,
 	consumer consumer.Metrics,
 ) (component.MetricsReceiver, error) {
-	return newReceiverCreator(params, cfg.(*Config), consumer)
+	if consumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newReceiverCreator(params, cfg.(*Config))
+	})
+	r.Unwrap().(*receiverCreator).nextConsumers.metrics = consumer
+	return r, nil
 }
+
+func createTracesReceiver(
+	ctx context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Traces,
+) (component.TracesReceiver, error) {
+	if consumer == nil {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		return newReceiverCreator(params, cfg.(*Config))
+	})
+	r.Unwrap().(*receiverCreator).nextConsumers.traces = consumer
+	return r, nil
+}
+
+// This is the map of already created receiver_creators for particular configurations.
+// We maintain this map because the Factory is asked trace, metric and log receivers separately
+// when it gets CreateTracesReceiver(), CreateMetricsReceiver() and CreateLogsReceiver() but
+// they must not create separate objects, they must use one receiver_creator object per
+// configuration, so that a discovered endpoint starts one set of receivers for all the pipelines.
+var receivers = sharedcomponent.NewSharedComponents()
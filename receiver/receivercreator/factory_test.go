@@ -22,6 +22,8 @@ import (
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 )
 
 func TestCreateReceiver(t *testing.T) {
@@ -37,8 +39,22 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NoError(t, err, "receiver creation failed")
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 
+	lReceiver, err := factory.CreateLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err, "receiver creation failed")
+	assert.Same(t, tReceiver, lReceiver, "receivers of the same config must be shared")
+
+	trReceiver, err := factory.CreateTracesReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err, "receiver creation failed")
+	assert.Same(t, tReceiver, trReceiver, "receivers of the same config must be shared")
+
+	rc := tReceiver.(*sharedcomponent.SharedComponent).Unwrap().(*receiverCreator)
+	assert.NotNil(t, rc.nextConsumers.logs)
+	assert.NotNil(t, rc.nextConsumers.metrics)
+	assert.NotNil(t, rc.nextConsumers.traces)
+	assert.NoError(t, tReceiver.Shutdown(context.Background()))
+
 	mReceiver, err := factory.CreateTracesReceiver(context.Background(), params, cfg, nil)
 	assert.Error(t, err)
-	assert.ErrorIs(t, err, componenterror.ErrDataTypeIsNotSupported)
+	assert.ErrorIs(t, err, componenterror.ErrNilNextConsumer)
 	assert.Nil(t, mReceiver)
 }
@@ -18,7 +18,6 @@ import (
 	"fmt"
 	"sync"
 
-	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/multierr"
 	"go.uber.org/zap"
 
@@ -36,8 +35,8 @@ type observerHandler struct {
 	logger *zap.Logger
 	// receiversByEndpointID is a map of endpoint IDs to a receiver instance.
 	receiversByEndpointID receiverMap
-	// nextConsumer is the receiver_creator's own consumer
-	nextConsumer consumer.Metrics
+	// nextConsumers are the receiver_creator's own consumers
+	nextConsumers nextConsumers
 	// runner starts and stops receiver instances.
 	runner runner
 }
@@ -115,7 +114,7 @@ func (obs *observerHandler) OnAdd(added []observer.Endpoint) {
 				obs.config.ResourceAttributes,
 				env,
 				e,
-				obs.nextConsumer,
+				obs.nextConsumers,
 			)
 
 			if err != nil {
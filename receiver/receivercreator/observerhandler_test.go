@@ -21,7 +21,6 @@ import (
 	"github.com/stretchr/testify/mock"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config"
-	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
@@ -34,7 +33,7 @@ type mockRunner struct {
 func (run *mockRunner) start(
 	receiver receiverConfig,
 	discoveredConfig userConfigMap,
-	nextConsumer consumer.Metrics,
+	nextConsumer *resourceEnhancer,
 ) (component.Receiver, error) {
 	args := run.Called(receiver, discoveredConfig, nextConsumer)
 	return args.Get(0).(component.Receiver), args.Error(1)
@@ -19,7 +19,6 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/consumer"
 	"go.uber.org/zap"
@@ -27,28 +26,31 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
 )
 
-var _ component.MetricsReceiver = (*receiverCreator)(nil)
+var _ component.Receiver = (*receiverCreator)(nil)
 
-// receiverCreator implements consumer.Metrics.
+// nextConsumers holds the consumers of the pipelines the receiver_creator is
+// part of. The consumer of a data type is nil if no pipeline of that type
+// uses the receiver_creator.
+type nextConsumers struct {
+	logs    consumer.Logs
+	metrics consumer.Metrics
+	traces  consumer.Traces
+}
+
+// receiverCreator starts receivers for the endpoints discovered by observers.
 type receiverCreator struct {
 	params          component.ReceiverCreateSettings
 	cfg             *Config
-	nextConsumer    consumer.Metrics
+	nextConsumers   nextConsumers
 	observerHandler observerHandler
 }
 
 // newReceiverCreator creates the receiver_creator with the given parameters.
-func newReceiverCreator(params component.ReceiverCreateSettings, cfg *Config, nextConsumer consumer.Metrics) (component.MetricsReceiver, error) {
-	if nextConsumer == nil {
-		return nil, componenterror.ErrNilNextConsumer
-	}
-
-	r := &receiverCreator{
-		params:       params,
-		cfg:          cfg,
-		nextConsumer: nextConsumer,
+func newReceiverCreator(params component.ReceiverCreateSettings, cfg *Config) *receiverCreator {
+	return &receiverCreator{
+		params: params,
+		cfg:    cfg,
 	}
-	return r, nil
 }
 
 // loggingHost provides a safer version of host that logs errors instead of exiting the process.
@@ -70,7 +72,7 @@ func (rc *receiverCreator) Start(_ context.Context, host component.Host) error {
 		config:                rc.cfg,
 		logger:                rc.params.Logger,
 		receiversByEndpointID: receiverMap{},
-		nextConsumer:          rc.nextConsumer,
+		nextConsumers:         rc.nextConsumers,
 		runner: &receiverRunner{
 			params:      rc.params,
 			idNamespace: rc.cfg.ID(),
@@ -24,21 +24,25 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
 )
 
-var _ consumer.Metrics = (*resourceEnhancer)(nil)
+var (
+	_ consumer.Logs    = (*resourceEnhancer)(nil)
+	_ consumer.Metrics = (*resourceEnhancer)(nil)
+	_ consumer.Traces  = (*resourceEnhancer)(nil)
+)
 
 // resourceEnhancer adds additional resource attribute entries
 // from the given endpoint environment. The added attributes vary based on the type
 // of the endpoint.
 type resourceEnhancer struct {
-	nextConsumer consumer.Metrics
-	attrs        map[string]string
+	nextConsumers nextConsumers
+	attrs         map[string]string
 }
 
 func newResourceEnhancer(
 	resources resourceAttributes,
 	env observer.EndpointEnv,
 	endpoint observer.Endpoint,
-	nextConsumer consumer.Metrics,
+	nextConsumers nextConsumers,
 ) (*resourceEnhancer, error) {
 	attrs := map[string]string{}
 
@@ -57,8 +61,8 @@ func newResourceEnhancer(
 	}
 
 	return &resourceEnhancer{
-		nextConsumer: nextConsumer,
-		attrs:        attrs,
+		nextConsumers: nextConsumers,
+		attrs:         attrs,
 	}, nil
 }
 
@@ -66,16 +70,38 @@ func (r *resourceEnhancer) Capabilities() consumer.Capabilities {
 	return consumer.Capabilities{MutatesData: true}
 }
 
+func (r *resourceEnhancer) ConsumeLogs(ctx context.Context, ld pdata.Logs) error {
+	rl := ld.ResourceLogs()
+	for i := 0; i < rl.Len(); i++ {
+		r.enhance(rl.At(i).Resource())
+	}
+
+	return r.nextConsumers.logs.ConsumeLogs(ctx, ld)
+}
+
 func (r *resourceEnhancer) ConsumeMetrics(ctx context.Context, md pdata.Metrics) error {
 	rm := md.ResourceMetrics()
 	for i := 0; i < rm.Len(); i++ {
-		rms := rm.At(i)
-		attrs := rms.Resource().Attributes()
+		r.enhance(rm.At(i).Resource())
+	}
 
-		for attr, val := range r.attrs {
-			attrs.InsertString(attr, val)
-		}
+	return r.nextConsumers.metrics.ConsumeMetrics(ctx, md)
+}
+
+func (r *resourceEnhancer) ConsumeTraces(ctx context.Context, td pdata.Traces) error {
+	rs := td.ResourceSpans()
+	for i := 0; i < rs.Len(); i++ {
+		r.enhance(rs.At(i).Resource())
 	}
 
-	return r.nextConsumer.ConsumeMetrics(ctx, md)
+	return r.nextConsumers.traces.ConsumeTraces(ctx, td)
+}
+
+// enhance inserts the endpoint attributes into the resource, keeping the
+// attributes already set by the receiver.
+func (r *resourceEnhancer) enhance(resource pdata.Resource) {
+	attrs := resource.Attributes()
+	for attr, val := range r.attrs {
+		attrs.InsertString(attr, val)
+	}
 }
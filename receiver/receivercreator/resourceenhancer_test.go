@@ -20,7 +20,6 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
-	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/model/pdata"
 
@@ -39,10 +38,10 @@ func Test_newResourceEnhancer(t *testing.T) {
 
 	cfg := createDefaultConfig().(*Config)
 	type args struct {
-		resources    resourceAttributes
-		env          observer.EndpointEnv
-		endpoint     observer.Endpoint
-		nextConsumer consumer.Metrics
+		resources     resourceAttributes
+		env           observer.EndpointEnv
+		endpoint      observer.Endpoint
+		nextConsumers nextConsumers
 	}
 	tests := []struct {
 		name    string
@@ -53,13 +52,13 @@ func Test_newResourceEnhancer(t *testing.T) {
 		{
 			name: "pod endpoint",
 			args: args{
-				resources:    cfg.ResourceAttributes,
-				env:          podEnv,
-				endpoint:     podEndpoint,
-				nextConsumer: &consumertest.MetricsSink{},
+				resources:     cfg.ResourceAttributes,
+				env:           podEnv,
+				endpoint:      podEndpoint,
+				nextConsumers: nextConsumers{metrics: &consumertest.MetricsSink{}},
 			},
 			want: &resourceEnhancer{
-				nextConsumer: &consumertest.MetricsSink{},
+				nextConsumers: nextConsumers{metrics: &consumertest.MetricsSink{}},
 				attrs: map[string]string{
 					"k8s.pod.uid":        "uid-1",
 					"k8s.pod.name":       "pod-1",
@@ -71,13 +70,13 @@ func Test_newResourceEnhancer(t *testing.T) {
 		{
 			name: "port endpoint",
 			args: args{
-				resources:    cfg.ResourceAttributes,
-				env:          portEnv,
-				endpoint:     portEndpoint,
-				nextConsumer: &consumertest.MetricsSink{},
+				resources:     cfg.ResourceAttributes,
+				env:           portEnv,
+				endpoint:      portEndpoint,
+				nextConsumers: nextConsumers{metrics: &consumertest.MetricsSink{}},
 			},
 			want: &resourceEnhancer{
-				nextConsumer: &consumertest.MetricsSink{},
+				nextConsumers: nextConsumers{metrics: &consumertest.MetricsSink{}},
 				attrs: map[string]string{
 					"k8s.pod.uid":        "uid-1",
 					"k8s.pod.name":       "pod-1",
@@ -96,12 +95,12 @@ func Test_newResourceEnhancer(t *testing.T) {
 					res[observer.PodType]["k8s.pod.name"] = ""
 					return res
 				}(),
-				env:          podEnv,
-				endpoint:     podEndpoint,
-				nextConsumer: nil,
+				env:           podEnv,
+				endpoint:      podEndpoint,
+				nextConsumers: nextConsumers{},
 			},
 			want: &resourceEnhancer{
-				nextConsumer: nil,
+				nextConsumers: nextConsumers{},
 				attrs: map[string]string{
 					"k8s.pod.uid":        "uid-1",
 					"k8s.namespace.name": "default",
@@ -117,9 +116,9 @@ func Test_newResourceEnhancer(t *testing.T) {
 					res[observer.PodType]["k8s.pod.name"] = "`unbalanced"
 					return res
 				}(),
-				env:          podEnv,
-				endpoint:     podEndpoint,
-				nextConsumer: nil,
+				env:           podEnv,
+				endpoint:      podEndpoint,
+				nextConsumers: nextConsumers{},
 			},
 			want:    nil,
 			wantErr: true,
@@ -127,7 +126,7 @@ func Test_newResourceEnhancer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := newResourceEnhancer(tt.args.resources, tt.args.env, tt.args.endpoint, tt.args.nextConsumer)
+			got, err := newResourceEnhancer(tt.args.resources, tt.args.env, tt.args.endpoint, tt.args.nextConsumers)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("newResourceEnhancer() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -186,8 +185,8 @@ func Test_resourceEnhancer_ConsumeMetrics(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &resourceEnhancer{
-				nextConsumer: tt.fields.nextConsumer,
-				attrs:        tt.fields.attrs,
+				nextConsumers: nextConsumers{metrics: tt.fields.nextConsumer},
+				attrs:         tt.fields.attrs,
 			}
 			if err := r.ConsumeMetrics(tt.args.ctx, tt.args.md); (err != nil) != tt.wantErr {
 				t.Errorf("ConsumeMetrics() error = %v, wantErr %v", err, tt.wantErr)
@@ -202,3 +201,53 @@ func Test_resourceEnhancer_ConsumeMetrics(t *testing.T) {
 		})
 	}
 }
+
+func Test_resourceEnhancer_ConsumeLogs(t *testing.T) {
+	sink := &consumertest.LogsSink{}
+	r := &resourceEnhancer{
+		nextConsumers: nextConsumers{logs: sink},
+		attrs: map[string]string{
+			"key1": "value1",
+			"key2": "value2",
+		},
+	}
+
+	ld := pdata.NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().InsertString("key1", "original")
+	require.NoError(t, r.ConsumeLogs(context.Background(), ld))
+
+	logs := sink.AllLogs()
+	require.Len(t, logs, 1)
+	require.Equal(t, 1, logs[0].ResourceLogs().Len())
+	attrs := logs[0].ResourceLogs().At(0).Resource().Attributes()
+	attrs.Sort()
+	want := pdata.NewAttributeMap()
+	want.InsertString("key1", "original")
+	want.InsertString("key2", "value2")
+	require.Equal(t, want, attrs)
+}
+
+func Test_resourceEnhancer_ConsumeTraces(t *testing.T) {
+	sink := &consumertest.TracesSink{}
+	r := &resourceEnhancer{
+		nextConsumers: nextConsumers{traces: sink},
+		attrs: map[string]string{
+			"key1": "value1",
+			"key2": "value2",
+		},
+	}
+
+	td := pdata.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	require.NoError(t, r.ConsumeTraces(context.Background(), td))
+
+	traces := sink.AllTraces()
+	require.Len(t, traces, 1)
+	require.Equal(t, 1, traces[0].ResourceSpans().Len())
+	attrs := traces[0].ResourceSpans().At(0).Resource().Attributes()
+	attrs.Sort()
+	want := pdata.NewAttributeMap()
+	want.InsertString("key1", "value1")
+	want.InsertString("key2", "value2")
+	require.Equal(t, want, attrs)
+}
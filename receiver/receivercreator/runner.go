@@ -16,19 +16,21 @@ package receivercreator // import "github.com/open-telemetry/opentelemetry-colle
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/spf13/cast"
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/configunmarshaler"
-	"go.opentelemetry.io/collector/consumer"
+	"go.uber.org/multierr"
 )
 
 // runner starts and stops receiver instances.
 type runner interface {
 	// start a receiver instance from its static config and discovered config.
-	start(receiver receiverConfig, discoveredConfig userConfigMap, nextConsumer consumer.Metrics) (component.Receiver, error)
+	start(receiver receiverConfig, discoveredConfig userConfigMap, nextConsumer *resourceEnhancer) (component.Receiver, error)
 	// shutdown a receiver.
 	shutdown(rcvr component.Receiver) error
 }
@@ -46,7 +48,7 @@ var _ runner = (*receiverRunner)(nil)
 func (run *receiverRunner) start(
 	receiver receiverConfig,
 	discoveredConfig userConfigMap,
-	nextConsumer consumer.Metrics,
+	nextConsumer *resourceEnhancer,
 ) (component.Receiver, error) {
 	factory := run.host.GetFactory(component.KindReceiver, receiver.id.Type())
 
@@ -102,11 +104,82 @@ func (run *receiverRunner) loadRuntimeReceiverConfig(
 	return receiverConfig, nil
 }
 
-// createRuntimeReceiver creates a receiver that is discovered at runtime.
+// createRuntimeReceiver creates a receiver that is discovered at runtime. A receiver is
+// created for each data type of the receiver_creator pipelines that the factory supports.
+// If the factory supports more than one of them, the receivers are combined into one.
 func (run *receiverRunner) createRuntimeReceiver(
 	factory component.ReceiverFactory,
 	cfg config.Receiver,
-	nextConsumer consumer.Metrics,
-) (component.MetricsReceiver, error) {
-	return factory.CreateMetricsReceiver(context.Background(), run.params, cfg, nextConsumer)
+	nextConsumer *resourceEnhancer,
+) (component.Receiver, error) {
+	ctx := context.Background()
+	var rcvrs multiReceiver
+
+	if nextConsumer.nextConsumers.logs != nil {
+		rcvr, err := factory.CreateLogsReceiver(ctx, run.params, cfg, nextConsumer)
+		if rcvrs, err = rcvrs.add(rcvr, err); err != nil {
+			return nil, err
+		}
+	}
+	if nextConsumer.nextConsumers.metrics != nil {
+		rcvr, err := factory.CreateMetricsReceiver(ctx, run.params, cfg, nextConsumer)
+		if rcvrs, err = rcvrs.add(rcvr, err); err != nil {
+			return nil, err
+		}
+	}
+	if nextConsumer.nextConsumers.traces != nil {
+		rcvr, err := factory.CreateTracesReceiver(ctx, run.params, cfg, nextConsumer)
+		if rcvrs, err = rcvrs.add(rcvr, err); err != nil {
+			return nil, err
+		}
+	}
+
+	switch len(rcvrs) {
+	case 0:
+		return nil, fmt.Errorf("receiver %v does not support any of the receiver_creator pipeline data types", cfg.ID())
+	case 1:
+		return rcvrs[0], nil
+	default:
+		return rcvrs, nil
+	}
+}
+
+// multiReceiver combines the receivers created from the same config for
+// different data types, so they are started and stopped together.
+type multiReceiver []component.Receiver
+
+var _ component.Receiver = (multiReceiver)(nil)
+
+// add appends the created receiver. Data types not supported by the factory
+// are skipped.
+func (mr multiReceiver) add(rcvr component.Receiver, err error) (multiReceiver, error) {
+	if errors.Is(err, componenterror.ErrDataTypeIsNotSupported) {
+		return mr, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(mr, rcvr), nil
+}
+
+// Start starts all the receivers. If one of them fails to start, the ones
+// already started are stopped, as failed receivers are not kept.
+func (mr multiReceiver) Start(ctx context.Context, host component.Host) error {
+	for i, rcvr := range mr {
+		if err := rcvr.Start(ctx, host); err != nil {
+			return multierr.Append(err, mr[:i].Shutdown(ctx))
+		}
+	}
+	return nil
+}
+
+// Shutdown stops all the receivers.
+func (mr multiReceiver) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, rcvr := range mr {
+		if err := rcvr.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return multierr.Combine(errs...)
 }
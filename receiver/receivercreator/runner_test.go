@@ -15,12 +15,15 @@
 package receivercreator
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receiverhelper"
 )
 
 func Test_loadAndCreateRuntimeReceiver(t *testing.T) {
@@ -41,9 +44,51 @@ func Test_loadAndCreateRuntimeReceiver(t *testing.T) {
 
 	// Test that metric receiver can be created from loaded config.
 	t.Run("test create receiver from loaded config", func(t *testing.T) {
-		recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig, nil)
+		recvr, err := run.createRuntimeReceiver(exampleFactory, loadedConfig, &resourceEnhancer{
+			nextConsumers: nextConsumers{metrics: consumertest.NewNop()},
+		})
 		require.NoError(t, err)
 		assert.NotNil(t, recvr)
 		assert.IsType(t, &nopWithEndpointReceiver{}, recvr)
 	})
+
+	// Test that a receiver is created for each supported data type.
+	t.Run("test create receiver for all data types", func(t *testing.T) {
+		allTypesFactory := &nopWithEndpointFactory{ReceiverFactory: componenttest.NewNopReceiverFactory()}
+		recvr, err := run.createRuntimeReceiver(allTypesFactory, loadedConfig, &resourceEnhancer{
+			nextConsumers: nextConsumers{
+				logs:    consumertest.NewNop(),
+				metrics: consumertest.NewNop(),
+				traces:  consumertest.NewNop(),
+			},
+		})
+		require.NoError(t, err)
+		require.IsType(t, multiReceiver{}, recvr)
+		rcvrs := recvr.(multiReceiver)
+		require.Len(t, rcvrs, 3)
+		assert.IsType(t, &nopWithEndpointReceiver{}, rcvrs[1])
+
+		require.NoError(t, recvr.Start(context.Background(), componenttest.NewNopHost()))
+		require.NoError(t, recvr.Shutdown(context.Background()))
+	})
+
+	// Test that data types the receiver doesn't support are skipped.
+	t.Run("test skip unsupported data types", func(t *testing.T) {
+		metricsOnlyFactory := receiverhelper.NewFactory("nop", exampleFactory.CreateDefaultConfig,
+			receiverhelper.WithMetrics(exampleFactory.CreateMetricsReceiver))
+
+		recvr, err := run.createRuntimeReceiver(metricsOnlyFactory, loadedConfig, &resourceEnhancer{
+			nextConsumers: nextConsumers{
+				logs:    consumertest.NewNop(),
+				metrics: consumertest.NewNop(),
+			},
+		})
+		require.NoError(t, err)
+		assert.IsType(t, &nopWithEndpointReceiver{}, recvr)
+
+		_, err = run.createRuntimeReceiver(metricsOnlyFactory, loadedConfig, &resourceEnhancer{
+			nextConsumers: nextConsumers{traces: consumertest.NewNop()},
+		})
+		assert.Error(t, err)
+	})
 }
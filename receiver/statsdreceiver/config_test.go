@@ -55,6 +55,7 @@ func TestLoadConfig(t *testing.T) {
 		},
 		AggregationInterval:   70 * time.Second,
 		TimerHistogramMapping: []protocol.TimerHistogramMapping{{StatsdType: "histogram", ObserverType: "gauge"}, {StatsdType: "timing", ObserverType: "gauge"}},
+		TCPIdleTimeout:        45 * time.Second,
 	}, r1)
 }
 
@@ -70,6 +71,7 @@ func TestValidate(t *testing.T) {
 		noObjectNameErr                = "must specify object id for all TimerHistogramMappings"
 		statsdTypeNotSupportErr        = "statsd_type is not a supported mapping: %s"
 		observerTypeNotSupportErr      = "observer_type is not supported: %s"
+		negativeTCPIdleTimeoutErr      = "tcp_idle_timeout must be a non-negative duration"
 	)
 
 	tests := []test{
@@ -123,6 +125,24 @@ func TestValidate(t *testing.T) {
 			},
 			expectedErr: fmt.Sprintf(observerTypeNotSupportErr, "gauge1"),
 		},
+		{
+			name: "ObserverTypeNotSupportForDistribution",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{StatsdType: "distribution", ObserverType: "histogram"},
+				},
+			},
+			expectedErr: fmt.Sprintf(observerTypeNotSupportErr, "histogram"),
+		},
+		{
+			name: "negativeTCPIdleTimeout",
+			cfg: &Config{
+				AggregationInterval: 10,
+				TCPIdleTimeout:      -1,
+			},
+			expectedErr: negativeTCPIdleTimeoutErr,
+		},
 	}
 
 	for _, test := range tests {
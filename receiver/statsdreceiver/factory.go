@@ -19,12 +19,15 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/config"
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver/receiverhelper"
 
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/sharedcomponent"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
 )
 
 const (
@@ -47,6 +50,7 @@ func NewFactory() component.ReceiverFactory {
 		typeStr,
 		createDefaultConfig,
 		receiverhelper.WithMetrics(createMetricsReceiver),
+		receiverhelper.WithLogs(createLogsReceiver),
 	)
 }
 
@@ -61,6 +65,7 @@ func createDefaultConfig() config.Receiver {
 		EnableMetricType:      defaultEnableMetricType,
 		IsMonotonicCounter:    defaultIsMonotonicCounter,
 		TimerHistogramMapping: defaultTimerHistogramMapping,
+		TCPIdleTimeout:        transport.TCPIdleTimeoutDefault,
 	}
 }
 
@@ -70,10 +75,59 @@ func createMetricsReceiver(
 	cfg config.Receiver,
 	consumer consumer.Metrics,
 ) (component.MetricsReceiver, error) {
+	r, err := getOrAddReceiver(params, cfg, consumer == nil)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).nextConsumer = consumer
+
+	return r, nil
+}
+
+func createLogsReceiver(
+	_ context.Context,
+	params component.ReceiverCreateSettings,
+	cfg config.Receiver,
+	consumer consumer.Logs,
+) (component.LogsReceiver, error) {
+	r, err := getOrAddReceiver(params, cfg, consumer == nil)
+	if err != nil {
+		return nil, err
+	}
+	r.Unwrap().(*statsdReceiver).logsConsumer = consumer
+
+	return r, nil
+}
+
+func getOrAddReceiver(params component.ReceiverCreateSettings, cfg config.Receiver, nilConsumer bool) (*sharedcomponent.SharedComponent, error) {
+	if nilConsumer {
+		return nil, componenterror.ErrNilNextConsumer
+	}
+
 	c := cfg.(*Config)
 	err := c.validate()
 	if err != nil {
 		return nil, err
 	}
-	return New(params, *c, consumer)
+
+	r := receivers.GetOrAdd(cfg, func() component.Component {
+		var recv *statsdReceiver
+		recv, err = newStatsdReceiver(params, *c)
+		if err != nil {
+			// Avoid wrapping a typed nil into a non-nil component.Component.
+			return nil
+		}
+		return recv
+	})
+	if err != nil {
+		// Don't keep the failed receiver, so the next create call tries again.
+		receivers.Remove(cfg)
+		return nil, err
+	}
+	return r, nil
 }
+
+// This is the map of already created StatsD receivers for particular configurations.
+// The metrics and logs receivers of a configuration must share the same server,
+// so they use one statsdReceiver object per configuration.
+var receivers = sharedcomponent.NewSharedComponents()
@@ -16,9 +16,11 @@ package statsdreceiver
 
 import (
 	"context"
+	"net"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configtest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -43,6 +45,47 @@ func TestCreateReceiver(t *testing.T) {
 	assert.NotNil(t, tReceiver, "receiver creation failed")
 }
 
+func TestCreateLogsReceiver(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = "localhost:0" // Endpoint is required, not going to be used here.
+
+	params := componenttest.NewNopReceiverCreateSettings()
+	lReceiver, err := createLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.NotNil(t, lReceiver, "receiver creation failed")
+
+	mReceiver, err := createMetricsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.NoError(t, err)
+	assert.Same(t, lReceiver, mReceiver, "metrics and logs receivers should share the server")
+}
+
+func TestCreateReceiverWithServerErr(t *testing.T) {
+	// Occupy the port so that the server of the receiver can't be created.
+	conn, err := net.ListenPacket("udp", "localhost:0")
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = conn.LocalAddr().String()
+
+	params := componenttest.NewNopReceiverCreateSettings()
+	mReceiver, err := createMetricsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, mReceiver)
+
+	// The failed receiver isn't shared with the other signal.
+	lReceiver, err := createLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	assert.Error(t, err)
+	assert.Nil(t, lReceiver)
+
+	// Once the port is free, the receiver is created.
+	require.NoError(t, conn.Close())
+	lReceiver, err = createLogsReceiver(context.Background(), params, cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NotNil(t, lReceiver)
+	require.NoError(t, lReceiver.Start(context.Background(), componenttest.NewNopHost()))
+	assert.NoError(t, lReceiver.Shutdown(context.Background()))
+}
+
 func TestCreateReceiverWithConfigErr(t *testing.T) {
 	cfg := &Config{
 		AggregationInterval: -1,
@@ -72,3 +115,15 @@ func TestCreateMetricsReceiverWithNilConsumer(t *testing.T) {
 	assert.Error(t, err, "nil consumer")
 	assert.Nil(t, receiver)
 }
+
+func TestCreateLogsReceiverWithNilConsumer(t *testing.T) {
+	receiver, err := createLogsReceiver(
+		context.Background(),
+		componenttest.NewNopReceiverCreateSettings(),
+		createDefaultConfig(),
+		nil,
+	)
+
+	assert.Error(t, err, "nil consumer")
+	assert.Nil(t, receiver)
+}
@@ -0,0 +1,191 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// DogStatsD datagrams, see https://docs.datadoghq.com/developers/dogstatsd/datagram_shell
+const (
+	eventPrefix        = "_e{"
+	serviceCheckPrefix = "_sc|"
+
+	containerIDPrefix    = "c:"
+	timestampPrefix      = "d:"
+	hostnamePrefix       = "h:"
+	messagePrefix        = "m:"
+	priorityPrefix       = "p:"
+	alertTypePrefix      = "t:"
+	aggregationKeyPrefix = "k:"
+	sourceTypePrefix     = "s:"
+
+	attributeEventTitle          = "title"
+	attributeEventPriority       = "priority"
+	attributeEventAlertType      = "alert_type"
+	attributeEventAggregationKey = "aggregation_key"
+	attributeEventSourceType     = "source_type_name"
+)
+
+// parseServiceCheck parses a DogStatsD service check, with the format
+// _sc|<name>|<status>|d:<timestamp>|h:<hostname>|#<tags>|m:<message>|c:<container_id>,
+// into a gauge of the check status. The message is dropped as it is free-form.
+func parseServiceCheck(line string, enableMetricType bool) (statsDMetric, error) {
+	result := statsDMetric{}
+
+	parts := strings.Split(line, "|")
+	if len(parts) < 3 {
+		return result, fmt.Errorf("invalid service check format: %s", line)
+	}
+
+	result.description.name = parts[1]
+	if result.description.name == "" {
+		return result, errEmptyMetricName
+	}
+	result.description.metricType = ServiceCheckType
+
+	status, err := strconv.Atoi(parts[2])
+	if err != nil || status < 0 || status > 3 {
+		return result, fmt.Errorf("invalid service check status: %s", parts[2])
+	}
+	result.asFloat = float64(status)
+
+	var kvs []attribute.KeyValue
+	for _, part := range parts[3:] {
+		switch {
+		case strings.HasPrefix(part, "#"):
+			tagKVs, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
+			}
+			kvs = append(kvs, result.addLabels(tagKVs...)...)
+		case strings.HasPrefix(part, hostnamePrefix):
+			hostname := strings.TrimPrefix(part, hostnamePrefix)
+			kvs = append(kvs, result.addLabels(attribute.String(conventions.AttributeHostName, hostname))...)
+		case strings.HasPrefix(part, containerIDPrefix):
+			containerID := strings.TrimPrefix(part, containerIDPrefix)
+			kvs = append(kvs, result.addLabels(attribute.String(conventions.AttributeContainerID, containerID))...)
+		case strings.HasPrefix(part, timestampPrefix), strings.HasPrefix(part, messagePrefix):
+			// The gauge is timestamped on aggregation, and the message dropped.
+		default:
+			return result, fmt.Errorf("unrecognized message part: %s", part)
+		}
+	}
+
+	result.setLabels(kvs, enableMetricType)
+
+	return result, nil
+}
+
+// parseEvent parses a DogStatsD event, with the format
+// _e{<title_length>,<text_length>}:<title>|<text>|d:<timestamp>|h:<hostname>|p:<priority>|t:<alert_type>|#<tags>|k:<aggregation_key>|s:<source_type_name>|c:<container_id>,
+// into a log record.
+func parseEvent(line string, timeNow time.Time) (pdata.LogRecord, error) {
+	lr := pdata.NewLogRecord()
+
+	headerEnd := strings.Index(line, "}:")
+	if headerEnd < 0 {
+		return lr, fmt.Errorf("invalid event format: %s", line)
+	}
+	lengths := strings.Split(line[len(eventPrefix):headerEnd], ",")
+	if len(lengths) != 2 {
+		return lr, fmt.Errorf("invalid event lengths: %s", line[:headerEnd+1])
+	}
+	titleLen, err := strconv.Atoi(lengths[0])
+	if err != nil || titleLen <= 0 {
+		return lr, fmt.Errorf("invalid event title length: %s", lengths[0])
+	}
+	textLen, err := strconv.Atoi(lengths[1])
+	if err != nil || textLen < 0 {
+		return lr, fmt.Errorf("invalid event text length: %s", lengths[1])
+	}
+
+	rest := line[headerEnd+2:]
+	if len(rest) < titleLen+1+textLen || rest[titleLen] != '|' {
+		return lr, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+	title := rest[:titleLen]
+	text := rest[titleLen+1 : titleLen+1+textLen]
+	rest = rest[titleLen+1+textLen:]
+	if rest != "" && rest[0] != '|' {
+		return lr, fmt.Errorf("event title and text do not match their lengths: %s", line)
+	}
+
+	lr.SetTimestamp(pdata.NewTimestampFromTime(timeNow))
+	lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+	lr.SetSeverityText("info")
+	lr.Body().SetStringVal(strings.ReplaceAll(text, "\\n", "\n"))
+	attrs := lr.Attributes()
+	attrs.InsertString(attributeEventTitle, title)
+
+	if rest == "" {
+		return lr, nil
+	}
+	for _, part := range strings.Split(rest[1:], "|") {
+		switch {
+		case strings.HasPrefix(part, "#"):
+			tagKVs, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return lr, err
+			}
+			for _, kv := range tagKVs {
+				attrs.UpsertString(string(kv.Key), kv.Value.AsString())
+			}
+		case strings.HasPrefix(part, timestampPrefix):
+			timestamp, err := strconv.ParseInt(strings.TrimPrefix(part, timestampPrefix), 10, 64)
+			if err != nil {
+				return lr, fmt.Errorf("invalid event timestamp: %s", part)
+			}
+			lr.SetTimestamp(pdata.NewTimestampFromTime(time.Unix(timestamp, 0)))
+		case strings.HasPrefix(part, alertTypePrefix):
+			alertType := strings.TrimPrefix(part, alertTypePrefix)
+			severity, ok := alertTypeSeverities[alertType]
+			if !ok {
+				return lr, fmt.Errorf("invalid event alert type: %s", alertType)
+			}
+			lr.SetSeverityNumber(severity)
+			lr.SetSeverityText(alertType)
+			attrs.UpsertString(attributeEventAlertType, alertType)
+		case strings.HasPrefix(part, hostnamePrefix):
+			attrs.UpsertString(conventions.AttributeHostName, strings.TrimPrefix(part, hostnamePrefix))
+		case strings.HasPrefix(part, containerIDPrefix):
+			attrs.UpsertString(conventions.AttributeContainerID, strings.TrimPrefix(part, containerIDPrefix))
+		case strings.HasPrefix(part, priorityPrefix):
+			attrs.UpsertString(attributeEventPriority, strings.TrimPrefix(part, priorityPrefix))
+		case strings.HasPrefix(part, aggregationKeyPrefix):
+			attrs.UpsertString(attributeEventAggregationKey, strings.TrimPrefix(part, aggregationKeyPrefix))
+		case strings.HasPrefix(part, sourceTypePrefix):
+			attrs.UpsertString(attributeEventSourceType, strings.TrimPrefix(part, sourceTypePrefix))
+		default:
+			return lr, fmt.Errorf("unrecognized message part: %s", part)
+		}
+	}
+
+	return lr, nil
+}
+
+var alertTypeSeverities = map[string]pdata.SeverityNumber{
+	"success": pdata.SeverityNumberINFO,
+	"info":    pdata.SeverityNumberINFO,
+	"warning": pdata.SeverityNumberWARN,
+	"error":   pdata.SeverityNumberERROR,
+}
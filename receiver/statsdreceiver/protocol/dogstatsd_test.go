@@ -0,0 +1,196 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/model/pdata"
+)
+
+func Test_ParseServiceCheck(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		enableMetricType bool
+		wantMetric       statsDMetric
+		err              error
+	}{
+		{
+			name:  "missing status",
+			input: "_sc|my.check",
+			err:   errors.New("invalid service check format: _sc|my.check"),
+		},
+		{
+			name:  "empty name",
+			input: "_sc||0",
+			err:   errors.New("empty metric name"),
+		},
+		{
+			name:  "invalid status",
+			input: "_sc|my.check|4",
+			err:   errors.New("invalid service check status: 4"),
+		},
+		{
+			name:  "unrecognized message part",
+			input: "_sc|my.check|0|x:y",
+			err:   errors.New("unrecognized message part: x:y"),
+		},
+		{
+			name:  "status only",
+			input: "_sc|my.check|1",
+			wantMetric: testStatsDMetric(
+				"my.check",
+				1,
+				false,
+				"_sc", 0, nil, nil),
+		},
+		{
+			name:  "all fields",
+			input: "_sc|my.check|2|d:1634567890|h:myhost|#mykey:myvalue|m:disk is full|c:abc123",
+			wantMetric: testStatsDMetric(
+				"my.check",
+				2,
+				false,
+				"_sc", 0, []string{"host.name", "mykey", "container.id"}, []string{"myhost", "myvalue", "abc123"}),
+		},
+		{
+			name:             "with metric type",
+			input:            "_sc|my.check|0",
+			enableMetricType: true,
+			wantMetric: testStatsDMetric(
+				"my.check",
+				0,
+				false,
+				"_sc", 0, []string{"metric_type"}, []string{"service_check"}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseServiceCheck(tt.input, tt.enableMetricType)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantMetric, got)
+			}
+		})
+	}
+}
+
+func Test_ParseEvent(t *testing.T) {
+	timeNow := time.Unix(711, 0)
+
+	tests := []struct {
+		name    string
+		input   string
+		wantLog func() pdata.LogRecord
+		err     error
+	}{
+		{
+			name:  "missing header end",
+			input: "_e{5,4}title|text",
+			err:   errors.New("invalid event format: _e{5,4}title|text"),
+		},
+		{
+			name:  "invalid title length",
+			input: "_e{a,4}:title|text",
+			err:   errors.New("invalid event title length: a"),
+		},
+		{
+			name:  "invalid text length",
+			input: "_e{5,-1}:title|text",
+			err:   errors.New("invalid event text length: -1"),
+		},
+		{
+			name:  "lengths too long",
+			input: "_e{5,10}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,10}:title|text"),
+		},
+		{
+			name:  "lengths too short",
+			input: "_e{5,2}:title|text",
+			err:   errors.New("event title and text do not match their lengths: _e{5,2}:title|text"),
+		},
+		{
+			name:  "invalid alert type",
+			input: "_e{5,4}:title|text|t:critical",
+			err:   errors.New("invalid event alert type: critical"),
+		},
+		{
+			name:  "unrecognized message part",
+			input: "_e{5,4}:title|text|x:y",
+			err:   errors.New("unrecognized message part: x:y"),
+		},
+		{
+			name:  "title and text",
+			input: "_e{5,11}:title|first\\nline",
+			wantLog: func() pdata.LogRecord {
+				lr := pdata.NewLogRecord()
+				lr.SetTimestamp(pdata.NewTimestampFromTime(timeNow))
+				lr.SetSeverityNumber(pdata.SeverityNumberINFO)
+				lr.SetSeverityText("info")
+				lr.Body().SetStringVal("first\nline")
+				lr.Attributes().InsertString("title", "title")
+				return lr
+			},
+		},
+		{
+			name:  "all fields",
+			input: "_e{5,4}:title|text|d:1634567890|h:myhost|p:low|t:warning|#mykey:myvalue|k:mykey|s:mysource|c:abc123",
+			wantLog: func() pdata.LogRecord {
+				lr := pdata.NewLogRecord()
+				lr.SetTimestamp(pdata.NewTimestampFromTime(time.Unix(1634567890, 0)))
+				lr.SetSeverityNumber(pdata.SeverityNumberWARN)
+				lr.SetSeverityText("warning")
+				lr.Body().SetStringVal("text")
+				attrs := lr.Attributes()
+				attrs.InsertString("title", "title")
+				attrs.InsertString("host.name", "myhost")
+				attrs.InsertString("priority", "low")
+				attrs.InsertString("alert_type", "warning")
+				attrs.InsertString("mykey", "myvalue")
+				attrs.InsertString("aggregation_key", "mykey")
+				attrs.InsertString("source_type_name", "mysource")
+				attrs.InsertString("container.id", "abc123")
+				return lr
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseEvent(tt.input, timeNow)
+
+			if tt.err != nil {
+				assert.Equal(t, tt.err, err)
+			} else {
+				require.NoError(t, err)
+				want := tt.wantLog()
+				assert.Equal(t, want.Timestamp(), got.Timestamp())
+				assert.Equal(t, want.SeverityNumber(), got.SeverityNumber())
+				assert.Equal(t, want.SeverityText(), got.SeverityText())
+				assert.Equal(t, want.Body(), got.Body())
+				assert.Equal(t, want.Attributes().Sort(), got.Attributes().Sort())
+			}
+		})
+	}
+}
@@ -15,6 +15,7 @@
 package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 
 import (
+	"math"
 	"sort"
 	"time"
 
@@ -26,6 +27,14 @@ var (
 	statsDDefaultPercentiles = []float64{0, 10, 50, 90, 95, 100}
 )
 
+const (
+	// expHistogramMaxScale is the finest resolution of the exponential
+	// histograms, lowered until their points fit in expHistogramMaxBuckets.
+	expHistogramMaxScale   = 20
+	expHistogramMinScale   = -10
+	expHistogramMaxBuckets = 160
+)
+
 func buildCounterMetric(parsedMetric statsDMetric, isMonotonicCounter bool, timeNow, lastIntervalTime time.Time) pdata.InstrumentationLibraryMetrics {
 	ilm := pdata.NewInstrumentationLibraryMetrics()
 	nm := ilm.Metrics().AppendEmpty()
@@ -67,6 +76,21 @@ func buildGaugeMetric(parsedMetric statsDMetric, timeNow time.Time) pdata.Instru
 	return ilm
 }
 
+func buildSetMetric(set setMetric, timeNow time.Time) pdata.InstrumentationLibraryMetrics {
+	ilm := pdata.NewInstrumentationLibraryMetrics()
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(set.metric.description.name)
+	nm.SetDataType(pdata.MetricDataTypeGauge)
+	dp := nm.Gauge().DataPoints().AppendEmpty()
+	dp.SetIntVal(int64(len(set.values)))
+	dp.SetTimestamp(pdata.NewTimestampFromTime(timeNow))
+	for i, key := range set.metric.labelKeys {
+		dp.Attributes().InsertString(key, set.metric.labelValues[i])
+	}
+
+	return ilm
+}
+
 func buildSummaryMetric(summary summaryMetric, startTime, timeNow time.Time, percentiles []float64, ilm pdata.InstrumentationLibraryMetrics) {
 	nm := ilm.Metrics().AppendEmpty()
 	nm.SetName(summary.name)
@@ -146,3 +170,106 @@ func (d dualSorter) Swap(i, j int) {
 func (d dualSorter) Less(i, j int) bool {
 	return d.values[i] < d.values[j]
 }
+
+func buildExponentialHistogramMetric(histogram summaryMetric, startTime, timeNow time.Time, ilm pdata.InstrumentationLibraryMetrics) {
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(histogram.name)
+	nm.SetDataType(pdata.MetricDataTypeExponentialHistogram)
+	nm.ExponentialHistogram().SetAggregationTemporality(pdata.MetricAggregationTemporalityDelta)
+
+	dp := nm.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(pdata.NewTimestampFromTime(startTime))
+	dp.SetTimestamp(pdata.NewTimestampFromTime(timeNow))
+	for i, key := range histogram.labelKeys {
+		dp.Attributes().InsertString(key, histogram.labelValues[i])
+	}
+
+	var positives, negatives []int
+	sum := float64(0)
+	zeroCount := float64(0)
+	for i, value := range histogram.points {
+		sum += value * histogram.weights[i]
+		switch {
+		case value > 0:
+			positives = append(positives, i)
+		case value < 0:
+			negatives = append(negatives, i)
+		default:
+			zeroCount += histogram.weights[i]
+		}
+	}
+
+	scale := expHistogramMaxScale
+	for scale > expHistogramMinScale &&
+		(bucketSpan(histogram.points, positives, scale) > expHistogramMaxBuckets ||
+			bucketSpan(histogram.points, negatives, scale) > expHistogramMaxBuckets) {
+		scale--
+	}
+
+	// Note: counts are rounded here, see note in counterValue().
+	count := uint64(math.Round(zeroCount))
+	count += fillBuckets(dp.Positive(), histogram, positives, scale)
+	count += fillBuckets(dp.Negative(), histogram, negatives, scale)
+
+	dp.SetScale(int32(scale))
+	dp.SetZeroCount(uint64(math.Round(zeroCount)))
+	dp.SetCount(count)
+	dp.SetSum(sum)
+}
+
+// bucketIndex returns the index of the exponential histogram bucket, at the
+// given scale, whose (base^index, base^(index+1)] range holds the absolute
+// value.
+func bucketIndex(value float64, scale int) int {
+	return int(math.Ceil(math.Log2(math.Abs(value))*math.Ldexp(1, scale))) - 1
+}
+
+// bucketSpan returns the number of buckets between the lowest and highest
+// indexed points.
+func bucketSpan(values []float64, indices []int, scale int) int {
+	if len(indices) == 0 {
+		return 0
+	}
+	lowest, highest := math.MaxInt32, math.MinInt32
+	for _, i := range indices {
+		index := bucketIndex(values[i], scale)
+		if index < lowest {
+			lowest = index
+		}
+		if index > highest {
+			highest = index
+		}
+	}
+	return highest - lowest + 1
+}
+
+// fillBuckets counts the indexed points into the buckets, and returns the
+// total count.
+func fillBuckets(buckets pdata.Buckets, histogram summaryMetric, indices []int, scale int) uint64 {
+	if len(indices) == 0 {
+		return 0
+	}
+
+	offset := math.MaxInt32
+	for _, i := range indices {
+		if index := bucketIndex(histogram.points[i], scale); index < offset {
+			offset = index
+		}
+	}
+
+	weights := make([]float64, bucketSpan(histogram.points, indices, scale))
+	for _, i := range indices {
+		weights[bucketIndex(histogram.points[i], scale)-offset] += histogram.weights[i]
+	}
+
+	total := uint64(0)
+	counts := make([]uint64, len(weights))
+	for i, weight := range weights {
+		counts[i] = uint64(math.Round(weight))
+		total += counts[i]
+	}
+
+	buckets.SetOffset(int32(offset))
+	buckets.SetBucketCounts(counts)
+	return total
+}
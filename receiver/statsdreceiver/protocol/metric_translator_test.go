@@ -188,3 +188,43 @@ func TestBuildSummaryMetricSampled(t *testing.T) {
 		assert.Equal(t, expectedMetric, metric)
 	}
 }
+
+func TestBuildExponentialHistogramMetric(t *testing.T) {
+	timeNow := time.Now()
+	sampledMetric := summaryMetric{
+		name:        "testExponentialHistogram",
+		points:      []float64{1, 2, 2, 4, 0, -3},
+		weights:     []float64{1, 1, 1, 1, 2, 1},
+		labelKeys:   []string{"mykey"},
+		labelValues: []string{"myvalue"},
+	}
+
+	ilm := pdata.NewInstrumentationLibraryMetrics()
+	buildExponentialHistogramMetric(sampledMetric, timeNow.Add(-time.Minute), timeNow, ilm)
+
+	m := ilm.Metrics().At(0)
+	assert.Equal(t, "testExponentialHistogram", m.Name())
+	assert.Equal(t, pdata.MetricDataTypeExponentialHistogram, m.DataType())
+	assert.Equal(t, pdata.MetricAggregationTemporalityDelta, m.ExponentialHistogram().AggregationTemporality())
+
+	dp := m.ExponentialHistogram().DataPoints().At(0)
+	assert.Equal(t, pdata.NewTimestampFromTime(timeNow.Add(-time.Minute)), dp.StartTimestamp())
+	assert.Equal(t, pdata.NewTimestampFromTime(timeNow), dp.Timestamp())
+	assert.Equal(t, uint64(7), dp.Count())
+	assert.Equal(t, float64(6), dp.Sum())
+	assert.Equal(t, uint64(2), dp.ZeroCount())
+	value, ok := dp.Attributes().Get("mykey")
+	assert.True(t, ok)
+	assert.Equal(t, "myvalue", value.StringVal())
+
+	// The finest scale at which 1 to 4 fit in the maximum number of buckets.
+	assert.Equal(t, int32(6), dp.Scale())
+
+	positive := make([]uint64, 129)
+	positive[0], positive[64], positive[128] = 1, 2, 1
+	assert.Equal(t, int32(-1), dp.Positive().Offset())
+	assert.Equal(t, positive, dp.Positive().BucketCounts())
+
+	assert.Equal(t, int32(101), dp.Negative().Offset())
+	assert.Equal(t, []uint64{1}, dp.Negative().BucketCounts())
+}
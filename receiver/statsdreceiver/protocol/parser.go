@@ -18,9 +18,11 @@ import (
 	"go.opentelemetry.io/collector/model/pdata"
 )
 
-// Parser is something that can map input StatsD strings to OTLP Metric representations.
+// Parser is something that can map input StatsD strings to OTLP Metric representations,
+// and DogStatsD events to OTLP Log representations.
 type Parser interface {
 	Initialize(enableMetricType bool, isMonotonicCounter bool, sendTimerHistogram []TimerHistogramMapping) error
 	GetMetrics() pdata.Metrics
+	GetLogs() pdata.Logs
 	Aggregate(line string) error
 }
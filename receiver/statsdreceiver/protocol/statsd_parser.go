@@ -22,6 +22,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/model/pdata"
+	conventions "go.opentelemetry.io/collector/model/semconv/v1.5.0"
 	"go.opentelemetry.io/otel/attribute"
 )
 
@@ -39,22 +40,33 @@ type (
 const (
 	tagMetricType = "metric_type"
 
-	CounterType   MetricType = "c"
-	GaugeType     MetricType = "g"
-	HistogramType MetricType = "h"
-	TimingType    MetricType = "ms"
-
-	CounterTypeName   TypeName = "counter"
-	GaugeTypeName     TypeName = "gauge"
-	HistogramTypeName TypeName = "histogram"
-	TimingTypeName    TypeName = "timing"
-	TimingAltTypeName TypeName = "timer"
-
-	GaugeObserver   ObserverType = "gauge"
-	SummaryObserver ObserverType = "summary"
-	DisableObserver ObserverType = "disabled"
+	CounterType      MetricType = "c"
+	GaugeType        MetricType = "g"
+	HistogramType    MetricType = "h"
+	TimingType       MetricType = "ms"
+	DistributionType MetricType = "d"
+	SetType          MetricType = "s"
+	ServiceCheckType MetricType = "_sc"
+
+	CounterTypeName      TypeName = "counter"
+	GaugeTypeName        TypeName = "gauge"
+	HistogramTypeName    TypeName = "histogram"
+	TimingTypeName       TypeName = "timing"
+	TimingAltTypeName    TypeName = "timer"
+	DistributionTypeName TypeName = "distribution"
+	SetTypeName          TypeName = "set"
+	ServiceCheckTypeName TypeName = "service_check"
+
+	GaugeObserver                ObserverType = "gauge"
+	SummaryObserver              ObserverType = "summary"
+	ExponentialHistogramObserver ObserverType = "exponential_histogram"
+	DisableObserver              ObserverType = "disabled"
 
 	DefaultObserverType = DisableObserver
+
+	// DefaultDistributionObserverType aggregates distributions, which are
+	// meant to be aggregated server-side, unless mapped otherwise.
+	DefaultDistributionObserverType = SummaryObserver
 )
 
 type TimerHistogramMapping struct {
@@ -62,16 +74,22 @@ type TimerHistogramMapping struct {
 	ObserverType ObserverType `mapstructure:"observer_type"`
 }
 
-// StatsDParser supports the Parse method for parsing StatsD messages with Tags.
+// StatsDParser supports the Parse method for parsing StatsD messages with Tags,
+// including the DogStatsD extensions: distributions, sets, service checks,
+// events and container IDs.
 type StatsDParser struct {
 	gauges                 map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics
 	counters               map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics
 	summaries              map[statsDMetricdescription]summaryMetric
+	expHistograms          map[statsDMetricdescription]summaryMetric
+	sets                   map[statsDMetricdescription]setMetric
 	timersAndDistributions []pdata.InstrumentationLibraryMetrics
+	events                 pdata.LogSlice
 	enableMetricType       bool
 	isMonotonicCounter     bool
 	observeTimer           ObserverType
 	observeHistogram       ObserverType
+	observeDistribution    ObserverType
 	lastIntervalTime       time.Time
 }
 
@@ -88,9 +106,15 @@ type summaryMetric struct {
 	labelValues []string
 }
 
+type setMetric struct {
+	metric statsDMetric
+	values map[string]struct{}
+}
+
 type statsDMetric struct {
 	description statsDMetricdescription
 	asFloat     float64
+	asString    string
 	addition    bool
 	unit        string
 	sampleRate  float64
@@ -114,6 +138,12 @@ func (t MetricType) FullName() TypeName {
 		return TimingTypeName
 	case HistogramType:
 		return HistogramTypeName
+	case DistributionType:
+		return DistributionTypeName
+	case SetType:
+		return SetTypeName
+	case ServiceCheckType:
+		return ServiceCheckTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -124,9 +154,13 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 	p.counters = make(map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics)
 	p.timersAndDistributions = make([]pdata.InstrumentationLibraryMetrics, 0)
 	p.summaries = make(map[statsDMetricdescription]summaryMetric)
+	p.expHistograms = make(map[statsDMetricdescription]summaryMetric)
+	p.sets = make(map[statsDMetricdescription]setMetric)
+	p.events = pdata.NewLogSlice()
 
 	p.observeHistogram = DefaultObserverType
 	p.observeTimer = DefaultObserverType
+	p.observeDistribution = DefaultDistributionObserverType
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
 	// Note: validation occurs in ("../".Config).vaidate()
@@ -136,6 +170,8 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 			p.observeHistogram = eachMap.ObserverType
 		case TimingTypeName, TimingAltTypeName:
 			p.observeTimer = eachMap.ObserverType
+		case DistributionTypeName:
+			p.observeDistribution = eachMap.ObserverType
 		}
 	}
 	return nil
@@ -168,14 +204,37 @@ func (p *StatsDParser) GetMetrics() pdata.Metrics {
 		)
 	}
 
+	for _, expHistogram := range p.expHistograms {
+		buildExponentialHistogramMetric(
+			expHistogram,
+			p.lastIntervalTime,
+			timeNowFunc(),
+			rm.InstrumentationLibraryMetrics().AppendEmpty(),
+		)
+	}
+
+	for _, set := range p.sets {
+		buildSetMetric(set, timeNowFunc()).CopyTo(rm.InstrumentationLibraryMetrics().AppendEmpty())
+	}
+
 	p.lastIntervalTime = timeNowFunc()
 	p.gauges = make(map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics)
 	p.counters = make(map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics)
 	p.timersAndDistributions = make([]pdata.InstrumentationLibraryMetrics, 0)
 	p.summaries = make(map[statsDMetricdescription]summaryMetric)
+	p.expHistograms = make(map[statsDMetricdescription]summaryMetric)
+	p.sets = make(map[statsDMetricdescription]setMetric)
 	return metrics
 }
 
+// GetLogs gets the events preparing for flushing and reset the state.
+func (p *StatsDParser) GetLogs() pdata.Logs {
+	logs := pdata.NewLogs()
+	ill := logs.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty()
+	p.events.MoveAndAppendTo(ill.Logs())
+	return logs
+}
+
 var timeNowFunc = func() time.Time {
 	return time.Now()
 }
@@ -186,17 +245,37 @@ func (p *StatsDParser) observerTypeFor(t MetricType) ObserverType {
 		return p.observeHistogram
 	case TimingType:
 		return p.observeTimer
+	case DistributionType:
+		return p.observeDistribution
 	}
 	return DisableObserver
 }
 
 // Aggregate for each metric line.
 func (p *StatsDParser) Aggregate(line string) error {
-	parsedMetric, err := parseMessageToMetric(line, p.enableMetricType)
+	if strings.HasPrefix(line, eventPrefix) {
+		event, err := parseEvent(line, timeNowFunc())
+		if err != nil {
+			return err
+		}
+		event.MoveTo(p.events.AppendEmpty())
+		return nil
+	}
+
+	var parsedMetric statsDMetric
+	var err error
+	if strings.HasPrefix(line, serviceCheckPrefix) {
+		parsedMetric, err = parseServiceCheck(line, p.enableMetricType)
+	} else {
+		parsedMetric, err = parseMessageToMetric(line, p.enableMetricType)
+	}
 	if err != nil {
 		return err
 	}
 	switch parsedMetric.description.metricType {
+	case ServiceCheckType:
+		p.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, timeNowFunc())
+
 	case GaugeType:
 		_, ok := p.gauges[parsedMetric.description]
 		if !ok {
@@ -219,29 +298,25 @@ func (p *StatsDParser) Aggregate(line string) error {
 			point.SetIntVal(point.IntVal() + parsedMetric.counterValue())
 		}
 
-	case TimingType, HistogramType:
+	case SetType:
+		existing, ok := p.sets[parsedMetric.description]
+		if !ok {
+			existing = setMetric{
+				metric: parsedMetric,
+				values: make(map[string]struct{}),
+			}
+			p.sets[parsedMetric.description] = existing
+		}
+		existing.values[parsedMetric.asString] = struct{}{}
+
+	case TimingType, HistogramType, DistributionType:
 		switch p.observerTypeFor(parsedMetric.description.metricType) {
 		case GaugeObserver:
 			p.timersAndDistributions = append(p.timersAndDistributions, buildGaugeMetric(parsedMetric, timeNowFunc()))
 		case SummaryObserver:
-			raw := parsedMetric.summaryValue()
-			if existing, ok := p.summaries[parsedMetric.description]; !ok {
-				p.summaries[parsedMetric.description] = summaryMetric{
-					name:        parsedMetric.description.name,
-					points:      []float64{raw.value},
-					weights:     []float64{raw.count},
-					labelKeys:   parsedMetric.labelKeys,
-					labelValues: parsedMetric.labelValues,
-				}
-			} else {
-				p.summaries[parsedMetric.description] = summaryMetric{
-					name:        parsedMetric.description.name,
-					points:      append(existing.points, raw.value),
-					weights:     append(existing.weights, raw.count),
-					labelKeys:   parsedMetric.labelKeys,
-					labelValues: parsedMetric.labelValues,
-				}
-			}
+			p.summaries[parsedMetric.description] = appendSummaryRaw(p.summaries, parsedMetric)
+		case ExponentialHistogramObserver:
+			p.expHistograms[parsedMetric.description] = appendSummaryRaw(p.expHistograms, parsedMetric)
 		case DisableObserver:
 			// No action.
 		}
@@ -250,6 +325,29 @@ func (p *StatsDParser) Aggregate(line string) error {
 	return nil
 }
 
+// appendSummaryRaw returns the raw points aggregated so far for the metric,
+// including its new point.
+func appendSummaryRaw(aggregated map[statsDMetricdescription]summaryMetric, parsedMetric statsDMetric) summaryMetric {
+	raw := parsedMetric.summaryValue()
+	existing, ok := aggregated[parsedMetric.description]
+	if !ok {
+		return summaryMetric{
+			name:        parsedMetric.description.name,
+			points:      []float64{raw.value},
+			weights:     []float64{raw.count},
+			labelKeys:   parsedMetric.labelKeys,
+			labelValues: parsedMetric.labelValues,
+		}
+	}
+	return summaryMetric{
+		name:        parsedMetric.description.name,
+		points:      append(existing.points, raw.value),
+		weights:     append(existing.weights, raw.count),
+		labelKeys:   parsedMetric.labelKeys,
+		labelValues: parsedMetric.labelValues,
+	}
+}
+
 func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, error) {
 	result := statsDMetric{}
 
@@ -277,7 +375,7 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType:
+	case CounterType, GaugeType, HistogramType, TimingType, DistributionType, SetType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
@@ -298,44 +396,72 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 
 			result.sampleRate = f
 		} else if strings.HasPrefix(part, "#") {
-			tagsStr := strings.TrimPrefix(part, "#")
-
-			tagSets := strings.Split(tagsStr, ",")
-
-			for _, tagSet := range tagSets {
-				tagParts := strings.Split(tagSet, ":")
-				if len(tagParts) != 2 {
-					return result, fmt.Errorf("invalid tag format: %s", tagParts)
-				}
-				result.labelKeys = append(result.labelKeys, tagParts[0])
-				result.labelValues = append(result.labelValues, tagParts[1])
-				kvs = append(kvs, attribute.String(tagParts[0], tagParts[1]))
+			tagKVs, err := parseTags(strings.TrimPrefix(part, "#"))
+			if err != nil {
+				return result, err
 			}
-
+			kvs = append(kvs, result.addLabels(tagKVs...)...)
+		} else if strings.HasPrefix(part, containerIDPrefix) {
+			containerID := strings.TrimPrefix(part, containerIDPrefix)
+			kvs = append(kvs, result.addLabels(attribute.String(conventions.AttributeContainerID, containerID))...)
 		} else {
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
 	}
-	var err error
-	result.asFloat, err = strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return result, fmt.Errorf("parse metric value string: %s", valueStr)
+
+	// Set members are opaque strings, only counted for their uniqueness.
+	if result.description.metricType == SetType {
+		result.addition = false
+		result.asString = valueStr
+	} else {
+		var err error
+		result.asFloat, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value string: %s", valueStr)
+		}
+	}
+
+	result.setLabels(kvs, enableMetricType)
+
+	return result, nil
+}
+
+// parseTags parses comma-separated <key>:<value> tags.
+func parseTags(tagsStr string) ([]attribute.KeyValue, error) {
+	var kvs []attribute.KeyValue
+	for _, tagSet := range strings.Split(tagsStr, ",") {
+		tagParts := strings.Split(tagSet, ":")
+		if len(tagParts) != 2 {
+			return nil, fmt.Errorf("invalid tag format: %s", tagParts)
+		}
+		kvs = append(kvs, attribute.String(tagParts[0], tagParts[1]))
 	}
+	return kvs, nil
+}
+
+// addLabels adds the given labels to the metric, and returns them.
+func (s *statsDMetric) addLabels(kvs ...attribute.KeyValue) []attribute.KeyValue {
+	for _, kv := range kvs {
+		s.labelKeys = append(s.labelKeys, string(kv.Key))
+		s.labelValues = append(s.labelValues, kv.Value.AsString())
+	}
+	return kvs
+}
 
+// setLabels sets the identity of the metric from its labels.
+func (s *statsDMetric) setLabels(kvs []attribute.KeyValue, enableMetricType bool) {
 	// add metric_type dimension for all metrics
 	if enableMetricType {
-		metricType := string(result.description.metricType.FullName())
+		metricType := string(s.description.metricType.FullName())
 
-		result.labelKeys = append(result.labelKeys, tagMetricType)
-		result.labelValues = append(result.labelValues, metricType)
+		s.labelKeys = append(s.labelKeys, tagMetricType)
+		s.labelValues = append(s.labelValues, metricType)
 
 		kvs = append(kvs, attribute.String(tagMetricType, metricType))
 	}
 
 	if len(kvs) != 0 {
 		set := attribute.NewSet(kvs...)
-		result.description.labels = set.Equivalent()
+		s.description.labels = set.Equivalent()
 	}
-
-	return result, nil
 }
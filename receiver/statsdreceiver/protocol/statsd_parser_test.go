@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/model/pdata"
 	"go.opentelemetry.io/otel/attribute"
 )
@@ -228,6 +229,38 @@ func Test_ParseMessageToMetric(t *testing.T) {
 				false,
 				"h", 0, nil, nil),
 		},
+		{
+			name:  "distribution",
+			input: "test.metric:42.5|d|@0.5",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				42.5,
+				false,
+				"d", 0.5, nil, nil),
+		},
+		{
+			name:  "invalid distribution metric value",
+			input: "test.metric:abc|d",
+			err:   errors.New("parse metric value string: abc"),
+		},
+		{
+			name:  "set",
+			input: "test.metric:-user-1|s",
+			wantMetric: func() statsDMetric {
+				m := testStatsDMetric("test.metric", 0, false, "s", 0, nil, nil)
+				m.asString = "-user-1"
+				return m
+			}(),
+		},
+		{
+			name:  "container id",
+			input: "test.metric:42|c|#key:value|c:abc123",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				42,
+				false,
+				"c", 0, []string{"key", "container.id"}, []string{"value", "abc123"}),
+		},
 	}
 
 	for _, tt := range tests {
@@ -914,6 +947,120 @@ func TestStatsDParser_AggregateTimerWithSummary(t *testing.T) {
 	}
 }
 
+func TestStatsDParser_AggregateSet(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	p.Initialize(false, false, nil)
+	for _, line := range []string{
+		"users:alice|s|#mykey:myvalue",
+		"users:bob|s|#mykey:myvalue",
+		"users:alice|s|#mykey:myvalue",
+		"users:carol|s|#mykey:othervalue",
+	} {
+		assert.NoError(t, p.Aggregate(line))
+	}
+
+	metrics := p.GetMetrics()
+	ilms := metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics()
+	require.Equal(t, 2, ilms.Len())
+	counts := map[string]int64{}
+	for i := 0; i < ilms.Len(); i++ {
+		m := ilms.At(i).Metrics().At(0)
+		assert.Equal(t, "users", m.Name())
+		require.Equal(t, pdata.MetricDataTypeGauge, m.DataType())
+		dp := m.Gauge().DataPoints().At(0)
+		value, ok := dp.Attributes().Get("mykey")
+		require.True(t, ok)
+		counts[value.StringVal()] = dp.IntVal()
+	}
+	assert.Equal(t, map[string]int64{"myvalue": 2, "othervalue": 1}, counts)
+
+	// Unique values are counted per aggregation interval.
+	assert.Equal(t, 0, p.GetMetrics().ResourceMetrics().At(0).InstrumentationLibraryMetrics().Len())
+}
+
+func TestStatsDParser_AggregateDistribution(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	input := []string{
+		"statsdTestMetric1:1|d|#mykey:myvalue",
+		"statsdTestMetric1:10|d|@0.5|#mykey:myvalue",
+	}
+	expected := map[statsDMetricdescription]summaryMetric{
+		testDescription("statsdTestMetric1", "d",
+			[]string{"mykey"}, []string{"myvalue"}): {
+			name:        "statsdTestMetric1",
+			points:      []float64{1, 10},
+			weights:     []float64{1, 2},
+			labelKeys:   []string{"mykey"},
+			labelValues: []string{"myvalue"},
+		},
+	}
+
+	// Distributions are summarized unless mapped otherwise.
+	p := &StatsDParser{}
+	p.Initialize(false, false, nil)
+	for _, line := range input {
+		assert.NoError(t, p.Aggregate(line))
+	}
+	assert.EqualValues(t, expected, p.summaries)
+	assert.Empty(t, p.expHistograms)
+
+	p = &StatsDParser{}
+	p.Initialize(false, false, []TimerHistogramMapping{{StatsdType: "distribution", ObserverType: "exponential_histogram"}})
+	for _, line := range input {
+		assert.NoError(t, p.Aggregate(line))
+	}
+	assert.EqualValues(t, expected, p.expHistograms)
+	assert.Empty(t, p.summaries)
+}
+
+func TestStatsDParser_AggregateServiceCheck(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	p.Initialize(false, false, nil)
+	assert.NoError(t, p.Aggregate("_sc|my.check|0|#mykey:myvalue"))
+	assert.NoError(t, p.Aggregate("_sc|my.check|2|#mykey:myvalue|m:disk full"))
+
+	expected := map[statsDMetricdescription]pdata.InstrumentationLibraryMetrics{
+		testDescription("my.check", "_sc",
+			[]string{"mykey"}, []string{"myvalue"}): buildGaugeMetric(testStatsDMetric("my.check", 2, false, "_sc", 0, []string{"mykey"}, []string{"myvalue"}), time.Unix(711, 0)),
+	}
+	assert.Equal(t, expected, p.gauges)
+}
+
+func TestStatsDParser_GetLogs(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	p.Initialize(false, false, nil)
+	assert.NoError(t, p.Aggregate("_e{5,4}:title|text|t:error"))
+	assert.NoError(t, p.Aggregate("_e{6,5}:title2|text2"))
+	assert.Error(t, p.Aggregate("_e{6,5}:title|text"))
+
+	// Events are not metrics.
+	assert.Equal(t, 0, p.GetMetrics().ResourceMetrics().At(0).InstrumentationLibraryMetrics().Len())
+
+	logs := p.GetLogs()
+	require.Equal(t, 2, logs.LogRecordCount())
+	records := logs.ResourceLogs().At(0).InstrumentationLibraryLogs().At(0).Logs()
+	assert.Equal(t, "text", records.At(0).Body().StringVal())
+	assert.Equal(t, pdata.SeverityNumberERROR, records.At(0).SeverityNumber())
+	assert.Equal(t, "text2", records.At(1).Body().StringVal())
+
+	assert.Equal(t, 0, p.GetLogs().LogRecordCount())
+}
+
 func TestStatsDParser_Initialize(t *testing.T) {
 	p := &StatsDParser{}
 	p.Initialize(true, false, []TimerHistogramMapping{{StatsdType: "timer", ObserverType: "gauge"}, {StatsdType: "histogram", ObserverType: "gauge"}})
@@ -23,15 +23,19 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenterror"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumerhelper"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
 )
 
 var _ component.MetricsReceiver = (*statsdReceiver)(nil)
+var _ component.LogsReceiver = (*statsdReceiver)(nil)
 
-// statsdReceiver implements the component.MetricsReceiver for StatsD protocol.
+// statsdReceiver implements the component.MetricsReceiver for StatsD protocol,
+// and the component.LogsReceiver for DogStatsD events.
 type statsdReceiver struct {
 	settings component.ReceiverCreateSettings
 	config   *Config
@@ -40,6 +44,7 @@ type statsdReceiver struct {
 	reporter     transport.Reporter
 	parser       protocol.Parser
 	nextConsumer consumer.Metrics
+	logsConsumer consumer.Logs
 	cancel       context.CancelFunc
 }
 
@@ -53,6 +58,20 @@ func New(
 		return nil, componenterror.ErrNilNextConsumer
 	}
 
+	r, err := newStatsdReceiver(set, config)
+	if err != nil {
+		return nil, err
+	}
+	r.nextConsumer = nextConsumer
+	return r, nil
+}
+
+// newStatsdReceiver creates the StatsD receiver, shared by the metrics and
+// logs pipelines which set their consumer.
+func newStatsdReceiver(
+	set component.ReceiverCreateSettings,
+	config Config,
+) (*statsdReceiver, error) {
 	if config.NetAddr.Endpoint == "" {
 		config.NetAddr.Endpoint = "localhost:8125"
 	}
@@ -63,34 +82,45 @@ func New(
 	}
 
 	r := &statsdReceiver{
-		settings:     set,
-		config:       &config,
-		nextConsumer: nextConsumer,
-		server:       server,
-		reporter:     newReporter(config.ID(), set),
-		parser:       &protocol.StatsDParser{},
+		settings: set,
+		config:   &config,
+		server:   server,
+		reporter: newReporter(config.ID(), set),
+		parser:   &protocol.StatsDParser{},
 	}
 	return r, nil
 }
 
 func buildTransportServer(config Config) (transport.Server, error) {
-	// TODO: Add TCP/unix socket transport implementations
 	switch strings.ToLower(config.NetAddr.Transport) {
 	case "", "udp":
 		return transport.NewUDPServer(config.NetAddr.Endpoint)
+	case "tcp":
+		return transport.NewTCPServer(config.NetAddr.Endpoint, config.TCPIdleTimeout)
+	case "unix":
+		return transport.NewUnixServer(config.NetAddr.Endpoint, config.TCPIdleTimeout)
+	case "unixgram":
+		return transport.NewUnixgramServer(config.NetAddr.Endpoint)
 	}
 
 	return nil, fmt.Errorf("unsupported transport %q for receiver %v", config.NetAddr.Transport, config.ID())
 }
 
-// Start starts a UDP server that can process StatsD messages.
+// Start starts a server, on the configured transport, that can process StatsD messages.
 func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 	ctx, r.cancel = context.WithCancel(ctx)
 	var transferChan = make(chan string, 10)
 	ticker := time.NewTicker(r.config.AggregationInterval)
 	r.parser.Initialize(r.config.EnableMetricType, r.config.IsMonotonicCounter, r.config.TimerHistogramMapping)
+	metricsConsumer := r.nextConsumer
+	if metricsConsumer == nil {
+		// Only events are consumed, aggregated metrics are dropped.
+		metricsConsumer, _ = consumerhelper.NewMetrics(func(context.Context, pdata.Metrics) error {
+			return nil
+		})
+	}
 	go func() {
-		if err := r.server.ListenAndServe(r.parser, r.nextConsumer, r.reporter, transferChan); err != nil {
+		if err := r.server.ListenAndServe(r.parser, metricsConsumer, r.reporter, transferChan); err != nil {
 			host.ReportFatalError(err)
 		}
 	}()
@@ -100,7 +130,11 @@ func (r *statsdReceiver) Start(ctx context.Context, host component.Host) error {
 			case <-ticker.C:
 				metrics := r.parser.GetMetrics()
 				if metrics.ResourceMetrics().At(0).InstrumentationLibraryMetrics().Len() > 0 {
-					r.Flush(ctx, metrics, r.nextConsumer)
+					r.Flush(ctx, metrics, metricsConsumer)
+				}
+				logs := r.parser.GetLogs()
+				if r.logsConsumer != nil && logs.LogRecordCount() > 0 {
+					r.flushLogs(ctx, logs)
 				}
 			case rawMetric := <-transferChan:
 				r.parser.Aggregate(rawMetric)
@@ -129,3 +163,11 @@ func (r *statsdReceiver) Flush(ctx context.Context, metrics pdata.Metrics, nextC
 
 	return nil
 }
+
+// flushLogs sends the events to the logs pipeline, only logging the failures
+// as the events aren't kept for a retry.
+func (r *statsdReceiver) flushLogs(ctx context.Context, logs pdata.Logs) {
+	if err := r.logsConsumer.ConsumeLogs(ctx, logs); err != nil {
+		r.settings.Logger.Warn("Failed to send the events", zap.Int("events", logs.LogRecordCount()), zap.Error(err))
+	}
+}
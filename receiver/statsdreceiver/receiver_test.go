@@ -31,6 +31,8 @@ import (
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/model/pdata"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testutil"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
@@ -91,6 +93,27 @@ func TestStatsdReceiver_Flush(t *testing.T) {
 	r.Shutdown(ctx)
 }
 
+func TestStatsdReceiver_FlushLogsFailure(t *testing.T) {
+	core, observed := observer.New(zap.WarnLevel)
+	set := componenttest.NewNopReceiverCreateSettings()
+	set.Logger = zap.New(core)
+	cfg := createDefaultConfig().(*Config)
+	cfg.NetAddr.Endpoint = testutil.GetAvailableLocalAddress(t)
+	r, err := newStatsdReceiver(set, *cfg)
+	require.NoError(t, err)
+	defer r.server.Close()
+	r.logsConsumer = consumertest.NewErr(errors.New("failed"))
+
+	logs := pdata.NewLogs()
+	logs.ResourceLogs().AppendEmpty().InstrumentationLibraryLogs().AppendEmpty().Logs().AppendEmpty()
+	r.flushLogs(context.Background(), logs)
+
+	entries := observed.All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "Failed to send the events", entries[0].Message)
+	assert.Equal(t, "failed", entries[0].ContextMap()["error"])
+}
+
 func Test_statsdreceiver_EndToEnd(t *testing.T) {
 	addr := testutil.GetAvailableLocalAddress(t)
 	host, portStr, err := net.SplitHostPort(addr)
@@ -121,6 +144,25 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 				return c
 			},
 		},
+		{
+			name: "tcp transport with 9s interval",
+			configFn: func() *Config {
+				return &Config{
+					ReceiverSettings: config.NewReceiverSettings(config.NewComponentID(typeStr)),
+					NetAddr: confignet.NetAddr{
+						Endpoint:  defaultBindEndpoint,
+						Transport: "tcp",
+					},
+					AggregationInterval: 9 * time.Second,
+					TCPIdleTimeout:      transport.TCPIdleTimeoutDefault,
+				}
+			},
+			clientFn: func(t *testing.T) *client.StatsD {
+				c, err := client.NewStatsD(client.TCP, host, port)
+				require.NoError(t, err)
+				return c
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -146,6 +188,7 @@ func Test_statsdreceiver_EndToEnd(t *testing.T) {
 			}
 			err = statsdClient.SendMetric(statsdMetric)
 			require.NoError(t, err)
+			defer statsdClient.Disconnect()
 
 			time.Sleep(10 * time.Second)
 			mdd := sink.AllMetrics()
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
 )
 
 // StatsD defines the properties of a StatsD connection.
@@ -35,6 +36,10 @@ const (
 	TCP Transport = iota
 	// UDP Transport
 	UDP
+	// Unix Transport, a stream-oriented unix domain socket at Host
+	Unix
+	// Unixgram Transport, a datagram-oriented unix domain socket at Host
+	Unixgram
 )
 
 // NewStatsD creates a new StatsD instance to support the need for testing
@@ -58,13 +63,15 @@ func (s *StatsD) connect(transport Transport) error {
 		cl.Close()
 	}
 
-	address := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	address := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
 
 	var err error
 	switch transport {
 	case TCP:
-		// TODO: implement TCP support
-		return fmt.Errorf("TCP unsupported")
+		s.Conn, err = net.Dial("tcp", address)
+		if err != nil {
+			return err
+		}
 	case UDP:
 		var udpAddr *net.UDPAddr
 		udpAddr, err = net.ResolveUDPAddr("udp", address)
@@ -75,6 +82,16 @@ func (s *StatsD) connect(transport Transport) error {
 		if err != nil {
 			return err
 		}
+	case Unix:
+		s.Conn, err = net.Dial("unix", s.Host)
+		if err != nil {
+			return err
+		}
+	case Unixgram:
+		s.Conn, err = net.Dial("unixgram", s.Host)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unknown transport: %d", transport)
 	}
@@ -94,7 +111,7 @@ func (s *StatsD) Disconnect() error {
 
 // SendMetric sends the input metric to the StatsD connection.
 func (s *StatsD) SendMetric(metric Metric) error {
-	_, err := fmt.Fprint(s.Conn, metric.String())
+	_, err := fmt.Fprintln(s.Conn, metric.String())
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,194 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+const (
+	// TCPIdleTimeoutDefault is the default timeout for idle TCP connections.
+	TCPIdleTimeoutDefault = 30 * time.Second
+)
+
+// tcpServer serves newline-delimited StatsD messages over a stream-oriented
+// transport: TCP or unix domain sockets.
+type tcpServer struct {
+	ln          net.Listener
+	wg          sync.WaitGroup
+	idleTimeout time.Duration
+	reporter    Reporter
+}
+
+var _ Server = (*tcpServer)(nil)
+
+// NewTCPServer creates a transport.Server using TCP as its transport.
+func NewTCPServer(addr string, idleTimeout time.Duration) (Server, error) {
+	return newStreamServer("tcp", addr, idleTimeout)
+}
+
+// NewUnixServer creates a transport.Server using a stream-oriented unix
+// domain socket, created at the given path, as its transport.
+func NewUnixServer(path string, idleTimeout time.Duration) (Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+	return newStreamServer("unix", path, idleTimeout)
+}
+
+func newStreamServer(network string, addr string, idleTimeout time.Duration) (Server, error) {
+	if idleTimeout < 0 {
+		return nil, fmt.Errorf("invalid idle timeout: %v", idleTimeout)
+	}
+
+	if idleTimeout == 0 {
+		idleTimeout = TCPIdleTimeoutDefault
+	}
+
+	ln, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	t := tcpServer{
+		ln:          ln,
+		idleTimeout: idleTimeout,
+	}
+	return &t, nil
+}
+
+func (t *tcpServer) ListenAndServe(
+	parser protocol.Parser,
+	nextConsumer consumer.Metrics,
+	reporter Reporter,
+	transferChan chan<- string,
+) error {
+	if parser == nil || nextConsumer == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	acceptedConnMap := make(map[net.Conn]struct{})
+	connMapMtx := &sync.Mutex{}
+
+	t.reporter = reporter
+	var err error
+	for {
+		conn, acceptErr := t.ln.Accept()
+		if acceptErr == nil {
+			connMapMtx.Lock()
+			acceptedConnMap[conn] = struct{}{}
+			connMapMtx.Unlock()
+			t.wg.Add(1)
+			go func(c net.Conn) {
+				t.handleConnection(c, transferChan)
+				connMapMtx.Lock()
+				delete(acceptedConnMap, c)
+				connMapMtx.Unlock()
+				t.wg.Done()
+			}(conn)
+			continue
+		}
+
+		if netErr, ok := acceptErr.(net.Error); ok {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - Accept (temporary=%v) net.Error: %v",
+				t.ln.Addr().String(),
+				netErr.Temporary(),
+				netErr)
+			if netErr.Temporary() {
+				continue
+			}
+		}
+
+		err = acceptErr
+		break
+	}
+
+	t.reporter.OnDebugf(
+		"TCP Transport (%s) exiting Accept loop error: %v",
+		t.ln.Addr().String(),
+		err)
+
+	// Close any lingering connection
+	connMapMtx.Lock()
+	for conn := range acceptedConnMap {
+		conn.Close()
+	}
+	connMapMtx.Unlock()
+
+	return err
+}
+
+func (t *tcpServer) Close() error {
+	err := t.ln.Close()
+	t.wg.Wait()
+	return err
+}
+
+func (t *tcpServer) handleConnection(
+	conn net.Conn,
+	transferChan chan<- string,
+) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(t.idleTimeout)); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - conn.SetDeadLine error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		// reader.ReadBytes call below will block until either:
+		//
+		// * a '\n' char is read
+		// * the connection is closed (either by client or server)
+		// * an idle timeout happens (see call to conn.SetDeadline above)
+		//
+		// Notice that it is possible for the function to return with error at
+		// the same time that it returns data (typically the error is io.EOF in
+		// this case).
+		bytes, err := reader.ReadBytes((byte)('\n'))
+
+		line := strings.TrimSpace(string(bytes))
+		if line != "" {
+			transferChan <- line
+		}
+
+		if err == io.EOF {
+			return
+		}
+
+		if err != nil {
+			// We want to end on timeout so idle connections are purged.
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - read error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+	}
+}
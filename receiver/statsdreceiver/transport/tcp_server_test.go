@@ -0,0 +1,173 @@
+// Copyright 2020, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+func Test_StreamServer_ListenAndServe(t *testing.T) {
+	tests := []struct {
+		name          string
+		buildServerFn func(t *testing.T) (Server, string, string)
+	}{
+		{
+			name: "tcp",
+			buildServerFn: func(t *testing.T) (Server, string, string) {
+				addr := testutil.GetAvailableLocalAddress(t)
+				srv, err := NewTCPServer(addr, time.Second)
+				require.NoError(t, err)
+				return srv, "tcp", addr
+			},
+		},
+		{
+			name: "unix",
+			buildServerFn: func(t *testing.T) (Server, string, string) {
+				path := filepath.Join(t.TempDir(), "statsd.sock")
+				srv, err := NewUnixServer(path, time.Second)
+				require.NoError(t, err)
+				return srv, "unix", path
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv, network, addr := tt.buildServerFn(t)
+			transferChan := make(chan string, 10)
+
+			wg := sync.WaitGroup{}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.Error(t, srv.ListenAndServe(&protocol.StatsDParser{}, consumertest.NewNop(), NewMockReporter(1), transferChan))
+			}()
+
+			conn, err := net.Dial(network, addr)
+			require.NoError(t, err)
+
+			// A message split across writes and a trailing line without a
+			// newline must both be delivered.
+			_, err = conn.Write([]byte("test.metric:42|c\ntest.me"))
+			require.NoError(t, err)
+			_, err = conn.Write([]byte("tric:1|g\n\ntest.metric:7|ms"))
+			require.NoError(t, err)
+			require.NoError(t, conn.Close())
+
+			assert.Equal(t, "test.metric:42|c", receive(t, transferChan))
+			assert.Equal(t, "test.metric:1|g", receive(t, transferChan))
+			assert.Equal(t, "test.metric:7|ms", receive(t, transferChan))
+
+			assert.NoError(t, srv.Close())
+			wg.Wait()
+		})
+	}
+}
+
+func Test_TCPServer_IdleTimeout(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	srv, err := NewTCPServer(addr, 100*time.Millisecond)
+	require.NoError(t, err)
+
+	go func() {
+		_ = srv.ListenAndServe(&protocol.StatsDParser{}, consumertest.NewNop(), NewMockReporter(1), make(chan string, 10))
+	}()
+	defer srv.Close()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	// The server closes the idle connection, which the client observes as
+	// the end of the stream.
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, err = conn.Read(make([]byte, 1))
+	assert.Error(t, err)
+	if netErr, ok := err.(net.Error); ok {
+		assert.False(t, netErr.Timeout(), "connection was not closed by the server")
+	}
+}
+
+func Test_NewTCPServer_InvalidIdleTimeout(t *testing.T) {
+	_, err := NewTCPServer(testutil.GetAvailableLocalAddress(t), -time.Second)
+	assert.Error(t, err)
+}
+
+func Test_UnixgramServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statsd.sock")
+
+	// A socket left over by a previous run must not prevent listening.
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	require.NoError(t, err)
+	stale.SetUnlinkOnClose(false)
+	require.NoError(t, stale.Close())
+
+	srv, err := NewUnixgramServer(path)
+	require.NoError(t, err)
+	transferChan := make(chan string, 10)
+
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		assert.Error(t, srv.ListenAndServe(&protocol.StatsDParser{}, consumertest.NewNop(), NewMockReporter(1), transferChan))
+	}()
+
+	conn, err := net.Dial("unixgram", path)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("test.metric:42|c\ntest.metric:1|g"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	assert.Equal(t, "test.metric:42|c", receive(t, transferChan))
+	assert.Equal(t, "test.metric:1|g", receive(t, transferChan))
+
+	assert.NoError(t, srv.Close())
+	wg.Wait()
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "socket file was not removed")
+}
+
+func Test_NewUnixServer_ExistingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "statsd.sock")
+	require.NoError(t, os.WriteFile(path, []byte("not a socket"), 0600))
+
+	_, err := NewUnixServer(path, time.Second)
+	assert.Error(t, err)
+	_, err = NewUnixgramServer(path)
+	assert.Error(t, err)
+}
+
+func receive(t *testing.T, ch <-chan string) string {
+	select {
+	case line := <-ch:
+		return line
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for message")
+		return ""
+	}
+}
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"io"
 	"net"
+	"os"
 	"strings"
 
 	"go.opentelemetry.io/collector/consumer"
@@ -25,9 +26,14 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
+// udpServer serves StatsD messages over a datagram-oriented transport:
+// UDP or unix domain sockets.
 type udpServer struct {
 	packetConn net.PacketConn
 	reporter   Reporter
+
+	// the path of the unix domain socket, removed upon close
+	socketPath string
 }
 
 var _ (Server) = (*udpServer)(nil)
@@ -45,6 +51,25 @@ func NewUDPServer(addr string) (Server, error) {
 	return &u, nil
 }
 
+// NewUnixgramServer creates a transport.Server using a datagram-oriented unix
+// domain socket, created at the given path, as its transport.
+func NewUnixgramServer(path string) (Server, error) {
+	if err := removeStaleSocket(path); err != nil {
+		return nil, err
+	}
+
+	packetConn, err := net.ListenPacket("unixgram", path)
+	if err != nil {
+		return nil, err
+	}
+
+	u := udpServer{
+		packetConn: packetConn,
+		socketPath: path,
+	}
+	return &u, nil
+}
+
 func (u *udpServer) ListenAndServe(
 	parser protocol.Parser,
 	nextConsumer consumer.Metrics,
@@ -80,7 +105,13 @@ func (u *udpServer) ListenAndServe(
 }
 
 func (u *udpServer) Close() error {
-	return u.packetConn.Close()
+	err := u.packetConn.Close()
+	if u.socketPath != "" {
+		if rmErr := os.Remove(u.socketPath); rmErr != nil && !os.IsNotExist(rmErr) && err == nil {
+			err = rmErr
+		}
+	}
+	return err
 }
 
 func (u *udpServer) handlePacket(